@@ -11,6 +11,15 @@ type Core interface {
 	RunQuery(*query.Query, Command)
 	Reflect(ReflectionType, Command)
 	Replicate([]crdt.Link, Command)
+	Tag(TagRequest, Command)
+	// Revert makes a past index HEAD.
+	Revert(crdt.IPFSPath, Command)
+	// Batch applies several join and delete queries as one change.
+	Batch([]*query.Query, Command)
+	// Compact merges the namespaces behind each table in the index.
+	Compact(Command)
+	// WatchIndex signals each time the index changes, until stop is closed.
+	WatchIndex(stop <-chan struct{}) <-chan struct{}
 	WriteMemoryImage() error
 	Close()
 }
@@ -18,12 +27,20 @@ type Core interface {
 type Service interface {
 	CloserService
 	RequestService
+	WatchService
 }
 
 type RequestService interface {
 	Call(Request) (<-chan Response, error)
 }
 
+// WatchService streams the results of a watch request.  The first Response
+// holds the results, and a new Response with the changes is sent each time
+// they change, until stop is closed.
+type WatchService interface {
+	Watch(request Request, stop <-chan struct{}) (<-chan Response, error)
+}
+
 type CloserService interface {
 	CloseAPI()
 }
@@ -50,6 +67,36 @@ func (replicator coreReplicator) Run(kvn Core, kvq Command) {
 	kvn.Replicate(replicator.links, kvq)
 }
 
+type coreTagRunner struct {
+	tag TagRequest
+}
+
+func (tagRunner coreTagRunner) Run(core Core, command Command) {
+	core.Tag(tagRunner.tag, command)
+}
+
+type coreReverter struct {
+	target crdt.IPFSPath
+}
+
+func (reverter coreReverter) Run(core Core, command Command) {
+	core.Revert(reverter.target, command)
+}
+
+type coreBatchRunner struct {
+	queries []*query.Query
+}
+
+func (batchRunner coreBatchRunner) Run(core Core, command Command) {
+	core.Batch(batchRunner.queries, command)
+}
+
+type coreCompactor struct{}
+
+func (compactor coreCompactor) Run(core Core, command Command) {
+	core.Compact(command)
+}
+
 type coreQueryRunner struct {
 	query *query.Query
 }
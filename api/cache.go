@@ -18,6 +18,14 @@ type Cache interface {
 type HeadCache interface {
 	SetHead(head crdt.IPFSPath) error
 	GetHead() (crdt.IPFSPath, error)
+	SetTag(tag Tag) error
+	GetTag(name string) (Tag, error)
+	// GetTags lists all tags, sorted by name.
+	GetTags() ([]Tag, error)
+	// SetQuarantine and GetQuarantine hold the namespace links that peers
+	// may not bring back into HEAD.
+	SetQuarantine(quarantine crdt.Index) error
+	GetQuarantine() (crdt.Index, error)
 }
 
 type RequestPriorityQueue interface {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -14,17 +15,27 @@ func GenResponse(rand *rand.Rand, size int) Response {
 
 	gen.Msg = testutil.RandLetters(rand, size)
 
-	if rand.Float32() < 0.5 {
+	branch := rand.Float32()
+	if branch < 0.4 {
 		gen.Type = API_QUERY
-	} else {
+	} else if branch < 0.8 {
 		gen.Type = API_REFLECT
+	} else if branch < 0.9 {
+		gen.Type = API_TAG
+	} else {
+		gen.Type = API_PREPARE
 	}
 
 	if rand.Float32() < 0.5 {
-		if gen.Type == API_QUERY {
+		switch gen.Type {
+		case API_QUERY:
 			genQueryResponse(rand, size, &gen)
-		} else {
+		case API_REFLECT:
 			genReflectResponse(rand, size, &gen)
+		case API_TAG:
+			genTagResponse(rand, size, &gen)
+		case API_PREPARE:
+			gen.Statement = testutil.RandLettersRange(rand, 1, size)
 		}
 	} else {
 		errText := testutil.RandLettersRange(rand, 1, size)
@@ -38,19 +49,130 @@ func genQueryResponse(rand *rand.Rand, size int, gen *Response) {
 	ns := crdt.GenNamespace(rand, size)
 	gen.Namespace = ns
 	gen.Path = genResponsePath(rand, size)
+
+	ns.ForeachRow(func(t crdt.TableName, r crdt.RowName, row crdt.Row) {
+		gen.RowOrder = append(gen.RowOrder, r)
+	})
+
+	if rand.Float32() < 0.3 {
+		removedCount := testutil.GenCountRange(rand, 1, size)
+		for i := 0; i < removedCount; i++ {
+			gen.RemovedRows = append(gen.RemovedRows, crdt.RowName(testutil.RandLettersRange(rand, 1, size)))
+		}
+	}
+
+	if rand.Float32() < 0.3 {
+		gen.Table = genResultTable(rand, size)
+	}
+
+	if rand.Float32() < 0.2 {
+		gen.Plan = genQueryPlan(rand, size)
+	}
+}
+
+func genQueryPlan(rand *rand.Rand, size int) QueryPlan {
+	gen := QueryPlan{RowsEvaluated: uint32(rand.Intn(size + 1))}
+
+	linkCount := testutil.GenCountRange(rand, 1, size)
+	for i := 0; i < linkCount; i++ {
+		path := genResponsePath(rand, size)
+		gen.IndexLinks = append(gen.IndexLinks, path)
+
+		load := NamespaceLoad{
+			Path:   path,
+			Source: NamespaceSource(rand.Intn(3)),
+			Failed: rand.Float32() < 0.2,
+		}
+		gen.Loads = append(gen.Loads, load)
+	}
+
+	phaseCount := testutil.GenCountRange(rand, 1, size)
+	for i := 0; i < phaseCount; i++ {
+		phase := PlanPhase{
+			Name:     testutil.RandLettersRange(rand, 1, size),
+			Duration: time.Duration(rand.Int63()),
+		}
+		gen.Phases = append(gen.Phases, phase)
+	}
+
+	return gen
+}
+
+func genResultTable(rand *rand.Rand, size int) ResultTable {
+	gen := ResultTable{}
+
+	columnCount := testutil.GenCountRange(rand, 1, size)
+	for i := 0; i < columnCount; i++ {
+		gen.Columns = append(gen.Columns, testutil.RandLettersRange(rand, 1, size))
+	}
+
+	rowCount := testutil.GenCountRange(rand, 0, size)
+	for i := 0; i < rowCount; i++ {
+		row := make([]string, columnCount)
+		for j := range row {
+			row[j] = testutil.RandLetters(rand, size)
+		}
+		gen.Rows = append(gen.Rows, row)
+	}
+
+	return gen
 }
 
 func genReflectResponse(rand *rand.Rand, size int, gen *Response) {
 	branch := rand.Float32()
-	if branch < 0.333 {
+	if branch < 0.25 {
 		gen.Path = genResponsePath(rand, size)
-	} else if branch < 0.666 {
+	} else if branch < 0.5 {
 		gen.Namespace = crdt.GenNamespace(rand, size)
-	} else {
+	} else if branch < 0.75 {
 		gen.Index = crdt.GenIndex(rand, size)
+	} else {
+		gen.History = genIndexHistory(rand, size)
 	}
 }
 
+func genTagResponse(rand *rand.Rand, size int, gen *Response) {
+	if rand.Float32() < 0.5 {
+		gen.Path = genResponsePath(rand, size)
+		return
+	}
+
+	tagCount := testutil.GenCountRange(rand, 0, size)
+	for i := 0; i < tagCount; i++ {
+		tag := Tag{
+			Name: testutil.RandLettersRange(rand, 1, size),
+			Path: genResponsePath(rand, size),
+		}
+		gen.Tags = append(gen.Tags, tag)
+	}
+}
+
+func genIndexHistory(rand *rand.Rand, size int) []IndexLogEntry {
+	gen := []IndexLogEntry{}
+
+	entryCount := testutil.GenCountRange(rand, 1, size)
+	for i := 0; i < entryCount; i++ {
+		entry := IndexLogEntry{
+			Path:    genResponsePath(rand, size),
+			Created: time.Unix(0, rand.Int63()),
+		}
+
+		if i < entryCount-1 {
+			entry.Parents = []crdt.IPFSPath{genResponsePath(rand, size)}
+		}
+
+		tableCount := testutil.GenCountRange(rand, 0, size)
+		for j := 0; j < tableCount; j++ {
+			table := crdt.TableName(testutil.RandLettersRange(rand, 1, size))
+			entry.ChangedTables = append(entry.ChangedTables, table)
+		}
+
+		gen = append(gen, entry)
+	}
+
+	return gen
+}
+
 func genResponsePath(rand *rand.Rand, size int) crdt.IPFSPath {
 	return crdt.IPFSPath(testutil.RandLettersRange(rand, 1, size))
 }
@@ -6,6 +6,7 @@ import (
 
 type Client interface {
 	Send(request Request) (Response, error)
+	Watch(request Request, stop <-chan struct{}) (<-chan Response, error)
 }
 
 type WebService interface {
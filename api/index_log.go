@@ -0,0 +1,96 @@
+package api
+
+import (
+	"time"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// IndexLogEntry describes one index in the history chain.
+type IndexLogEntry struct {
+	Path    crdt.IPFSPath
+	Created time.Time
+	Parents []crdt.IPFSPath
+	// ChangedTables lists the tables that differ from the first parent.
+	ChangedTables []crdt.TableName
+}
+
+func MakeIndexLogEntry(path crdt.IPFSPath, index crdt.Index, parent crdt.Index) IndexLogEntry {
+	entry := IndexLogEntry{
+		Path:          path,
+		Parents:       index.Parents,
+		ChangedTables: index.ChangedTables(parent),
+	}
+
+	if index.Created != 0 {
+		entry.Created = time.Unix(0, index.Created)
+	}
+
+	return entry
+}
+
+func (entry IndexLogEntry) Equals(other IndexLogEntry) bool {
+	ok := entry.Path == other.Path
+	ok = ok && entry.Created.Equal(other.Created)
+	ok = ok && len(entry.Parents) == len(other.Parents)
+	ok = ok && len(entry.ChangedTables) == len(other.ChangedTables)
+
+	if !ok {
+		return false
+	}
+
+	for i, parent := range entry.Parents {
+		if parent != other.Parents[i] {
+			return false
+		}
+	}
+
+	for i, table := range entry.ChangedTables {
+		if table != other.ChangedTables[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func makeIndexLogEntryMessage(entry IndexLogEntry) *proto.IndexLogEntryMessage {
+	message := &proto.IndexLogEntryMessage{
+		Path:          string(entry.Path),
+		Parents:       make([]string, len(entry.Parents)),
+		ChangedTables: make([]string, len(entry.ChangedTables)),
+	}
+
+	if !entry.Created.IsZero() {
+		message.Created = entry.Created.UnixNano()
+	}
+
+	for i, parent := range entry.Parents {
+		message.Parents[i] = string(parent)
+	}
+
+	for i, table := range entry.ChangedTables {
+		message.ChangedTables[i] = string(table)
+	}
+
+	return message
+}
+
+func readIndexLogEntryMessage(message *proto.IndexLogEntryMessage) IndexLogEntry {
+	entry := IndexLogEntry{Path: crdt.IPFSPath(message.Path)}
+
+	if message.Created != 0 {
+		entry.Created = time.Unix(0, message.Created)
+	}
+
+	for _, parent := range message.Parents {
+		entry.Parents = append(entry.Parents, crdt.IPFSPath(parent))
+	}
+
+	for _, table := range message.ChangedTables {
+		entry.ChangedTables = append(entry.ChangedTables, crdt.TableName(table))
+	}
+
+	return entry
+}
@@ -7,5 +7,7 @@ import (
 type MemoryImage interface {
 	JoinIndex(index crdt.Index) error
 	GetIndex() (crdt.Index, error)
+	// ResetIndex replaces the joined index.
+	ResetIndex(index crdt.Index) error
 	CloseMemoryImage() error
 }
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"time"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// QueryPlan reports how an explained select was run.
+type QueryPlan struct {
+	// IndexLinks are the namespace addresses chosen from the index.
+	IndexLinks    []crdt.IPFSPath
+	Loads         []NamespaceLoad
+	RowsEvaluated uint32
+	Phases        []PlanPhase
+}
+
+type NamespaceLoad struct {
+	Path   crdt.IPFSPath
+	Source NamespaceSource
+	Failed bool
+}
+
+type PlanPhase struct {
+	Name     string
+	Duration time.Duration
+}
+
+func (plan QueryPlan) IsEmpty() bool {
+	return plan.Equals(QueryPlan{})
+}
+
+func (plan QueryPlan) Equals(other QueryPlan) bool {
+	ok := plan.RowsEvaluated == other.RowsEvaluated
+	ok = ok && len(plan.IndexLinks) == len(other.IndexLinks)
+	ok = ok && len(plan.Loads) == len(other.Loads)
+	ok = ok && len(plan.Phases) == len(other.Phases)
+
+	if !ok {
+		return false
+	}
+
+	for i, link := range plan.IndexLinks {
+		if link != other.IndexLinks[i] {
+			return false
+		}
+	}
+
+	for i, load := range plan.Loads {
+		if load != other.Loads[i] {
+			return false
+		}
+	}
+
+	for i, phase := range plan.Phases {
+		if phase != other.Phases[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NamespacesLoaded counts the namespaces loaded from each source.
+func (plan QueryPlan) NamespacesLoaded(source NamespaceSource) int {
+	count := 0
+
+	for _, load := range plan.Loads {
+		if load.Source == source && !load.Failed {
+			count++
+		}
+	}
+
+	return count
+}
+
+func makeQueryPlanMessage(plan QueryPlan) *proto.QueryPlanMessage {
+	message := &proto.QueryPlanMessage{
+		IndexLinks:    make([]string, len(plan.IndexLinks)),
+		Loads:         make([]*proto.NamespaceLoadMessage, len(plan.Loads)),
+		RowsEvaluated: plan.RowsEvaluated,
+		Phases:        make([]*proto.PlanPhaseMessage, len(plan.Phases)),
+	}
+
+	for i, link := range plan.IndexLinks {
+		message.IndexLinks[i] = string(link)
+	}
+
+	for i, load := range plan.Loads {
+		message.Loads[i] = &proto.NamespaceLoadMessage{
+			Path:   string(load.Path),
+			Source: uint32(load.Source),
+			Failed: load.Failed,
+		}
+	}
+
+	for i, phase := range plan.Phases {
+		message.Phases[i] = &proto.PlanPhaseMessage{
+			Name:        phase.Name,
+			Nanoseconds: int64(phase.Duration),
+		}
+	}
+
+	return message
+}
+
+func readQueryPlanMessage(message *proto.QueryPlanMessage) QueryPlan {
+	plan := QueryPlan{RowsEvaluated: message.RowsEvaluated}
+
+	for _, link := range message.IndexLinks {
+		plan.IndexLinks = append(plan.IndexLinks, crdt.IPFSPath(link))
+	}
+
+	for _, load := range message.Loads {
+		plan.Loads = append(plan.Loads, NamespaceLoad{
+			Path:   crdt.IPFSPath(load.Path),
+			Source: NamespaceSource(load.Source),
+			Failed: load.Failed,
+		})
+	}
+
+	for _, phase := range message.Phases {
+		plan.Phases = append(plan.Phases, PlanPhase{
+			Name:     phase.Name,
+			Duration: time.Duration(phase.Nanoseconds),
+		})
+	}
+
+	return plan
+}
@@ -8,6 +8,8 @@ import (
 type RemoteNamespace interface {
 	JoinTable(crdt.TableName, crdt.Table) (crdt.IPFSPath, error)
 	LoadTraverse(searcher NamespaceSearcher) error
+	// LoadTraverseIndex searches a past index, rather than the current one.
+	LoadTraverseIndex(indexAddr crdt.IPFSPath, searcher NamespaceSearcher) error
 }
 
 type RemoteNamespaceCore interface {
@@ -19,6 +21,28 @@ type SearchResult struct {
 	Namespace            crdt.Namespace
 	NamespaceLoadFailure bool
 	IndexLoadFailure     bool
+	// Path and Source describe where the Namespace was loaded from.
+	Path   crdt.IPFSPath
+	Source NamespaceSource
+}
+
+type NamespaceSource uint8
+
+const (
+	SOURCE_UNKNOWN = NamespaceSource(iota)
+	SOURCE_CACHE
+	SOURCE_IPFS
+)
+
+func (source NamespaceSource) String() string {
+	switch source {
+	case SOURCE_CACHE:
+		return "cache"
+	case SOURCE_IPFS:
+		return "ipfs"
+	default:
+		return "unknown"
+	}
 }
 
 type SearchResultTraverser interface {
@@ -39,10 +63,23 @@ type NamespaceSearcher interface {
 	SearchResultTraverser
 }
 
+// RowSearch is implemented by searchers that need only some rows of each
+// table.  Sharded namespaces are then loaded in part.
+type RowSearch interface {
+	// SearchRows is nil when every row is needed.
+	SearchRows() []crdt.RowName
+}
+
 type SignedTableSearcher struct {
 	Reader SearchResultTraverser
 	Tables []crdt.TableName
 	Keys   []crypto.PublicKey
+	// Rows is optional.  If set, only these rows are needed.
+	Rows []crdt.RowName
+}
+
+func (searcher SignedTableSearcher) SearchRows() []crdt.RowName {
+	return searcher.Rows
 }
 
 func (searcher SignedTableSearcher) ReadSearchResult(result SearchResult) TraversalUpdate {
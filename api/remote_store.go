@@ -9,6 +9,8 @@ type RemoteStore interface {
 	AddNamespace(crdt.Namespace) (crdt.IPFSPath, error)
 	AddIndex(crdt.Index) (crdt.IPFSPath, error)
 	CatNamespace(crdt.IPFSPath) (crdt.Namespace, error)
+	// CatNamespaceRows loads at least the given rows of the namespace.
+	CatNamespaceRows(crdt.IPFSPath, []crdt.RowName) (crdt.Namespace, error)
 	CatIndex(crdt.IPFSPath) (crdt.Index, error)
 	SubscribeAddrStream(topic PubSubTopic) (<-chan crdt.Link, <-chan error)
 	PublishAddr(addr crdt.Link, topics []PubSubTopic) error
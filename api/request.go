@@ -10,6 +10,7 @@ import (
 
 	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/function"
+	"github.com/johnny-morrice/godless/internal/testutil"
 	"github.com/johnny-morrice/godless/internal/util"
 	"github.com/johnny-morrice/godless/proto"
 	"github.com/johnny-morrice/godless/query"
@@ -20,6 +21,17 @@ type Request struct {
 	Reflection ReflectionType
 	Query      *query.Query
 	Replicate  []crdt.Link
+	Tag        TagRequest
+	// Revert is the address of the index to make HEAD.
+	Revert crdt.IPFSPath
+	// Prepare is the text of a query to hold on the server.
+	Prepare string
+	Execute ExecuteRequest
+	// Batch holds join and delete queries that are applied as one change.
+	Batch []*query.Query
+	// LogLimit is the most entries returned by an index log reflection.
+	// DEFAULT_LOG_LIMIT is used when it is zero.
+	LogLimit uint32
 }
 
 func MakeQueryRequest(query *query.Query) Request {
@@ -36,6 +48,72 @@ func MakeReflectRequest(reflection ReflectionType) Request {
 	}
 }
 
+// MakeIndexLogRequest asks for at most limit entries of the index history.
+func MakeIndexLogRequest(limit uint32) Request {
+	request := MakeReflectRequest(REFLECT_INDEX_LOG)
+	request.LogLimit = limit
+	return request
+}
+
+// MakeWatchRequest asks for the results of a select query each time they change.
+func MakeWatchRequest(query *query.Query) Request {
+	return Request{
+		Type:  API_WATCH,
+		Query: query,
+	}
+}
+
+func MakeTagRequest(tag TagRequest) Request {
+	return Request{
+		Type: API_TAG,
+		Tag:  tag,
+	}
+}
+
+func MakeRevertRequest(target crdt.IPFSPath) Request {
+	return Request{
+		Type:   API_REVERT,
+		Revert: target,
+	}
+}
+
+// MakePrepareRequest asks the server to hold a query, so that it can be run
+// by handle.  The handle is in Response.Statement.
+func MakePrepareRequest(source string) Request {
+	return Request{
+		Type:    API_PREPARE,
+		Prepare: source,
+	}
+}
+
+// MakeExecuteRequest runs a prepared statement with the bind values.
+func MakeExecuteRequest(statement string, variables ...interface{}) Request {
+	return Request{
+		Type: API_EXECUTE,
+		Execute: ExecuteRequest{
+			Statement: statement,
+			Variables: variables,
+		},
+	}
+}
+
+// MakeBatchRequest applies the join and delete queries as one change, so
+// that readers see all of them or none of them.
+func MakeBatchRequest(queries []*query.Query) Request {
+	return Request{
+		Type:  API_BATCH,
+		Batch: queries,
+	}
+}
+
+// MakeCompactRequest asks the server to merge the namespaces behind each
+// table, publishing a smaller index.
+func MakeCompactRequest() Request {
+	return Request{
+		Type: API_COMPACT,
+	}
+}
+
 func MakeReplicateRequest(replicate []crdt.Link) Request {
 	return Request{
 		Type:      API_REPLICATE,
@@ -51,6 +129,16 @@ func (request Request) MakeCommand() (Command, error) {
 		return makeApiQuery(request, coreReflectRunner{reflection: request.Reflection}), nil
 	case API_REPLICATE:
 		return makeApiQuery(request, coreReplicator{links: request.Replicate}), nil
+	case API_TAG:
+		return makeApiQuery(request, coreTagRunner{tag: request.Tag}), nil
+	case API_REVERT:
+		return makeApiQuery(request, coreReverter{target: request.Revert}), nil
+	case API_BATCH:
+		return makeApiQuery(request, coreBatchRunner{queries: request.Batch}), nil
+	case API_COMPACT:
+		return makeApiQuery(request, coreCompactor{}), nil
+	case API_PREPARE, API_EXECUTE:
+		return Command{}, fmt.Errorf("Prepared statements are run by the service, not the Core")
 	default:
 		return Command{}, fmt.Errorf("Invalid request.Type: %d", request.Type)
 	}
@@ -59,7 +147,13 @@ func (request Request) MakeCommand() (Command, error) {
 func (request Request) Equals(other Request) bool {
 	ok := request.Type == other.Type
 	ok = ok && request.Reflection == other.Reflection
+	ok = ok && request.Tag.Equals(other.Tag)
+	ok = ok && request.Revert == other.Revert
+	ok = ok && request.Prepare == other.Prepare
+	ok = ok && request.LogLimit == other.LogLimit
+	ok = ok && request.Execute.Equals(other.Execute)
 	ok = ok && len(request.Replicate) == len(other.Replicate)
+	ok = ok && len(request.Batch) == len(other.Batch)
 	ok = ok && (request.Query == nil) == (other.Query == nil)
 
 	if !ok {
@@ -74,6 +168,12 @@ func (request Request) Equals(other Request) bool {
 		}
 	}
 
+	for i, myQuery := range request.Batch {
+		if !myQuery.Equals(other.Batch[i]) {
+			return false
+		}
+	}
+
 	if request.Query != nil {
 		return request.Query.Equals(other.Query)
 	}
@@ -109,6 +209,20 @@ func (request Request) Validate(validator RequestValidator) error {
 		return request.validateReflect()
 	case API_REPLICATE:
 		return request.validateReplicate()
+	case API_WATCH:
+		return request.validateWatch(validator)
+	case API_TAG:
+		return request.Tag.validate()
+	case API_REVERT:
+		return request.validateRevert()
+	case API_PREPARE:
+		return request.validatePrepare()
+	case API_EXECUTE:
+		return request.Execute.validate()
+	case API_BATCH:
+		return request.validateBatch(validator)
+	case API_COMPACT:
+		return nil
 	default:
 		return fmt.Errorf("Invalid MessageType: %v", request.Type)
 	}
@@ -128,11 +242,57 @@ func (request Request) validateQuery(validator RequestValidator) error {
 	return errors.Wrap(err, failMsg)
 }
 
+func (request Request) validateWatch(validator RequestValidator) error {
+	if request.Query == nil {
+		return fmt.Errorf("Query was nil")
+	}
+
+	if request.Query.OpCode != query.SELECT {
+		return fmt.Errorf("Only select queries can be watched")
+	}
+
+	if request.Query.Select.Explain {
+		return fmt.Errorf("Explain queries cannot be watched")
+	}
+
+	return request.validateQuery(validator)
+}
+
+func (request Request) validateBatch(validator RequestValidator) error {
+	const failMsg = "Request.validateBatch failed"
+
+	if len(request.Batch) == 0 {
+		return fmt.Errorf("Empty batch")
+	}
+
+	for i, q := range request.Batch {
+		if q == nil {
+			return fmt.Errorf("Query %d in batch was nil", i)
+		}
+
+		switch q.OpCode {
+		case query.JOIN:
+		case query.DELETE:
+		default:
+			return fmt.Errorf("Query %d in batch was not a join or delete", i)
+		}
+
+		err := q.Validate(validator.QueryValidationContext())
+
+		if err != nil {
+			return errors.Wrap(err, failMsg)
+		}
+	}
+
+	return nil
+}
+
 func (request Request) validateReflect() error {
 	switch request.Reflection {
 	case REFLECT_HEAD_PATH:
 	case REFLECT_DUMP_NAMESPACE:
 	case REFLECT_INDEX:
+	case REFLECT_INDEX_LOG:
 	default:
 		return fmt.Errorf("Invalid ReflectionType: %v", request.Reflection)
 	}
@@ -140,6 +300,22 @@ func (request Request) validateReflect() error {
 	return nil
 }
 
+func (request Request) validateRevert() error {
+	if crdt.IsNilPath(request.Revert) {
+		return fmt.Errorf("No index to revert to")
+	}
+
+	return nil
+}
+
+func (request Request) validatePrepare() error {
+	if request.Prepare == "" {
+		return fmt.Errorf("No statement to prepare")
+	}
+
+	return nil
+}
+
 func (request Request) validateReplicate() error {
 	if len(request.Replicate) == 0 {
 		return fmt.Errorf("No replication links")
@@ -185,8 +361,19 @@ func GenRequest(rand *rand.Rand, size int) Request {
 		generateQueryRequest(rand, size, &gen)
 	} else if chooseType < 0.6 {
 		generateReflectRequest(rand, size, &gen)
-	} else {
+	} else if chooseType < 0.8 {
 		generateReplicateRequest(rand, size, &gen)
+	} else if chooseType < 0.85 {
+		generateTagRequest(rand, size, &gen)
+	} else if chooseType < 0.88 {
+		generateExecuteRequest(rand, size, &gen)
+	} else if chooseType < 0.92 {
+		generateBatchRequest(rand, size, &gen)
+	} else if chooseType < 0.94 {
+		gen.Type = API_COMPACT
+	} else {
+		gen.Type = API_REVERT
+		gen.Revert = crdt.IPFSPath(testutil.RandLettersRange(rand, 1, size))
 	}
 
 	return gen
@@ -202,15 +389,62 @@ func generateReflectRequest(rand *rand.Rand, size int, gen *Request) {
 
 	chooseType := rand.Float32()
 
-	if chooseType < 0.3 {
+	if chooseType < 0.25 {
 		gen.Reflection = REFLECT_HEAD_PATH
-	} else if chooseType < 0.6 {
+	} else if chooseType < 0.5 {
 		gen.Reflection = REFLECT_INDEX
+	} else if chooseType < 0.75 {
+		gen.Reflection = REFLECT_INDEX_LOG
+		gen.LogLimit = uint32(rand.Intn(size + 1))
 	} else {
 		gen.Reflection = REFLECT_DUMP_NAMESPACE
 	}
 }
 
+func generateTagRequest(rand *rand.Rand, size int, gen *Request) {
+	gen.Type = API_TAG
+	gen.Tag.Command = TagCommand(rand.Intn(3) + 1)
+
+	if gen.Tag.Command == TAG_LIST {
+		return
+	}
+
+	gen.Tag.Name = testutil.RandLettersRange(rand, 1, size)
+
+	if gen.Tag.Command == TAG_CREATE {
+		if rand.Float32() < 0.5 {
+			gen.Tag.Path = crdt.IPFSPath(testutil.RandLettersRange(rand, 1, size))
+		}
+	}
+}
+
+func generateExecuteRequest(rand *rand.Rand, size int, gen *Request) {
+	gen.Type = API_EXECUTE
+	gen.Execute.Statement = testutil.RandLettersRange(rand, 1, size)
+
+	variableCount := testutil.GenCount(rand, size)
+	for i := 0; i < variableCount; i++ {
+		if rand.Float32() < 0.5 {
+			gen.Execute.Variables = append(gen.Execute.Variables, testutil.RandLetters(rand, size))
+		} else {
+			gen.Execute.Variables = append(gen.Execute.Variables, rand.Int()-rand.Int())
+		}
+	}
+}
+
+func generateBatchRequest(rand *rand.Rand, size int, gen *Request) {
+	gen.Type = API_BATCH
+
+	queryCount := testutil.GenCountRange(rand, 1, size)
+	for len(gen.Batch) < queryCount {
+		q := query.GenQuery(rand, size)
+
+		if q.OpCode != query.SELECT {
+			gen.Batch = append(gen.Batch, q)
+		}
+	}
+}
+
 func generateReplicateRequest(rand *rand.Rand, size int, gen *Request) {
 	gen.Type = API_REPLICATE
 
@@ -228,8 +462,13 @@ const (
 	REFLECT_HEAD_PATH
 	REFLECT_DUMP_NAMESPACE
 	REFLECT_INDEX
+	REFLECT_INDEX_LOG
 )
 
+// DEFAULT_LOG_LIMIT is the most entries in an index log when the request
+// does not set LogLimit.
+const DEFAULT_LOG_LIMIT = 100
+
 type MessageType uint8
 
 const (
@@ -237,4 +476,11 @@ const (
 	API_QUERY
 	API_REFLECT
 	API_REPLICATE
+	API_WATCH
+	API_TAG
+	API_REVERT
+	API_PREPARE
+	API_EXECUTE
+	API_BATCH
+	API_COMPACT
 )
@@ -12,6 +12,9 @@ func MakeRequestMessage(request Request) *proto.APIRequestMessage {
 	message.Type = uint32(request.Type)
 
 	message.Reflection = uint32(request.Reflection)
+	message.Revert = string(request.Revert)
+	message.Prepare = request.Prepare
+	message.LogLimit = request.LogLimit
 
 	message.Replicate = &proto.ReplicateMessage{}
 	message.Replicate.Links = make([]*proto.LinkMessage, 0, len(request.Replicate))
@@ -30,6 +33,18 @@ func MakeRequestMessage(request Request) *proto.APIRequestMessage {
 		message.Query = query.MakeQueryMessage(request.Query)
 	}
 
+	for _, q := range request.Batch {
+		message.Batch = append(message.Batch, query.MakeQueryMessage(q))
+	}
+
+	if request.Type == API_TAG {
+		message.Tag = makeTagRequestMessage(request.Tag)
+	}
+
+	if request.Type == API_EXECUTE {
+		message.Execute = makeExecuteMessage(request.Execute)
+	}
+
 	return message
 }
 
@@ -37,6 +52,9 @@ func ReadRequestMessage(message *proto.APIRequestMessage) Request {
 	request := Request{}
 	request.Type = MessageType(message.Type)
 	request.Reflection = ReflectionType(message.Reflection)
+	request.Revert = crdt.IPFSPath(message.Revert)
+	request.Prepare = message.Prepare
+	request.LogLimit = message.LogLimit
 
 	if message.Replicate != nil {
 		request.Replicate = make([]crdt.Link, 0, len(message.Replicate.Links))
@@ -52,6 +70,25 @@ func ReadRequestMessage(message *proto.APIRequestMessage) Request {
 		}
 	}
 
+	if message.Tag != nil {
+		request.Tag = readTagRequestMessage(message.Tag)
+	}
+
+	if message.Execute != nil {
+		request.Execute = readExecuteMessage(message.Execute)
+	}
+
+	for _, qmsg := range message.Batch {
+		query, err := query.ReadQueryMessage(qmsg)
+
+		// The nil query is kept, so that the whole batch fails validation.
+		if err != nil {
+			log.Error("Invalid Query in batch: %s", err.Error())
+		}
+
+		request.Batch = append(request.Batch, query)
+	}
+
 	if message.Query != nil {
 		query, err := query.ReadQueryMessage(message.Query)
 
@@ -11,6 +11,8 @@ import (
 
 	"github.com/johnny-morrice/godless/internal/testutil"
 	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/proto"
+	"github.com/johnny-morrice/godless/query"
 )
 
 func init() {
@@ -91,3 +93,17 @@ func TestRequestValidateFailure(t *testing.T) {
 		testutil.Assert(t, "Invalid request", !requestIsValid(request))
 	}
 }
+
+func TestReadRequestMessageInvalidBatch(t *testing.T) {
+	join, err := query.Compile(`join cars rows (@key=car1, driver="Mr Fast")`)
+	testutil.AssertNil(t, err)
+
+	message := MakeRequestMessage(MakeBatchRequest([]*query.Query{join}))
+	message.Batch = append(message.Batch, &proto.QueryMessage{OpCode: 99})
+
+	request := ReadRequestMessage(message)
+
+	testutil.AssertEquals(t, "Unexpected batch length", 2, len(request.Batch))
+	testutil.Assert(t, "Expected nil query", request.Batch[1] == nil)
+	testutil.AssertNonNil(t, request.Validate(StandardRequestValidator()))
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
 	"io"
 
@@ -18,6 +19,21 @@ type Response struct {
 	Path      crdt.IPFSPath
 	Namespace crdt.Namespace
 	Index     crdt.Index
+	// RowOrder lists the selected rows in the order the query asked for.
+	RowOrder []crdt.RowName
+	// RemovedRows lists the rows of the last watch result that no longer
+	// match.
+	RemovedRows []crdt.RowName
+	// Table holds the results of an aggregate select.
+	Table ResultTable
+	// Plan reports how an explained select was run.
+	Plan QueryPlan
+	// History walks the index chain back from HEAD.
+	History []IndexLogEntry
+	// Tags are listed in name order.
+	Tags []Tag
+	// Statement is the handle of a prepared statement.
+	Statement string
 }
 
 func (resp Response) IsEmpty() bool {
@@ -41,6 +57,7 @@ func (resp Response) Equals(other Response) bool {
 	ok := resp.Msg == other.Msg
 	ok = ok && resp.Type == other.Type
 	ok = ok && resp.Path == other.Path
+	ok = ok && resp.Statement == other.Statement
 
 	if !ok {
 		return false
@@ -62,6 +79,54 @@ func (resp Response) Equals(other Response) bool {
 		return false
 	}
 
+	if !resp.Table.Equals(other.Table) {
+		return false
+	}
+
+	if !resp.Plan.Equals(other.Plan) {
+		return false
+	}
+
+	if len(resp.History) != len(other.History) {
+		return false
+	}
+
+	for i, entry := range resp.History {
+		if !entry.Equals(other.History[i]) {
+			return false
+		}
+	}
+
+	if len(resp.Tags) != len(other.Tags) {
+		return false
+	}
+
+	for i, tag := range resp.Tags {
+		if tag != other.Tags[i] {
+			return false
+		}
+	}
+
+	if len(resp.RowOrder) != len(other.RowOrder) {
+		return false
+	}
+
+	for i, row := range resp.RowOrder {
+		if row != other.RowOrder[i] {
+			return false
+		}
+	}
+
+	if len(resp.RemovedRows) != len(other.RemovedRows) {
+		return false
+	}
+
+	for i, row := range resp.RemovedRows {
+		if row != other.RemovedRows[i] {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -93,6 +158,41 @@ func DecodeResponse(r io.Reader) (Response, error) {
 	return ReadAPIResponseMessage(message), nil
 }
 
+// EncodeResponseFrame writes one Response in a stream of Responses.
+func EncodeResponseFrame(resp Response, w io.Writer) error {
+	const failMsg = "EncodeResponseFrame failed"
+
+	message := MakeAPIResponseMessage(resp)
+
+	err := util.EncodeDelimited(message, w)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	return nil
+}
+
+// DecodeResponseFrame reads the next Response from a stream written by
+// EncodeResponseFrame.  It returns io.EOF at the end of the stream.
+func DecodeResponseFrame(r *bufio.Reader) (Response, error) {
+	const failMsg = "DecodeResponseFrame failed"
+
+	message := &proto.APIResponseMessage{}
+
+	err := util.DecodeDelimited(message, r)
+
+	if err == io.EOF {
+		return RESPONSE_FAIL, err
+	}
+
+	if err != nil {
+		return RESPONSE_FAIL, errors.Wrap(err, failMsg)
+	}
+
+	return ReadAPIResponseMessage(message), nil
+}
+
 func EncodeResponseText(resp Response, w io.Writer) error {
 	const failMsg = "EncodeResponseText failed"
 
@@ -128,3 +228,8 @@ var RESPONSE_FAIL Response = Response{Msg: RESPONSE_FAIL_MSG}
 var RESPONSE_QUERY Response = Response{Msg: RESPONSE_OK_MSG, Type: API_QUERY}
 var RESPONSE_REPLICATE Response = Response{Msg: RESPONSE_OK_MSG, Type: API_REPLICATE}
 var RESPONSE_REFLECT Response = Response{Msg: RESPONSE_OK_MSG, Type: API_REFLECT}
+var RESPONSE_TAG Response = Response{Msg: RESPONSE_OK_MSG, Type: API_TAG}
+var RESPONSE_REVERT Response = Response{Msg: RESPONSE_OK_MSG, Type: API_REVERT}
+var RESPONSE_BATCH Response = Response{Msg: RESPONSE_OK_MSG, Type: API_BATCH}
+var RESPONSE_COMPACT Response = Response{Msg: RESPONSE_OK_MSG, Type: API_COMPACT}
+var RESPONSE_PREPARE Response = Response{Msg: RESPONSE_OK_MSG, Type: API_PREPARE}
@@ -9,9 +9,10 @@ import (
 
 func MakeAPIResponseMessage(resp Response) *proto.APIResponseMessage {
 	message := &proto.APIResponseMessage{
-		Message: resp.Msg,
-		Type:    uint32(resp.Type),
-		Path:    string(resp.Path),
+		Message:   resp.Msg,
+		Type:      uint32(resp.Type),
+		Path:      string(resp.Path),
+		Statement: resp.Statement,
 	}
 
 	if resp.Err != nil {
@@ -28,14 +29,65 @@ func MakeAPIResponseMessage(resp Response) *proto.APIResponseMessage {
 
 	logInvalidIndex(indexInvalid)
 
+	for _, row := range resp.RowOrder {
+		message.RowOrder = append(message.RowOrder, string(row))
+	}
+
+	for _, row := range resp.RemovedRows {
+		message.RemovedRows = append(message.RemovedRows, string(row))
+	}
+
+	if !resp.Table.IsEmpty() {
+		message.Table = makeResultTableMessage(resp.Table)
+	}
+
+	if !resp.Plan.IsEmpty() {
+		message.Plan = makeQueryPlanMessage(resp.Plan)
+	}
+
+	for _, entry := range resp.History {
+		message.History = append(message.History, makeIndexLogEntryMessage(entry))
+	}
+
+	for _, tag := range resp.Tags {
+		message.Tags = append(message.Tags, makeTagMessage(tag))
+	}
+
+	return message
+}
+
+func makeResultTableMessage(table ResultTable) *proto.ResultTableMessage {
+	message := &proto.ResultTableMessage{
+		Columns: table.Columns,
+		Rows:    make([]*proto.ResultRowMessage, len(table.Rows)),
+	}
+
+	for i, row := range table.Rows {
+		message.Rows[i] = &proto.ResultRowMessage{Values: row}
+	}
+
 	return message
 }
 
+func readResultTableMessage(message *proto.ResultTableMessage) ResultTable {
+	table := ResultTable{
+		Columns: message.Columns,
+		Rows:    make([][]string, len(message.Rows)),
+	}
+
+	for i, row := range message.Rows {
+		table.Rows[i] = row.Values
+	}
+
+	return table
+}
+
 func ReadAPIResponseMessage(message *proto.APIResponseMessage) Response {
 	resp := Response{
-		Msg:  message.Message,
-		Type: MessageType(message.Type),
-		Path: crdt.IPFSPath(message.Path),
+		Msg:       message.Message,
+		Type:      MessageType(message.Type),
+		Path:      crdt.IPFSPath(message.Path),
+		Statement: message.Statement,
 	}
 
 	if message.Error != "" {
@@ -55,6 +107,30 @@ func ReadAPIResponseMessage(message *proto.APIResponseMessage) Response {
 		logInvalidIndex(indexInvalid)
 	}
 
+	for _, row := range message.RowOrder {
+		resp.RowOrder = append(resp.RowOrder, crdt.RowName(row))
+	}
+
+	for _, row := range message.RemovedRows {
+		resp.RemovedRows = append(resp.RemovedRows, crdt.RowName(row))
+	}
+
+	if message.Table != nil {
+		resp.Table = readResultTableMessage(message.Table)
+	}
+
+	if message.Plan != nil {
+		resp.Plan = readQueryPlanMessage(message.Plan)
+	}
+
+	for _, entry := range message.History {
+		resp.History = append(resp.History, readIndexLogEntryMessage(entry))
+	}
+
+	for _, tag := range message.Tags {
+		resp.Tags = append(resp.Tags, readTagMessage(tag))
+	}
+
 	return resp
 }
 
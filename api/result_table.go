@@ -0,0 +1,43 @@
+package api
+
+// ResultTable holds the summary values returned by an aggregate select.
+type ResultTable struct {
+	Columns []string
+	Rows    [][]string
+}
+
+func (table ResultTable) IsEmpty() bool {
+	return len(table.Columns) == 0 && len(table.Rows) == 0
+}
+
+func (table ResultTable) Equals(other ResultTable) bool {
+	if !stringsEqual(table.Columns, other.Columns) {
+		return false
+	}
+
+	if len(table.Rows) != len(other.Rows) {
+		return false
+	}
+
+	for i, row := range table.Rows {
+		if !stringsEqual(row, other.Rows[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, text := range a {
+		if text != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
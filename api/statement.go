@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// ExecuteRequest runs a prepared statement.  Only the handle and the bind
+// values are sent, the query text is held by the server.
+type ExecuteRequest struct {
+	// Statement is the handle returned by a prepare request.
+	Statement string
+	// Variables are string or int values for the statement placeholders.
+	Variables []interface{}
+}
+
+func (execute ExecuteRequest) Equals(other ExecuteRequest) bool {
+	ok := execute.Statement == other.Statement
+	ok = ok && len(execute.Variables) == len(other.Variables)
+
+	if !ok {
+		return false
+	}
+
+	for i, variable := range execute.Variables {
+		if variable != other.Variables[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (execute ExecuteRequest) validate() error {
+	if execute.Statement == "" {
+		return fmt.Errorf("No statement handle")
+	}
+
+	for i, variable := range execute.Variables {
+		switch variable.(type) {
+		case string:
+		case int:
+		default:
+			return fmt.Errorf("Variable at %d was not string or int: %v", i, variable)
+		}
+	}
+
+	return nil
+}
+
+type VariableType uint8
+
+const (
+	VARIABLE_NOOP = VariableType(iota)
+	VARIABLE_TEXT
+	VARIABLE_NUMBER
+)
+
+func makeExecuteMessage(execute ExecuteRequest) *proto.ExecuteMessage {
+	message := &proto.ExecuteMessage{
+		Statement: execute.Statement,
+		Variables: make([]*proto.VariableMessage, 0, len(execute.Variables)),
+	}
+
+	for _, variable := range execute.Variables {
+		vmsg := &proto.VariableMessage{}
+
+		switch val := variable.(type) {
+		case string:
+			vmsg.Type = uint32(VARIABLE_TEXT)
+			vmsg.Text = val
+		case int:
+			vmsg.Type = uint32(VARIABLE_NUMBER)
+			vmsg.Number = int64(val)
+		default:
+			log.Error("Invalid variable: %v", variable)
+			continue
+		}
+
+		message.Variables = append(message.Variables, vmsg)
+	}
+
+	return message
+}
+
+func readExecuteMessage(message *proto.ExecuteMessage) ExecuteRequest {
+	execute := ExecuteRequest{
+		Statement: message.Statement,
+		Variables: make([]interface{}, 0, len(message.Variables)),
+	}
+
+	for _, vmsg := range message.Variables {
+		switch VariableType(vmsg.Type) {
+		case VARIABLE_TEXT:
+			execute.Variables = append(execute.Variables, vmsg.Text)
+		case VARIABLE_NUMBER:
+			execute.Variables = append(execute.Variables, int(vmsg.Number))
+		default:
+			log.Error("Invalid VariableMessage type: %d", vmsg.Type)
+		}
+	}
+
+	return execute
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// Tag names an index, so it can be found after HEAD has moved on.
+type Tag struct {
+	Name string
+	Path crdt.IPFSPath
+}
+
+type TagCommand uint8
+
+const (
+	TAG_NOOP = TagCommand(iota)
+	TAG_CREATE
+	TAG_LIST
+	TAG_CHECKOUT
+)
+
+type TagRequest struct {
+	Command TagCommand
+	Name    string
+	// Path is the index to tag.  HEAD is tagged if it is not set.
+	Path crdt.IPFSPath
+}
+
+func (tag TagRequest) Equals(other TagRequest) bool {
+	return tag == other
+}
+
+func (tag TagRequest) validate() error {
+	switch tag.Command {
+	case TAG_LIST:
+		return nil
+	case TAG_CREATE:
+	case TAG_CHECKOUT:
+	default:
+		return fmt.Errorf("Invalid TagCommand: %v", tag.Command)
+	}
+
+	return ValidateTagName(tag.Name)
+}
+
+// ValidateTagName rejects empty names and names containing whitespace.
+func ValidateTagName(name string) error {
+	if name == "" {
+		return fmt.Errorf("Empty tag name")
+	}
+
+	if strings.IndexFunc(name, unicode.IsSpace) != -1 {
+		return fmt.Errorf("Tag name contains whitespace: '%s'", name)
+	}
+
+	return nil
+}
+
+func makeTagRequestMessage(tag TagRequest) *proto.TagRequestMessage {
+	return &proto.TagRequestMessage{
+		Command: uint32(tag.Command),
+		Name:    tag.Name,
+		Path:    string(tag.Path),
+	}
+}
+
+func readTagRequestMessage(message *proto.TagRequestMessage) TagRequest {
+	return TagRequest{
+		Command: TagCommand(message.Command),
+		Name:    message.Name,
+		Path:    crdt.IPFSPath(message.Path),
+	}
+}
+
+func makeTagMessage(tag Tag) *proto.TagMessage {
+	return &proto.TagMessage{
+		Name: tag.Name,
+		Path: string(tag.Path),
+	}
+}
+
+func readTagMessage(message *proto.TagMessage) Tag {
+	return Tag{
+		Name: message.Name,
+		Path: crdt.IPFSPath(message.Path),
+	}
+}
@@ -85,7 +85,7 @@ type boltCache struct {
 }
 
 func (cache boltCache) initBuckets() error {
-	return createAllBucketsIfNotExists(cache.db, BOLT_NAMESPACE_CACHE_BUCKET, BOLT_INDEX_CACHE_BUCKET, BOLT_HEAD_CACHE_BUCKET)
+	return createAllBucketsIfNotExists(cache.db, BOLT_NAMESPACE_CACHE_BUCKET, BOLT_INDEX_CACHE_BUCKET, BOLT_HEAD_CACHE_BUCKET, BOLT_TAG_CACHE_BUCKET)
 }
 
 func (cache boltCache) GetHead() (crdt.IPFSPath, error) {
@@ -127,6 +127,107 @@ func (cache boltCache) SetHead(head crdt.IPFSPath) error {
 	return nil
 }
 
+func (cache boltCache) SetTag(tag api.Tag) error {
+	const failMsg = "boltCache.SetTag failed"
+
+	err := cache.updateTags(func(bucket *bolt.Bucket) error {
+		return bucket.Put([]byte(tag.Name), []byte(tag.Path))
+	})
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	log.Info("Wrote tag '%s' to Bolt: %s", tag.Name, tag.Path)
+
+	return nil
+}
+
+func (cache boltCache) GetTag(name string) (api.Tag, error) {
+	const failMsg = "boltCache.GetTag failed"
+
+	tag := api.Tag{Name: name}
+	err := cache.viewTags(func(bucket *bolt.Bucket) error {
+		value := bucket.Get([]byte(name))
+
+		if value == nil {
+			return fmt.Errorf("No such tag: %s", name)
+		}
+
+		tag.Path = crdt.IPFSPath(value)
+		return nil
+	})
+
+	if err != nil {
+		return api.Tag{}, errors.Wrap(err, failMsg)
+	}
+
+	return tag, nil
+}
+
+// GetTags relies on Bolt iterating keys in byte order.
+func (cache boltCache) GetTags() ([]api.Tag, error) {
+	const failMsg = "boltCache.GetTags failed"
+
+	tags := []api.Tag{}
+	err := cache.viewTags(func(bucket *bolt.Bucket) error {
+		return bucket.ForEach(func(k []byte, v []byte) error {
+			tag := api.Tag{Name: string(k), Path: crdt.IPFSPath(v)}
+			tags = append(tags, tag)
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	return tags, nil
+}
+
+func (cache boltCache) SetQuarantine(quarantine crdt.Index) error {
+	const failMsg = "boltCache.SetQuarantine failed"
+
+	// TODO handle the invalid entries.
+	message, _ := crdt.MakeIndexMessage(quarantine)
+
+	err := cache.updateHead(func(bucket *bolt.Bucket) error {
+		return putMessage(bucket, BOLT_QUARANTINE_CACHE_KEY, message)
+	})
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	log.Info("Wrote quarantine to Bolt (%d tables)", len(quarantine.Index))
+
+	return nil
+}
+
+func (cache boltCache) GetQuarantine() (crdt.Index, error) {
+	const failMsg = "boltCache.GetQuarantine failed"
+
+	message := &proto.IndexMessage{}
+	err := cache.viewHead(func(bucket *bolt.Bucket) error {
+		value := bucket.Get(BOLT_QUARANTINE_CACHE_KEY)
+
+		if value == nil {
+			return nil
+		}
+
+		return pb.Unmarshal(value, message)
+	})
+
+	if err != nil {
+		return crdt.EmptyIndex(), errors.Wrap(err, failMsg)
+	}
+
+	// TODO handle the invalid entries.
+	quarantine, _ := crdt.ReadIndexMessage(message)
+
+	return quarantine, nil
+}
+
 func (cache boltCache) GetIndex(indexAddr crdt.IPFSPath) (crdt.Index, error) {
 	const failMsg = "boltCache.GetIndex failed"
 
@@ -296,6 +397,30 @@ func (cache boltCache) updateHead(updater func(bucket *bolt.Bucket) error) error
 	})
 }
 
+func (cache boltCache) viewTags(viewer func(bucket *bolt.Bucket) error) error {
+	return cache.db.View(func(transaction *bolt.Tx) error {
+		bucket, err := getBucket(transaction, BOLT_TAG_CACHE_BUCKET)
+
+		if err != nil {
+			return err
+		}
+
+		return viewer(bucket)
+	})
+}
+
+func (cache boltCache) updateTags(updater func(bucket *bolt.Bucket) error) error {
+	return cache.db.Update(func(transaction *bolt.Tx) error {
+		bucket, err := getBucket(transaction, BOLT_TAG_CACHE_BUCKET)
+
+		if err != nil {
+			return err
+		}
+
+		return updater(bucket)
+	})
+}
+
 func (cache boltCache) CloseCache() error {
 	err := cache.db.Close()
 	log.Info("Closed boltCache")
@@ -421,6 +546,24 @@ func (memimg boltMemoryImage) JoinIndex(index crdt.Index) error {
 	return nil
 }
 
+func (memimg boltMemoryImage) ResetIndex(index crdt.Index) error {
+	const failMsg = "boltMemoryIndex.ResetIndex failed"
+
+	err := memimg.update(func(bucket *bolt.Bucket) error {
+		// TODO handle the invalid entries.
+		message, _ := crdt.MakeIndexMessage(index)
+		return putMessage(bucket, BOLT_MEMORY_IMAGE_INDEX_KEY, message)
+	})
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	log.Info("Reset Bolt MemoryImage")
+
+	return nil
+}
+
 func (memimg boltMemoryImage) view(viewer func(bucket *bolt.Bucket) error) error {
 	return memimg.db.View(func(transaction *bolt.Tx) error {
 		bucket, err := getBucket(transaction, BOLT_MEMORY_IMAGE_BUCKET)
@@ -632,7 +775,9 @@ var TIMESTAMP_KEY = []byte("timestamp")
 var NANO_TIMESTAMP_KEY = []byte("nano_timestamp")
 var DATA_KEY = []byte("data")
 var BOLT_HEAD_CACHE_KEY = []byte("head")
+var BOLT_QUARANTINE_CACHE_KEY = []byte("quarantine")
 var BOLT_HEAD_CACHE_BUCKET = []byte("head_cache")
+var BOLT_TAG_CACHE_BUCKET = []byte("tag_cache")
 var BOLT_NAMESPACE_CACHE_BUCKET = []byte("namespace_cache")
 var BOLT_INDEX_CACHE_BUCKET = []byte("index_cache")
 var BOLT_MEMORY_IMAGE_INDEX_KEY = []byte("current_index")
@@ -29,6 +29,62 @@ func TestBoltCache(t *testing.T) {
 	testCacheGetSet(t, cache)
 }
 
+func TestBoltCacheTags(t *testing.T) {
+	f := createTempFile()
+	defer f.Close()
+
+	options := BoltOptions{
+		FilePath: f.Name(),
+	}
+
+	boltFactory, err := MakeBoltFactory(options)
+
+	panicOnBadInit(err)
+
+	cache, err := boltFactory.MakeCache()
+
+	panicOnBadInit(err)
+
+	testTags(t, cache)
+}
+
+func TestBoltCacheQuarantine(t *testing.T) {
+	f := createTempFile()
+	defer f.Close()
+
+	options := BoltOptions{
+		FilePath: f.Name(),
+	}
+
+	boltFactory, err := MakeBoltFactory(options)
+
+	panicOnBadInit(err)
+
+	cache, err := boltFactory.MakeCache()
+
+	panicOnBadInit(err)
+
+	testQuarantine(t, cache)
+
+	expected, err := cache.GetQuarantine()
+	testutil.AssertNil(t, err)
+
+	err = cache.CloseCache()
+	testutil.AssertNil(t, err)
+
+	boltFactory, err = MakeBoltFactory(options)
+
+	panicOnBadInit(err)
+
+	cache, err = boltFactory.MakeCache()
+
+	panicOnBadInit(err)
+
+	actual, err := cache.GetQuarantine()
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Quarantine not persisted", expected.Equals(actual))
+}
+
 func TestBoltCacheConcurrency(t *testing.T) {
 	f := createTempFile()
 	defer f.Close()
@@ -66,6 +66,58 @@ func testMemoryImage(t *testing.T, memimg api.MemoryImage) {
 
 	testutil.AssertNil(t, err)
 	testutil.Assert(t, "Unexpected index", expected.Equals(actual))
+
+	err = memimg.ResetIndex(indexA)
+	testutil.AssertNil(t, err)
+	actual, err = memimg.GetIndex()
+
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Unexpected index after reset", indexA.Equals(actual))
+}
+
+func testTags(t *testing.T, cache api.HeadCache) {
+	tags := []api.Tag{
+		api.Tag{Name: "release", Path: "Addr B"},
+		api.Tag{Name: "alpha", Path: "Addr A"},
+	}
+
+	for _, tag := range tags {
+		err := cache.SetTag(tag)
+		testutil.AssertNil(t, err)
+	}
+
+	actual, err := cache.GetTag("release")
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected tag", tags[0], actual)
+
+	_, err = cache.GetTag("missing")
+	testutil.AssertNonNil(t, err)
+
+	moved := api.Tag{Name: "release", Path: "Addr C"}
+	err = cache.SetTag(moved)
+	testutil.AssertNil(t, err)
+
+	expected := []api.Tag{tags[1], moved}
+	all, err := cache.GetTags()
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected tags", expected, all)
+}
+
+func testQuarantine(t *testing.T, cache api.HeadCache) {
+	empty, err := cache.GetQuarantine()
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Expected empty quarantine", empty.IsEmpty())
+
+	quarantine := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+
+	err = cache.SetQuarantine(quarantine)
+	testutil.AssertNil(t, err)
+
+	actual, err := cache.GetQuarantine()
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Unexpected quarantine", quarantine.Equals(actual))
 }
 
 func testIndexExpire(t *testing.T, cache api.IndexCache, count, buffsize int) {
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 	"unsafe"
@@ -38,6 +39,14 @@ func (memimg *residentMemoryImage) GetIndex() (crdt.Index, error) {
 	return memimg.joined, nil
 }
 
+func (memimg *residentMemoryImage) ResetIndex(index crdt.Index) error {
+	memimg.Lock()
+	defer memimg.Unlock()
+
+	memimg.joined = index.Copy()
+	return nil
+}
+
 func (memimg *residentMemoryImage) CloseMemoryImage() error {
 	log.Info("Closed residentMemoryImage")
 	return nil
@@ -230,7 +239,9 @@ func (stamp timestamp) older(other timestamp) bool {
 
 type residentHeadCache struct {
 	sync.RWMutex
-	current crdt.IPFSPath
+	current    crdt.IPFSPath
+	tags       map[string]crdt.IPFSPath
+	quarantine crdt.Index
 }
 
 func (cache *residentHeadCache) SetHead(head crdt.IPFSPath) error {
@@ -247,8 +258,71 @@ func (cache *residentHeadCache) GetHead() (crdt.IPFSPath, error) {
 	return head, nil
 }
 
+func (cache *residentHeadCache) SetTag(tag api.Tag) error {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.tags[tag.Name] = tag.Path
+	return nil
+}
+
+func (cache *residentHeadCache) GetTag(name string) (api.Tag, error) {
+	cache.RLock()
+	defer cache.RUnlock()
+	path, present := cache.tags[name]
+
+	if !present {
+		return api.Tag{}, fmt.Errorf("No such tag: %s", name)
+	}
+
+	return api.Tag{Name: name, Path: path}, nil
+}
+
+func (cache *residentHeadCache) GetTags() ([]api.Tag, error) {
+	cache.RLock()
+	defer cache.RUnlock()
+
+	tags := make([]api.Tag, 0, len(cache.tags))
+	for name, path := range cache.tags {
+		tags = append(tags, api.Tag{Name: name, Path: path})
+	}
+
+	sort.Sort(byTagName(tags))
+
+	return tags, nil
+}
+
+func (cache *residentHeadCache) SetQuarantine(quarantine crdt.Index) error {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.quarantine = quarantine.Copy()
+	return nil
+}
+
+func (cache *residentHeadCache) GetQuarantine() (crdt.Index, error) {
+	cache.RLock()
+	defer cache.RUnlock()
+	return cache.quarantine.Copy(), nil
+}
+
 func MakeResidentHeadCache() api.HeadCache {
-	return &residentHeadCache{}
+	return &residentHeadCache{
+		tags:       map[string]crdt.IPFSPath{},
+		quarantine: crdt.EmptyIndex(),
+	}
+}
+
+type byTagName []api.Tag
+
+func (tags byTagName) Len() int {
+	return len(tags)
+}
+
+func (tags byTagName) Swap(i, j int) {
+	tags[i], tags[j] = tags[j], tags[i]
+}
+
+func (tags byTagName) Less(i, j int) bool {
+	return tags[i].Name < tags[j].Name
 }
 
 type residentPriorityQueue struct {
@@ -423,15 +497,20 @@ func makeResidentQueueItem(request api.Request, data interface{}) (residentQueue
 func findRequestPriority(request api.Request) (residentPriority, error) {
 	switch request.Type {
 	case api.API_QUERY:
-		if request.Query.OpCode == query.JOIN {
+		switch request.Query.OpCode {
+		case query.JOIN:
+			fallthrough
+		case query.DELETE:
 			return __QUERY_JOIN_PRIORITY, nil
-		} else {
+		default:
 			return __QUERY_SELECT_PRIORITY, nil
 		}
 	case api.API_REFLECT:
 		return __QUERY_REFLECT_PRIORITY, nil
 	case api.API_REPLICATE:
 		return __QUERY_REPLICATE_PRIORITY, nil
+	case api.API_TAG, api.API_REVERT, api.API_BATCH, api.API_COMPACT:
+		return __QUERY_JOIN_PRIORITY, nil
 	default:
 		return __UNKNOWN_PRIORITY, fmt.Errorf("Unknown request.Type: %v", request.Type)
 	}
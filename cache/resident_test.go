@@ -27,6 +27,16 @@ func TestResidentHeadCacheConcurrency(t *testing.T) {
 	testHeadConcurrency(t, cache, count)
 }
 
+func TestResidentHeadCacheTags(t *testing.T) {
+	cache := MakeResidentHeadCache()
+	testTags(t, cache)
+}
+
+func TestResidentHeadCacheQuarantine(t *testing.T) {
+	cache := MakeResidentHeadCache()
+	testQuarantine(t, cache)
+}
+
 func TestResidentCache(t *testing.T) {
 	cache := MakeResidentMemoryCache(0, 0)
 	testCacheGetSet(t, cache)
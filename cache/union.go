@@ -29,6 +29,46 @@ func (cache Union) SetHead(head crdt.IPFSPath) error {
 	return cache.HeadCache.SetHead(head)
 }
 
+func (cache Union) SetTag(tag api.Tag) error {
+	if cache.HeadCache == nil {
+		return noSuchCache()
+	}
+
+	return cache.HeadCache.SetTag(tag)
+}
+
+func (cache Union) GetTag(name string) (api.Tag, error) {
+	if cache.HeadCache == nil {
+		return api.Tag{}, noSuchCache()
+	}
+
+	return cache.HeadCache.GetTag(name)
+}
+
+func (cache Union) GetTags() ([]api.Tag, error) {
+	if cache.HeadCache == nil {
+		return nil, noSuchCache()
+	}
+
+	return cache.HeadCache.GetTags()
+}
+
+func (cache Union) SetQuarantine(quarantine crdt.Index) error {
+	if cache.HeadCache == nil {
+		return noSuchCache()
+	}
+
+	return cache.HeadCache.SetQuarantine(quarantine)
+}
+
+func (cache Union) GetQuarantine() (crdt.Index, error) {
+	if cache.HeadCache == nil {
+		return crdt.EmptyIndex(), noSuchCache()
+	}
+
+	return cache.HeadCache.GetQuarantine()
+}
+
 func (cache Union) GetIndex(indexAddr crdt.IPFSPath) (crdt.Index, error) {
 	if cache.IndexCache == nil {
 		return crdt.EmptyIndex(), noSuchCache()
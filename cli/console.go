@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/peterh/liner"
+	"github.com/pkg/errors"
 
 	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/crdt"
@@ -28,6 +30,7 @@ type Console struct {
 	TerminalOptions
 	line         *liner.State
 	outputBuffer *bufio.Writer
+	lastSelect   *query.Query
 }
 
 func MakeConsole(options TerminalOptions) *Console {
@@ -91,7 +94,7 @@ func (console *Console) shutdownLiner() {
 	console.line.Close()
 }
 
-func (console Console) readEvalPrint() (bool, error) {
+func (console *Console) readEvalPrint() (bool, error) {
 	defer console.outputBuffer.Flush()
 	command, err := console.line.Prompt("> ")
 
@@ -105,13 +108,63 @@ func (console Console) readEvalPrint() (bool, error) {
 
 	console.line.AppendHistory(command)
 
-	query, err := query.Compile(command)
+	if strings.HasPrefix(command, "watch ") {
+		q, err := query.Compile(strings.TrimPrefix(command, "watch "))
 
-	if err != nil {
-		console.printf("Compiliation error: %v", err.Error())
+		if err != nil {
+			console.printf("Compiliation error: %v", err.Error())
+			return false, nil
+		}
+
+		console.watchQuery(q)
 		return false, nil
 	}
 
+	var q *query.Query
+	if command == ":next" {
+		q, err = console.nextPage()
+
+		if err != nil {
+			console.printf("Error: %v\n", err.Error())
+			return false, nil
+		}
+	} else {
+		q, err = query.Compile(command)
+
+		if err != nil {
+			console.printf("Compiliation error: %v", err.Error())
+			return false, nil
+		}
+	}
+
+	if q.OpCode == query.SELECT {
+		console.lastSelect = q
+	}
+
+	console.sendQuery(q)
+
+	return false, nil
+}
+
+// nextPage copies the last select query, moving the offset past the rows
+// already shown.
+func (console *Console) nextPage() (*query.Query, error) {
+	if console.lastSelect == nil {
+		return nil, errors.New("No select query to page through")
+	}
+
+	if console.lastSelect.Select.Limit == 0 {
+		return nil, errors.New("Last select query had no limit")
+	}
+
+	next := *console.lastSelect
+	next.Select.Offset += next.Select.Limit
+
+	return &next, nil
+}
+
+func (console *Console) sendQuery(query *query.Query) {
+
 	sendTime := time.Now()
 	request := api.MakeQueryRequest(query)
 	resp, err := console.Client.Send(request)
@@ -135,12 +188,59 @@ func (console Console) readEvalPrint() (bool, error) {
 	console.printResponseTables(resp, query)
 
 	console.printf("Waited %v for response from server.\n", waitTime)
+}
 
-	return false, nil
+// watchQuery prints the results of a select, and then the changes to them,
+// until the user presses enter.
+func (console *Console) watchQuery(q *query.Query) {
+	stopch := make(chan struct{})
+	respch, err := console.Client.Watch(api.MakeWatchRequest(q), stopch)
+
+	if err != nil {
+		console.printf("Error: %v\n", err.Error())
+		return
+	}
+
+	console.printf("Watching for changes, press enter to stop.\n")
+	console.outputBuffer.Flush()
+
+	donech := make(chan struct{})
+	go func() {
+		defer close(donech)
+		heading := "Results"
+		for resp := range respch {
+			if resp.Err != nil {
+				console.printf("Error: %v\n", resp.Err.Error())
+				heading = "Results"
+			} else {
+				console.printf("%s at %v:\n", heading, time.Now().Format(time.RFC3339))
+				console.printResponseTables(resp, q)
+				heading = "Changes"
+			}
+
+			for _, row := range resp.RemovedRows {
+				console.printf("Removed row: %s\n", row)
+			}
+
+			console.outputBuffer.Flush()
+		}
+	}()
+
+	console.line.Prompt("")
+	close(stopch)
+	<-donech
 }
 
 func (console *Console) printResponseTables(resp api.Response, q *query.Query) {
-	if q.OpCode == query.SELECT {
+	if q.OpCode != query.SELECT {
+		return
+	}
+
+	if q.Select.Explain {
+		FprintQueryPlan(console.outputBuffer, resp)
+	} else if q.Select.IsAggregate() {
+		FprintResultTable(console.outputBuffer, resp)
+	} else {
 		FprintNamespaceTable(console.outputBuffer, resp)
 	}
 }
@@ -163,7 +263,7 @@ func FprintNamespaceTable(w io.Writer, resp api.Response) {
 		return
 	}
 
-	table := makeNamespaceTable(resp.Namespace)
+	table := makeNamespaceTable(resp.Namespace, resp.RowOrder)
 	table.fprint(w)
 	fmt.Fprintf(w, "\nFound %d Namespace Entries.\n", table.countrows())
 
@@ -172,12 +272,210 @@ func FprintNamespaceTable(w io.Writer, resp api.Response) {
 	}
 }
 
+func FprintResultTable(w io.Writer, resp api.Response) {
+	if resp.Table.IsEmpty() {
+		fmt.Fprintln(w, "No results returned.")
+		return
+	}
+
+	table, err := makeResultTable(resp.Table)
+
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err.Error())
+		return
+	}
+
+	table.fprint(w)
+	fmt.Fprintf(w, "\nFound %d Rows.\n", table.countrows())
+}
+
+func FprintQueryPlan(w io.Writer, resp api.Response) {
+	plan := resp.Plan
+
+	if plan.IsEmpty() {
+		fmt.Fprintln(w, "No plan returned.")
+		return
+	}
+
+	fmt.Fprintf(w, "Index links chosen: %d\n", len(plan.IndexLinks))
+	fmt.Fprintf(w, "Namespaces loaded: %d from cache, %d from IPFS\n", plan.NamespacesLoaded(api.SOURCE_CACHE), plan.NamespacesLoaded(api.SOURCE_IPFS))
+	fmt.Fprintf(w, "Rows evaluated: %d\n\n", plan.RowsEvaluated)
+
+	loads := makeNamespaceLoadTable(plan.Loads)
+	loads.fprint(w)
+	fmt.Fprintln(w)
+
+	phases := makePlanPhaseTable(plan.Phases)
+	phases.fprint(w)
+}
+
+// FprintIndexLog writes the index history in the style of git log.
+func FprintIndexLog(w io.Writer, resp api.Response) {
+	if len(resp.History) == 0 {
+		fmt.Fprintln(w, "No index history.")
+		return
+	}
+
+	for i, entry := range resp.History {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "index %s\n", entry.Path)
+
+		if len(entry.Parents) > 0 {
+			parents := make([]string, len(entry.Parents))
+			for j, parent := range entry.Parents {
+				parents[j] = string(parent)
+			}
+			fmt.Fprintf(w, "Parents: %s\n", strings.Join(parents, " "))
+		}
+
+		if !entry.Created.IsZero() {
+			fmt.Fprintf(w, "Created: %s\n", entry.Created.Format(time.RFC3339))
+		}
+
+		tables := make([]string, len(entry.ChangedTables))
+		for j, table := range entry.ChangedTables {
+			tables[j] = string(table)
+		}
+		fmt.Fprintf(w, "Changed tables: %s\n", strings.Join(tables, ", "))
+	}
+}
+
+func FprintTags(w io.Writer, resp api.Response) {
+	if len(resp.Tags) == 0 {
+		fmt.Fprintln(w, "No tags.")
+		return
+	}
+
+	table := &monospaceTable{}
+	table.addColumn("Tag", "Index")
+
+	for _, tag := range resp.Tags {
+		table.addRow(tag.Name, string(tag.Path))
+	}
+
+	table.fprint(w)
+}
+
+// FprintNamespaceDiff writes the points added and removed between two
+// namespaces, one row for each signature.
+func FprintNamespaceDiff(w io.Writer, added, removed crdt.NamespaceDiff) {
+	if added.IsEmpty() && removed.IsEmpty() {
+		fmt.Fprintln(w, "No differences.")
+		return
+	}
+
+	table := &monospaceTable{}
+	table.addColumn("Change", "Table", "Row", "Entry", "Kind", "Point", "Signature")
+	addNamespaceDiffRows(table, "+", added)
+	addNamespaceDiffRows(table, "-", removed)
+	table.fprint(w)
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Added %d tables, %d rows, %d entries and %d points.\n", len(added.Tables), len(added.Rows), len(added.Entries), len(added.Points))
+	fmt.Fprintf(w, "Removed %d tables, %d rows, %d entries and %d points.\n", len(removed.Tables), len(removed.Rows), len(removed.Entries), len(removed.Points))
+}
+
+// FprintIndexDiff writes the links added and removed between two indices, one
+// row for each signature.
+func FprintIndexDiff(w io.Writer, added, removed crdt.IndexDiff) {
+	if added.IsEmpty() && removed.IsEmpty() {
+		fmt.Fprintln(w, "No differences.")
+		return
+	}
+
+	table := &monospaceTable{}
+	table.addColumn("Change", "Table", "Link", "Signature")
+	addIndexDiffRows(table, "+", added)
+	addIndexDiffRows(table, "-", removed)
+	table.fprint(w)
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Added %d tables and %d links.\n", len(added.Tables), len(added.Links))
+	fmt.Fprintf(w, "Removed %d tables and %d links.\n", len(removed.Tables), len(removed.Links))
+}
+
+func addNamespaceDiffRows(table *monospaceTable, change string, diff crdt.NamespaceDiff) {
+	for _, entry := range diff.Points {
+		kind := "point"
+		point := string(entry.Point.Text)
+
+		if entry.IsCounter() {
+			kind = "counter"
+			shard := entry.Counter
+			point = fmt.Sprintf("%s +%d -%d", shard.Replica, shard.Increments, shard.Decrements)
+
+			if entry.Tombstone {
+				kind = "counter tombstone"
+			}
+		} else if entry.Tombstone {
+			kind = "tombstone"
+		}
+
+		table.addRow(change, string(entry.Table), string(entry.Row), string(entry.Entry), kind, point, string(entry.Point.Signature))
+	}
+}
+
+func addIndexDiffRows(table *monospaceTable, change string, diff crdt.IndexDiff) {
+	for _, entry := range diff.Links {
+		table.addRow(change, string(entry.TableName), string(entry.Link), string(entry.Signature))
+	}
+}
+
+func makeNamespaceLoadTable(loads []api.NamespaceLoad) *monospaceTable {
+	table := &monospaceTable{}
+	table.addColumn("Namespace", "Source", "Status")
+
+	for _, load := range loads {
+		status := "ok"
+		if load.Failed {
+			status = "failed"
+		}
+
+		table.addRow(string(load.Path), load.Source.String(), status)
+	}
+
+	return table
+}
+
+func makePlanPhaseTable(phases []api.PlanPhase) *monospaceTable {
+	table := &monospaceTable{}
+	table.addColumn("Phase", "Time")
+
+	for _, phase := range phases {
+		table.addRow(phase.Name, phase.Duration.String())
+	}
+
+	return table
+}
+
+func makeResultTable(result api.ResultTable) (*monospaceTable, error) {
+	table := &monospaceTable{}
+	err := table.addColumn(result.Columns...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Rows {
+		err = table.addRow(row...)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
+
 func makeIndexTable(index crdt.Index) *monospaceTable {
 	panic("not implemented")
 }
 
 // TODO figure out how to make signatures look nice
-func makeNamespaceTable(namespace crdt.Namespace) *monospaceTable {
+func makeNamespaceTable(namespace crdt.Namespace, rowOrder []crdt.RowName) *monospaceTable {
 	table := &monospaceTable{}
 	columns := []string{
 		"Table",
@@ -189,7 +487,7 @@ func makeNamespaceTable(namespace crdt.Namespace) *monospaceTable {
 
 	table.addColumn(columns...)
 
-	namespace.ForeachEntry(func(t crdt.TableName, r crdt.RowName, e crdt.EntryName, entry crdt.Entry) {
+	addEntry := func(t crdt.TableName, r crdt.RowName, e crdt.EntryName, entry crdt.Entry) {
 		for _, point := range entry.GetValues() {
 			// sigText := makeSigText(point.Signatures())
 			row := []string{
@@ -202,11 +500,48 @@ func makeNamespaceTable(namespace crdt.Namespace) *monospaceTable {
 
 			table.addRow(row...)
 		}
-	})
+	}
+
+	if len(rowOrder) == 0 {
+		namespace.ForeachEntry(addEntry)
+		return table
+	}
+
+	for t, tableData := range namespace.Tables {
+		for _, r := range rowOrder {
+			row, err := tableData.GetRow(r)
+
+			if err != nil {
+				continue
+			}
+
+			for _, e := range sortedEntryNames(row) {
+				entry, _ := row.GetEntry(e)
+				addEntry(t, r, e, entry)
+			}
+		}
+	}
 
 	return table
 }
 
+func sortedEntryNames(row crdt.Row) []crdt.EntryName {
+	names := make([]string, 0, len(row.Entries))
+
+	for e := range row.Entries {
+		names = append(names, string(e))
+	}
+
+	sort.Strings(names)
+
+	entryNames := make([]crdt.EntryName, len(names))
+	for i, name := range names {
+		entryNames[i] = crdt.EntryName(name)
+	}
+
+	return entryNames
+}
+
 func makeSigText(signatures []crypto.Signature) string {
 	sigText := make([]string, 0, len(signatures))
 
@@ -0,0 +1,117 @@
+package crdt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Timestamp is a hybrid logical clock reading.  Wall is in unix nanoseconds.
+// Logical breaks ties between readings made in the same nanosecond, or when
+// the wall clock runs behind a timestamp we have observed.  Node breaks ties
+// between peers.
+type Timestamp struct {
+	Wall    int64
+	Logical uint32
+	Node    string
+}
+
+func (stamp Timestamp) IsZero() bool {
+	return stamp == Timestamp{}
+}
+
+func (stamp Timestamp) Less(other Timestamp) bool {
+	if stamp.Wall != other.Wall {
+		return stamp.Wall < other.Wall
+	}
+
+	if stamp.Logical != other.Logical {
+		return stamp.Logical < other.Logical
+	}
+
+	return stamp.Node < other.Node
+}
+
+func (stamp Timestamp) String() string {
+	return fmt.Sprintf("%d.%d@%s", stamp.Wall, stamp.Logical, stamp.Node)
+}
+
+func latestTimestamp(stamp, other Timestamp) Timestamp {
+	if stamp.Less(other) {
+		return other
+	}
+
+	return stamp
+}
+
+// HybridClock issues Timestamps that never go backwards, even when the wall
+// clock does, and that are later than any Timestamp the clock has observed.
+type HybridClock struct {
+	sync.Mutex
+	node string
+	last Timestamp
+	wall func() int64
+}
+
+func MakeHybridClock(node string) *HybridClock {
+	return &HybridClock{
+		node: node,
+		wall: wallNow,
+	}
+}
+
+// Node identifies the clock's peer.
+func (clock *HybridClock) Node() string {
+	return clock.node
+}
+
+func (clock *HybridClock) Now() Timestamp {
+	clock.Lock()
+	defer clock.Unlock()
+
+	wall := clock.wall()
+
+	if wall > clock.last.Wall {
+		clock.last = Timestamp{Wall: wall}
+	} else {
+		clock.last.Logical++
+	}
+
+	clock.last.Node = clock.node
+
+	return clock.last
+}
+
+// Observe moves the clock past a Timestamp seen on data from a peer.
+func (clock *HybridClock) Observe(remote Timestamp) {
+	clock.Lock()
+	defer clock.Unlock()
+
+	if clock.last.Wall < remote.Wall {
+		clock.last.Wall = remote.Wall
+		clock.last.Logical = remote.Logical
+	} else if clock.last.Wall == remote.Wall && clock.last.Logical < remote.Logical {
+		clock.last.Logical = remote.Logical
+	}
+}
+
+// GenerateNodeID makes a random identifier for a HybridClock.
+func GenerateNodeID() (string, error) {
+	const idLength = 8
+	bs := make([]byte, idLength)
+	_, err := rand.Read(bs)
+
+	if err != nil {
+		return "", errors.Wrap(err, "GenerateNodeID failed")
+	}
+
+	return hex.EncodeToString(bs), nil
+}
+
+func wallNow() int64 {
+	return time.Now().UnixNano()
+}
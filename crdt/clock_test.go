@@ -0,0 +1,59 @@
+package crdt
+
+import (
+	"testing"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestHybridClockNow(t *testing.T) {
+	wall := int64(100)
+	clock := MakeHybridClock("node")
+	clock.wall = func() int64 { return wall }
+
+	first := clock.Now()
+	second := clock.Now()
+
+	testutil.Assert(t, "Expected increasing timestamps", first.Less(second))
+	testutil.AssertEquals(t, "Unexpected node", "node", second.Node)
+
+	wall = 50
+	third := clock.Now()
+
+	testutil.Assert(t, "Expected increasing timestamps when wall clock goes backwards", second.Less(third))
+
+	wall = 200
+	fourth := clock.Now()
+
+	testutil.AssertEquals(t, "Expected wall time", Timestamp{Wall: 200, Node: "node"}, fourth)
+}
+
+func TestHybridClockObserve(t *testing.T) {
+	clock := MakeHybridClock("node")
+	clock.wall = func() int64 { return 100 }
+
+	remote := Timestamp{Wall: 500, Logical: 3, Node: "other"}
+	clock.Observe(remote)
+
+	local := clock.Now()
+
+	testutil.Assert(t, "Expected timestamp after observed", remote.Less(local))
+	testutil.AssertEquals(t, "Unexpected timestamp", Timestamp{Wall: 500, Logical: 4, Node: "node"}, local)
+}
+
+func TestTimestampLess(t *testing.T) {
+	ordered := []Timestamp{
+		Timestamp{},
+		Timestamp{Wall: 1},
+		Timestamp{Wall: 1, Logical: 1},
+		Timestamp{Wall: 1, Logical: 1, Node: "a"},
+		Timestamp{Wall: 1, Logical: 1, Node: "b"},
+		Timestamp{Wall: 2},
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		before, after := ordered[i-1], ordered[i]
+		testutil.Assert(t, "Expected less", before.Less(after))
+		testutil.Assert(t, "Unexpected less", !after.Less(before))
+	}
+}
@@ -0,0 +1,224 @@
+package crdt
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// CounterShard holds the increment and decrement totals written by a single
+// replica.  Both totals only grow, so shards from the same replica join by
+// taking the maximum of each.  A replica changes a counter by adding to its
+// own shard, so a counter has one shard per replica however often it is
+// written.
+type CounterShard struct {
+	Replica    string
+	Increments uint64
+	Decrements uint64
+}
+
+// MakeCounterShard creates a shard that adds delta to a counter.
+func MakeCounterShard(replica string, delta int64) CounterShard {
+	shard := CounterShard{Replica: replica}
+
+	if delta < 0 {
+		shard.Decrements = uint64(-delta)
+	} else {
+		shard.Increments = uint64(delta)
+	}
+
+	return shard
+}
+
+// Add returns the shard with delta added to its totals.  It fails rather than
+// wrapping round if a total would overflow.
+func (shard CounterShard) Add(delta int64) (CounterShard, error) {
+	change := MakeCounterShard(shard.Replica, delta)
+
+	if shard.Increments > math.MaxUint64-change.Increments || shard.Decrements > math.MaxUint64-change.Decrements {
+		return shard, errors.New("CounterShard total overflows")
+	}
+
+	shard.Increments += change.Increments
+	shard.Decrements += change.Decrements
+	return shard, nil
+}
+
+func (shard CounterShard) isZero() bool {
+	return shard.Increments == 0 && shard.Decrements == 0
+}
+
+func (shard CounterShard) IsEmpty() bool {
+	return shard == CounterShard{}
+}
+
+func (shard CounterShard) joinShard(other CounterShard) CounterShard {
+	joined := shard
+
+	if other.Increments > joined.Increments {
+		joined.Increments = other.Increments
+	}
+
+	if other.Decrements > joined.Decrements {
+		joined.Decrements = other.Decrements
+	}
+
+	return joined
+}
+
+// Counter is a PN-counter.  Its value is the sum of the increments less the
+// sum of the decrements over all shards.
+type Counter struct {
+	Shards []CounterShard `json:",omitempty"`
+}
+
+func EmptyCounter() Counter {
+	return Counter{}
+}
+
+func MakeCounter(shards []CounterShard) Counter {
+	if len(shards) == 0 {
+		return EmptyCounter()
+	}
+
+	sort.Sort(byCounterShardReplica(shards))
+	return Counter{Shards: uniqCounterShardSorted(shards)}
+}
+
+func (counter Counter) IsEmpty() bool {
+	return len(counter.Shards) == 0
+}
+
+// Total is the exact sum of the increments less the decrements.
+func (counter Counter) Total() *big.Int {
+	total := &big.Int{}
+	shardTotal := &big.Int{}
+
+	for _, shard := range counter.Shards {
+		total.Add(total, shardTotal.SetUint64(shard.Increments))
+		total.Sub(total, shardTotal.SetUint64(shard.Decrements))
+	}
+
+	return total
+}
+
+// Value is the Total, or an error if it does not fit in an int64.
+func (counter Counter) Value() (int64, error) {
+	total := counter.Total()
+
+	if !total.IsInt64() {
+		return 0, errors.New("Counter total overflows int64")
+	}
+
+	return total.Int64(), nil
+}
+
+// Point shows the Counter's Total as text.
+func (counter Counter) Point() Point {
+	return UnsignedPoint(PointText(counter.Total().String()))
+}
+
+// GetShard finds the shard written by the replica, or an empty shard for the
+// replica if it has not written one.
+func (counter Counter) GetShard(replica string) CounterShard {
+	for _, shard := range counter.Shards {
+		if shard.Replica == replica {
+			return shard
+		}
+	}
+
+	return CounterShard{Replica: replica}
+}
+
+// Without takes away the totals in removed, which were observed from the
+// same counter, leaving what was added since.
+func (counter Counter) Without(removed Counter) Counter {
+	remaining := make([]CounterShard, 0, len(counter.Shards))
+
+	for _, shard := range counter.Shards {
+		seen := removed.GetShard(shard.Replica)
+		shard.Increments -= minUint64(shard.Increments, seen.Increments)
+		shard.Decrements -= minUint64(shard.Decrements, seen.Decrements)
+
+		if !shard.isZero() {
+			remaining = append(remaining, shard)
+		}
+	}
+
+	return Counter{Shards: remaining}
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func (counter Counter) Copy() Counter {
+	if counter.IsEmpty() {
+		return EmptyCounter()
+	}
+
+	cpy := make([]CounterShard, len(counter.Shards))
+	copy(cpy, counter.Shards)
+	return Counter{Shards: cpy}
+}
+
+func (counter Counter) JoinCounter(other Counter) Counter {
+	shards := make([]CounterShard, 0, len(counter.Shards)+len(other.Shards))
+	shards = append(shards, counter.Shards...)
+	shards = append(shards, other.Shards...)
+	return MakeCounter(shards)
+}
+
+func (counter Counter) Equals(other Counter) bool {
+	if len(counter.Shards) != len(other.Shards) {
+		return false
+	}
+
+	for i, myShard := range counter.Shards {
+		if myShard != other.Shards[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type byCounterShardReplica []CounterShard
+
+func (shards byCounterShardReplica) Len() int {
+	return len(shards)
+}
+
+func (shards byCounterShardReplica) Swap(i, j int) {
+	shards[i], shards[j] = shards[j], shards[i]
+}
+
+func (shards byCounterShardReplica) Less(i, j int) bool {
+	return shards[i].Replica < shards[j].Replica
+}
+
+func uniqCounterShardSorted(shards []CounterShard) []CounterShard {
+	if len(shards) < 2 {
+		return shards
+	}
+
+	uniqIndex := 0
+	for i := 1; i < len(shards); i++ {
+		shard := shards[i]
+		last := &shards[uniqIndex]
+		if shard.Replica == last.Replica {
+			*last = last.joinShard(shard)
+		} else {
+			uniqIndex++
+			shards[uniqIndex] = shard
+		}
+	}
+
+	return shards[:uniqIndex+1]
+}
@@ -0,0 +1,77 @@
+package crdt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestMakeCounterShard(t *testing.T) {
+	testutil.AssertEquals(t, "Unexpected increment", CounterShard{Replica: "a", Increments: 2}, MakeCounterShard("a", 2))
+	testutil.AssertEquals(t, "Unexpected decrement", CounterShard{Replica: "a", Decrements: 2}, MakeCounterShard("a", -2))
+}
+
+func TestCounterJoin(t *testing.T) {
+	counter := MakeCounter([]CounterShard{
+		CounterShard{Replica: "b", Increments: 1},
+		CounterShard{Replica: "a", Increments: 4, Decrements: 1},
+		CounterShard{Replica: "a", Increments: 2, Decrements: 3},
+	})
+
+	expected := Counter{Shards: []CounterShard{
+		CounterShard{Replica: "a", Increments: 4, Decrements: 3},
+		CounterShard{Replica: "b", Increments: 1},
+	}}
+
+	value, err := counter.Value()
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected counter", expected, counter)
+	testutil.AssertEquals(t, "Unexpected value", int64(2), value)
+	testutil.Assert(t, "Expected idempotent join", counter.Equals(counter.JoinCounter(counter)))
+}
+
+func TestCounterShardAdd(t *testing.T) {
+	shard, err := MakeCounterShard("a", 2).Add(-3)
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected shard", CounterShard{Replica: "a", Increments: 2, Decrements: 3}, shard)
+
+	full := CounterShard{Replica: "a", Increments: math.MaxUint64}
+	_, err = full.Add(1)
+	testutil.AssertNonNil(t, err)
+
+	shard, err = full.Add(math.MinInt64)
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected decrements", uint64(1<<63), shard.Decrements)
+}
+
+func TestCounterOverflow(t *testing.T) {
+	counter := MakeCounter([]CounterShard{
+		CounterShard{Replica: "a", Increments: math.MaxUint64},
+		CounterShard{Replica: "b", Increments: math.MaxUint64},
+	})
+
+	_, err := counter.Value()
+	testutil.AssertNonNil(t, err)
+	testutil.AssertEquals(t, "Unexpected total", "36893488147419103230", counter.Total().String())
+	testutil.AssertEquals(t, "Unexpected point", UnsignedPoint("36893488147419103230"), counter.Point())
+}
+
+func TestCounterWithout(t *testing.T) {
+	counter := MakeCounter([]CounterShard{
+		CounterShard{Replica: "a", Increments: 4, Decrements: 1},
+		CounterShard{Replica: "b", Increments: 2},
+	})
+
+	removed := MakeCounter([]CounterShard{
+		CounterShard{Replica: "a", Increments: 3, Decrements: 1},
+		CounterShard{Replica: "b", Increments: 2},
+	})
+
+	expected := Counter{Shards: []CounterShard{
+		CounterShard{Replica: "a", Increments: 1},
+	}}
+
+	testutil.AssertEquals(t, "Unexpected counter", expected, counter.Without(removed))
+	testutil.Assert(t, "Expected empty counter", counter.Without(counter).IsEmpty())
+}
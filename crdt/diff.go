@@ -0,0 +1,205 @@
+package crdt
+
+import (
+	"sort"
+
+	"github.com/johnny-morrice/godless/log"
+)
+
+// RowPath names a row in a Namespace.
+type RowPath struct {
+	Table TableName
+	Row   RowName
+}
+
+// EntryPath names an entry in a Namespace.
+type EntryPath struct {
+	Table TableName
+	Row   RowName
+	Entry EntryName
+}
+
+// NamespaceDiff holds what one Namespace adds to another.  Points are listed
+// as stream entries, one for each signature, so a point that is only newly
+// signed is included with the new signature.
+type NamespaceDiff struct {
+	Tables  []TableName
+	Rows    []RowPath
+	Entries []EntryPath
+	Points  []NamespaceStreamEntry
+}
+
+func (diff NamespaceDiff) IsEmpty() bool {
+	return len(diff.Tables) == 0 && len(diff.Rows) == 0 && len(diff.Entries) == 0 && len(diff.Points) == 0
+}
+
+// DiffNamespace finds the tables, rows, entries and points in b that are not
+// in a.
+func DiffNamespace(a, b Namespace) NamespaceDiff {
+	mine := makeDiffStream(a)
+	theirs := makeDiffStream(b)
+
+	diff := NamespaceDiff{
+		Points: differenceNamespaceStream(theirs, mine),
+	}
+
+	for t, theirTable := range b.Tables {
+		myTable, tableErr := a.GetTable(t)
+
+		if tableErr != nil {
+			diff.Tables = append(diff.Tables, t)
+		}
+
+		for r, theirRow := range theirTable.Rows {
+			myRow, rowErr := myTable.GetRow(r)
+
+			if rowErr != nil {
+				diff.Rows = append(diff.Rows, RowPath{Table: t, Row: r})
+			}
+
+			for e := range theirRow.Entries {
+				if _, entryErr := myRow.GetEntry(e); entryErr != nil {
+					diff.Entries = append(diff.Entries, EntryPath{Table: t, Row: r, Entry: e})
+				}
+			}
+		}
+	}
+
+	sort.Sort(byTableName(diff.Tables))
+	sort.Sort(byRowPath(diff.Rows))
+	sort.Sort(byEntryPath(diff.Entries))
+
+	return diff
+}
+
+// IndexDiff holds what one Index adds to another.  Links are listed as stream
+// entries, one for each signature.
+type IndexDiff struct {
+	Tables []TableName
+	Links  []IndexStreamEntry
+}
+
+func (diff IndexDiff) IsEmpty() bool {
+	return len(diff.Tables) == 0 && len(diff.Links) == 0
+}
+
+// DiffIndex finds the tables and links in b that are not in a.
+func DiffIndex(a, b Index) IndexDiff {
+	mine, invalid := MakeIndexStream(a)
+	logInvalidDiffEntries(len(invalid))
+
+	theirs, invalid := MakeIndexStream(b)
+	logInvalidDiffEntries(len(invalid))
+
+	diff := IndexDiff{
+		Links: differenceIndexStream(theirs, mine),
+	}
+
+	for _, t := range b.AllTables() {
+		if _, present := a.Index[t]; !present {
+			diff.Tables = append(diff.Tables, t)
+		}
+	}
+
+	sort.Sort(byTableName(diff.Tables))
+
+	return diff
+}
+
+func makeDiffStream(ns Namespace) []NamespaceStreamEntry {
+	stream, invalid := MakeNamespaceStream(ns)
+	logInvalidDiffEntries(len(invalid))
+	return stream
+}
+
+func logInvalidDiffEntries(count int) {
+	if count > 0 {
+		log.Warn("Diff skipped %d invalid entries", count)
+	}
+}
+
+// differenceNamespaceStream keeps the entries in stream that are not in other.
+// Both streams must be in stream order.
+func differenceNamespaceStream(stream, other []NamespaceStreamEntry) []NamespaceStreamEntry {
+	diff := []NamespaceStreamEntry{}
+
+	j := 0
+	for _, entry := range stream {
+		for j < len(other) && namespaceStreamLess(other[j], entry) {
+			j++
+		}
+
+		if j < len(other) && other[j] == entry {
+			continue
+		}
+
+		diff = append(diff, entry)
+	}
+
+	return diff
+}
+
+// differenceIndexStream keeps the entries in stream that are not in other.
+// Both streams must be in stream order.
+func differenceIndexStream(stream, other []IndexStreamEntry) []IndexStreamEntry {
+	diff := []IndexStreamEntry{}
+
+	j := 0
+	for _, entry := range stream {
+		for j < len(other) && indexStreamLess(other[j], entry) {
+			j++
+		}
+
+		if j < len(other) && other[j] == entry {
+			continue
+		}
+
+		diff = append(diff, entry)
+	}
+
+	return diff
+}
+
+type byRowPath []RowPath
+
+func (paths byRowPath) Len() int {
+	return len(paths)
+}
+
+func (paths byRowPath) Swap(i, j int) {
+	paths[i], paths[j] = paths[j], paths[i]
+}
+
+func (paths byRowPath) Less(i, j int) bool {
+	a, b := paths[i], paths[j]
+
+	if a.Table != b.Table {
+		return a.Table < b.Table
+	}
+
+	return a.Row < b.Row
+}
+
+type byEntryPath []EntryPath
+
+func (paths byEntryPath) Len() int {
+	return len(paths)
+}
+
+func (paths byEntryPath) Swap(i, j int) {
+	paths[i], paths[j] = paths[j], paths[i]
+}
+
+func (paths byEntryPath) Less(i, j int) bool {
+	a, b := paths[i], paths[j]
+
+	if a.Table != b.Table {
+		return a.Table < b.Table
+	}
+
+	if a.Row != b.Row {
+		return a.Row < b.Row
+	}
+
+	return a.Entry < b.Entry
+}
@@ -0,0 +1,96 @@
+package crdt
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/johnny-morrice/godless/crypto"
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestDiffNamespace(t *testing.T) {
+	priv, _, err := crypto.GenerateKey()
+	testutil.AssertNil(t, err)
+
+	signed, err := SignedPoint("Mr Blogs", []crypto.PrivateKey{priv})
+	testutil.AssertNil(t, err)
+
+	a := EmptyNamespace().JoinTable("cars", MakeTable(map[RowName]Row{
+		"car1": MakeRow(map[EntryName]Entry{
+			"driver": MakeEntry([]Point{UnsignedPoint("Mr Blogs")}),
+		}),
+	}))
+
+	b := a.JoinTable("cars", MakeTable(map[RowName]Row{
+		"car1": MakeRow(map[EntryName]Entry{
+			"driver": MakeEntry([]Point{signed}),
+		}),
+		"car2": MakeRow(map[EntryName]Entry{
+			"driver": MakeEntry([]Point{UnsignedPoint("Mrs Blogs")}),
+		}),
+	}))
+	b = b.JoinTable("bikes", MakeTable(map[RowName]Row{
+		"bike1": MakeRow(map[EntryName]Entry{
+			"rider": MakeEntry([]Point{UnsignedPoint("Mr Blogs")}),
+		}),
+	}))
+
+	diff := DiffNamespace(a, b)
+
+	testutil.AssertEquals(t, "Unexpected tables", []TableName{"bikes"}, diff.Tables)
+	testutil.AssertEquals(t, "Unexpected rows", []RowPath{
+		RowPath{Table: "bikes", Row: "bike1"},
+		RowPath{Table: "cars", Row: "car2"},
+	}, diff.Rows)
+	testutil.AssertEquals(t, "Unexpected entries", []EntryPath{
+		EntryPath{Table: "bikes", Row: "bike1", Entry: "rider"},
+		EntryPath{Table: "cars", Row: "car2", Entry: "driver"},
+	}, diff.Entries)
+
+	testutil.AssertEquals(t, "Unexpected point count", 3, len(diff.Points))
+
+	newlySigned := false
+	for _, entry := range diff.Points {
+		if entry.Table == "cars" && entry.Row == "car1" {
+			newlySigned = !crypto.IsNilSignature(entry.Point.Signature)
+		}
+	}
+
+	testutil.Assert(t, "Expected newly signed point", newlySigned)
+	testutil.Assert(t, "Expected empty diff", DiffNamespace(b, b).IsEmpty())
+}
+
+func TestDiffNamespaceSelf(t *testing.T) {
+	config := &quick.Config{
+		MaxCount: testutil.ENCODE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(diffNamespaceSelfOk, config)
+
+	testutil.AssertVerboseErrorIsNil(t, err)
+}
+
+func diffNamespaceSelfOk(ns Namespace) bool {
+	stream, _ := MakeNamespaceStream(ns)
+	all := DiffNamespace(EmptyNamespace(), ns)
+
+	return DiffNamespace(ns, ns).IsEmpty() && len(all.Points) == len(stream) && len(all.Tables) == len(ns.Tables)
+}
+
+func TestDiffIndex(t *testing.T) {
+	a := MakeIndex(map[TableName]Link{
+		"Kept": UnsignedLink("Addr A"),
+	})
+
+	b := a.JoinTable("Kept", UnsignedLink("Addr B"))
+	b = b.JoinTable("Added", UnsignedLink("Addr C"))
+
+	diff := DiffIndex(a, b)
+
+	testutil.AssertEquals(t, "Unexpected tables", []TableName{"Added"}, diff.Tables)
+	testutil.AssertEquals(t, "Unexpected links", []IndexStreamEntry{
+		IndexStreamEntry{TableName: "Added", Link: "Addr C"},
+		IndexStreamEntry{TableName: "Kept", Link: "Addr B"},
+	}, diff.Links)
+	testutil.Assert(t, "Expected empty diff", DiffIndex(b, a).IsEmpty())
+}
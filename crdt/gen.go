@@ -47,6 +47,29 @@ func genRow(rand *rand.Rand, size int) Row {
 	for k := 0; k < entryCount; k++ {
 		entryName := EntryName(testutil.RandLetters(rand, maxStr))
 		entry := GenEntry(rand, size)
+
+		if rand.Float32() < 0.2 {
+			entry = genStampedEntry(rand, entry)
+		}
+
+		if rand.Float32() < 0.3 {
+			entry = genTaggedEntry(rand, entry)
+		}
+
+		if rand.Float32() < 0.1 {
+			counter := genCounterEntry(rand, size)
+			entry = entry.JoinEntry(counter)
+
+			if rand.Float32() < 0.5 {
+				entry = entry.JoinEntry(MakeCounterTombstoneEntry(counter.Counter.Shards[:1]))
+			}
+		}
+
+		if rand.Float32() < 0.2 {
+			tombstone := MakeTombstoneEntry([]Point{entry.Set[0]})
+			entry = entry.JoinEntry(tombstone)
+		}
+
 		row.addEntry(entryName, entry)
 	}
 	return row
@@ -72,6 +95,52 @@ func GenEntry(rand *rand.Rand, size int) Entry {
 	return MakeEntry(points)
 }
 
+func genStampedEntry(rand *rand.Rand, entry Entry) Entry {
+	stamped := make([]Point, len(entry.Set))
+
+	for i, point := range entry.Set {
+		stamped[i] = point.Stamp(genTimestamp(rand))
+	}
+
+	return MakeEntry(stamped)
+}
+
+func genTaggedEntry(rand *rand.Rand, entry Entry) Entry {
+	tagged := make([]Point, len(entry.Set))
+
+	for i, point := range entry.Set {
+		tagged[i] = point.Tagged(genTimestamp(rand))
+	}
+
+	return MakeEntry(tagged)
+}
+
+func genTimestamp(rand *rand.Rand) Timestamp {
+	const maxNode = 5
+
+	return Timestamp{
+		Wall:    rand.Int63(),
+		Logical: rand.Uint32(),
+		Node:    testutil.RandLettersRange(rand, 1, maxNode),
+	}
+}
+
+func genCounterEntry(rand *rand.Rand, size int) Entry {
+	const maxReplica = 10
+	shardCount := testutil.GenCountRange(rand, 1, size)
+	shards := make([]CounterShard, shardCount)
+
+	for i := 0; i < shardCount; i++ {
+		shards[i] = CounterShard{
+			Replica:    testutil.RandLettersRange(rand, 1, maxReplica),
+			Increments: uint64(rand.Uint32()),
+			Decrements: uint64(rand.Uint32()),
+		}
+	}
+
+	return MakeCounterEntry(shards)
+}
+
 func genPoint(rand *rand.Rand, size int) Point {
 	return UnsignedPoint(PointText(testutil.RandLettersRange(rand, 1, size)))
 }
@@ -101,6 +170,21 @@ func GenIndex(rand *rand.Rand, size int) Index {
 		index.addTable(indexKey, addrs...)
 	}
 
+	if rand.Float32() < 0.5 {
+		parentCount := testutil.GenCountRange(rand, 1, 2)
+		for i := 0; i < parentCount; i++ {
+			parent := IPFSPath(testutil.RandLettersRange(rand, 1, size))
+			index.Parents = append(index.Parents, parent)
+		}
+
+		index.Created = rand.Int63()
+	}
+
+	if rand.Float32() < 0.25 {
+		index.Base = IPFSPath(testutil.RandLettersRange(rand, 1, size))
+		index.Head = IPFSPath(testutil.RandLettersRange(rand, 1, size))
+	}
+
 	return index
 }
 
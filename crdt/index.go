@@ -1,9 +1,11 @@
 package crdt
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
 
 	"github.com/johnny-morrice/godless/crypto"
 	"github.com/johnny-morrice/godless/log"
@@ -15,6 +17,17 @@ import (
 
 type Index struct {
 	Index map[TableName][]Link
+	// Parents are the addresses of the indices this one succeeded as HEAD.
+	Parents []IPFSPath
+	// Created is the unix time in nanoseconds when the index was written as
+	// HEAD.  It is read from the writer's HybridClock, so no Timestamp in the
+	// index is later.
+	Created int64
+	// Base is set when the index is a delta, holding only the links added
+	// since the index at Base.
+	Base IPFSPath
+	// Head is the full index that a delta was taken from.
+	Head IPFSPath
 }
 
 func EmptyIndex() Index {
@@ -33,6 +46,16 @@ func MakeIndex(indices map[TableName]Link) Index {
 	return out
 }
 
+// MakeIndexDelta holds the links in head that base does not have.  A peer
+// that has merged base can merge the delta instead of all of head.
+func MakeIndexDelta(head Index, headPath IPFSPath, base Index, basePath IPFSPath) Index {
+	delta := head.Difference(base)
+	delta.Base = basePath
+	delta.Head = headPath
+	delta.Created = head.Created
+	return delta
+}
+
 func EncodeIndex(index Index, w io.Writer) ([]InvalidIndexEntry, error) {
 	const failMsg = "EncodeIndex failed"
 
@@ -59,11 +82,24 @@ func EncodeIndex(index Index, w io.Writer) ([]InvalidIndexEntry, error) {
 	return invalid, nil
 }
 
+// DecodeIndex reads the stream encoding, or the single message encoding.
 func DecodeIndex(r io.Reader) (Index, []InvalidIndexEntry, error) {
 	const failMsg = "DecodeIndex failed"
 
+	buff := bufio.NewReader(r)
+
+	if IsStreamEncoded(buff) {
+		index, invalid, err := decodeIndexStream(buff)
+
+		if err != nil {
+			return __EMPTY_INDEX, invalid, errors.Wrap(err, failMsg)
+		}
+
+		return index, invalid, nil
+	}
+
 	message := &proto.IndexMessage{}
-	bs, err := ioutil.ReadAll(r)
+	bs, err := ioutil.ReadAll(buff)
 
 	if err != nil {
 		return __EMPTY_INDEX, nil, errors.Wrap(err, failMsg)
@@ -82,12 +118,44 @@ func DecodeIndex(r io.Reader) (Index, []InvalidIndexEntry, error) {
 
 func ReadIndexMessage(message *proto.IndexMessage) (Index, []InvalidIndexEntry) {
 	stream := ReadIndexStreamMessage(message)
-	return ReadIndexStream(stream)
+	index, invalid := ReadIndexStream(stream)
+
+	return readIndexHistory(index, message), invalid
+}
+
+func readIndexHistory(index Index, message *proto.IndexMessage) Index {
+	for _, parent := range message.Parents {
+		index.Parents = append(index.Parents, IPFSPath(parent))
+	}
+
+	index.Created = message.Created
+	index.Base = IPFSPath(message.Base)
+	index.Head = IPFSPath(message.Head)
+
+	return index
 }
 
 func MakeIndexMessage(index Index) (*proto.IndexMessage, []InvalidIndexEntry) {
 	stream, invalid := MakeIndexStream(index)
-	return MakeIndexStreamMessage(stream), invalid
+	message := MakeIndexStreamMessage(stream)
+
+	writeIndexHistory(index, message)
+
+	return message, invalid
+}
+
+func writeIndexHistory(index Index, message *proto.IndexMessage) {
+	for _, parent := range index.Parents {
+		message.Parents = append(message.Parents, string(parent))
+	}
+
+	message.Created = index.Created
+	message.Base = string(index.Base)
+	message.Head = string(index.Head)
+}
+
+func (index Index) IsDelta() bool {
+	return !IsNilPath(index.Base)
 }
 
 func (index Index) IsEmpty() bool {
@@ -110,8 +178,13 @@ func (index Index) ForTable(tableName TableName, f func(link Link)) error {
 	return nil
 }
 
+// JoinIndex does not keep history: the joined index has no parents.
 func (index Index) JoinIndex(other Index) Index {
 	cpy := index.Copy()
+	cpy.Parents = nil
+	cpy.Created = 0
+	cpy.Base = NIL_PATH
+	cpy.Head = NIL_PATH
 
 	for table, addrs := range other.Index {
 		cpy.addTable(table, addrs...)
@@ -140,7 +213,7 @@ func (index Index) addStreamEntry(entry IndexStreamEntry) error {
 	return nil
 }
 
-// Equals does not take into account any invalid signatures.
+// Equals does not take into account any invalid signatures, nor the history.
 func (index Index) Equals(other Index) bool {
 	if len(index.Index) != len(other.Index) {
 		return false
@@ -172,6 +245,87 @@ func (index Index) Equals(other Index) bool {
 	return true
 }
 
+// SameHistory is true when both indices have the same parents, creation time
+// and delta base.
+func (index Index) SameHistory(other Index) bool {
+	if index.Created != other.Created || len(index.Parents) != len(other.Parents) {
+		return false
+	}
+
+	if index.Base != other.Base || index.Head != other.Head {
+		return false
+	}
+
+	for i, parent := range index.Parents {
+		if parent != other.Parents[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ChangedTables lists the tables whose links differ from those in the parent.
+func (index Index) ChangedTables(parent Index) []TableName {
+	changed := []TableName{}
+
+	for _, table := range index.AllTables() {
+		if !sameLinks(index.Index[table], parent.Index[table]) {
+			changed = append(changed, table)
+		}
+	}
+
+	for _, table := range parent.AllTables() {
+		if _, present := index.Index[table]; !present {
+			changed = append(changed, table)
+		}
+	}
+
+	sort.Sort(byTableName(changed))
+
+	return changed
+}
+
+// Difference keeps the links that other does not have for the same table.
+// Links are compared by path, so signatures are ignored.
+func (index Index) Difference(other Index) Index {
+	diff := EmptyIndex()
+
+	for table, links := range index.Index {
+		for _, link := range links {
+			if !other.hasPath(table, link.Path()) {
+				diff.addTable(table, link)
+			}
+		}
+	}
+
+	return diff
+}
+
+func (index Index) hasPath(table TableName, path IPFSPath) bool {
+	for _, link := range index.Index[table] {
+		if link.Path() == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameLinks(links, other []Link) bool {
+	if len(links) != len(other) {
+		return false
+	}
+
+	for i, link := range links {
+		if !link.Equals(other[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (index Index) AllTables() []TableName {
 	tables := make([]TableName, 0, len(index.Index))
 
@@ -231,6 +385,15 @@ func (index Index) Copy() Index {
 		cpy.Index[table] = addrCopy
 	}
 
+	if len(index.Parents) > 0 {
+		cpy.Parents = make([]IPFSPath, len(index.Parents))
+		copy(cpy.Parents, index.Parents)
+	}
+
+	cpy.Created = index.Created
+	cpy.Base = index.Base
+	cpy.Head = index.Head
+
 	return cpy
 }
 
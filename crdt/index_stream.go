@@ -56,9 +56,10 @@ func (stream byIndexStreamOrder) Swap(i, j int) {
 }
 
 func (stream byIndexStreamOrder) Less(i, j int) bool {
-	a := stream[i]
-	b := stream[j]
+	return indexStreamLess(stream[i], stream[j])
+}
 
+func indexStreamLess(a, b IndexStreamEntry) bool {
 	if a.TableName < b.TableName {
 		return true
 	} else if a.TableName > b.TableName {
@@ -136,7 +136,70 @@ func TestIndexCopy(t *testing.T) {
 
 func indexCopyOk(expected Index) bool {
 	actual := expected.Copy()
-	return expected.Equals(actual)
+	return expected.Equals(actual) && expected.SameHistory(actual)
+}
+
+func TestIndexDifference(t *testing.T) {
+	index := MakeIndex(map[TableName]Link{
+		"Kept":    UnsignedLink("Addr A"),
+		"Changed": UnsignedLink("Addr B"),
+	})
+	index = index.JoinTable("Changed", UnsignedLink("Addr C"))
+
+	other := MakeIndex(map[TableName]Link{
+		"Kept":    UnsignedLink("Addr A"),
+		"Changed": UnsignedLink("Addr B"),
+		"Other":   UnsignedLink("Addr C"),
+	})
+
+	expected := MakeIndex(map[TableName]Link{
+		"Changed": UnsignedLink("Addr C"),
+	})
+	actual := index.Difference(other)
+
+	testutil.Assert(t, "Unexpected difference", expected.Equals(actual))
+	testutil.Assert(t, "Expected empty difference", index.Difference(index).IsEmpty())
+}
+
+func TestMakeIndexDelta(t *testing.T) {
+	base := MakeIndex(map[TableName]Link{
+		"Kept": UnsignedLink("Addr A"),
+	})
+	head := base.JoinTable("Added", UnsignedLink("Addr B"))
+	head.Created = 1
+
+	expected := MakeIndex(map[TableName]Link{
+		"Added": UnsignedLink("Addr B"),
+	})
+	actual := MakeIndexDelta(head, "Head addr", base, "Base addr")
+
+	testutil.Assert(t, "Unexpected delta", expected.Equals(actual))
+	testutil.Assert(t, "Expected delta", actual.IsDelta())
+	testutil.AssertEquals(t, "Unexpected base", IPFSPath("Base addr"), actual.Base)
+	testutil.AssertEquals(t, "Unexpected head", IPFSPath("Head addr"), actual.Head)
+	testutil.AssertEquals(t, "Unexpected created time", head.Created, actual.Created)
+	testutil.Assert(t, "Expected full index", !head.IsDelta())
+	testutil.Assert(t, "Expected joined index to be full", !actual.JoinIndex(base).IsDelta())
+}
+
+func TestIndexChangedTables(t *testing.T) {
+	parent := MakeIndex(map[TableName]Link{
+		"Kept":    UnsignedLink("Addr A"),
+		"Changed": UnsignedLink("Addr B"),
+		"Removed": UnsignedLink("Addr C"),
+	})
+
+	index := MakeIndex(map[TableName]Link{
+		"Kept":    UnsignedLink("Addr A"),
+		"Changed": UnsignedLink("Addr D"),
+		"Added":   UnsignedLink("Addr E"),
+	})
+
+	expected := []TableName{"Added", "Changed", "Removed"}
+	actual := index.ChangedTables(parent)
+
+	testutil.AssertEquals(t, "Unexpected changed tables", expected, actual)
+	testutil.AssertEquals(t, "Unexpected changed tables", 0, len(index.ChangedTables(index)))
 }
 
 func TestIndexEquals(t *testing.T) {
@@ -286,7 +349,7 @@ func TestIndexJoinNamespace(t *testing.T) {
 
 func indexEncodeOk(expected Index) bool {
 	actual := indexSerializationPass(expected)
-	same := expected.Equals(actual)
+	same := expected.Equals(actual) && expected.SameHistory(actual)
 
 	if !same {
 		expectedText := indexText(expected)
@@ -89,6 +89,47 @@ func (ns Namespace) addEntry(t TableName, r RowName, e EntryName, entry Entry) {
 	ns.addTable(t, table)
 }
 
+// ApplyTombstones returns a Namespace containing only the points that have not
+// been removed, with last-writer-wins entries reduced to their newest point
+// and counters reduced to their total.
+// Entries, Rows and Tables with nothing left are dropped.  The result has lost
+// its tombstones, so it should be shown to users but never joined back into
+// the store.
+func (ns Namespace) ApplyTombstones() Namespace {
+	visible := EmptyNamespace()
+
+	ns.ForeachEntry(func(t TableName, r RowName, e EntryName, entry Entry) {
+		values := entry.GetValues()
+
+		if len(values) == 0 {
+			return
+		}
+
+		visible.addEntry(t, r, e, MakeEntry(values))
+	})
+
+	return visible
+}
+
+// LatestTimestamp finds the newest Timestamp or Tag on any point or
+// tombstone in the Namespace.
+func (ns Namespace) LatestTimestamp() Timestamp {
+	latest := Timestamp{}
+
+	ns.ForeachEntry(func(t TableName, r RowName, e EntryName, entry Entry) {
+		for _, p := range entry.Set {
+			latest = latestTimestamp(latest, p.timestamp)
+			latest = latestTimestamp(latest, p.tag)
+		}
+
+		for _, p := range entry.Tombstones {
+			latest = latestTimestamp(latest, p.tag)
+		}
+	})
+
+	return latest
+}
+
 // Strip removes empty tables and rows that would not be saved to the backing store.
 func (ns Namespace) Strip() (Namespace, []InvalidNamespaceEntry) {
 	const failMsg = "Namespace.Strip failed"
@@ -126,18 +167,25 @@ func (ns Namespace) addPointBatch(stream []NamespaceStreamEntry) ([]InvalidNames
 		return nil, nil
 	}
 
+	first := stream[0]
+
+	if first.IsCounter() {
+		shards := make([]CounterShard, len(stream))
+		for i, entry := range stream {
+			shards[i] = entry.Counter
+		}
+
+		ns.addEntry(first.Table, first.Row, first.Entry, makeStreamCounterEntry(first, shards))
+		return nil, nil
+	}
+
 	point, invalid, err := readStreamPoint(stream)
 
 	if err != nil {
 		return invalid, errors.Wrap(err, failMsg)
 	}
 
-	first := stream[0]
-	tableName := first.Table
-	rowName := first.Row
-	entryName := first.Entry
-
-	ns.addPoint(tableName, rowName, entryName, point)
+	ns.addStreamPoint(first, point)
 
 	return invalid, nil
 }
@@ -145,6 +193,12 @@ func (ns Namespace) addPointBatch(stream []NamespaceStreamEntry) ([]InvalidNames
 func (ns Namespace) addStreamEntry(entry NamespaceStreamEntry) error {
 	const failMsg = "Namespace.addStreamEntry failed"
 
+	if entry.IsCounter() {
+		counter := makeStreamCounterEntry(entry, []CounterShard{entry.Counter})
+		ns.addEntry(entry.Table, entry.Row, entry.Entry, counter)
+		return nil
+	}
+
 	var sigs []crypto.Signature
 
 	if !crypto.IsNilSignature(entry.Point.Signature) {
@@ -157,20 +211,34 @@ func (ns Namespace) addStreamEntry(entry NamespaceStreamEntry) error {
 		sigs = []crypto.Signature{sig}
 	}
 
-	point := PresignedPoint(entry.Point.Text, sigs)
+	point := PresignedPoint(entry.Point.Text, sigs).Stamp(entry.Point.Timestamp).Tagged(entry.Point.Tag)
 
-	ns.addPoint(entry.Table, entry.Row, entry.Entry, point)
+	ns.addStreamPoint(entry, point)
 	return nil
 }
 
+func makeStreamCounterEntry(entry NamespaceStreamEntry, shards []CounterShard) Entry {
+	if entry.Tombstone {
+		return MakeCounterTombstoneEntry(shards)
+	}
+
+	return MakeCounterEntry(shards)
+}
+
+func (ns Namespace) addStreamPoint(entry NamespaceStreamEntry, point Point) {
+	if entry.Tombstone {
+		ns.addTombstone(entry.Table, entry.Row, entry.Entry, point)
+	} else {
+		ns.addPoint(entry.Table, entry.Row, entry.Entry, point)
+	}
+}
+
 func (ns Namespace) addPoint(tableName TableName, rowName RowName, entryName EntryName, point Point) {
-	table := MakeTable(map[RowName]Row{
-		rowName: MakeRow(map[EntryName]Entry{
-			entryName: MakeEntry([]Point{point}),
-		}),
-	})
+	ns.addEntry(tableName, rowName, entryName, MakeEntry([]Point{point}))
+}
 
-	ns.addTable(tableName, table)
+func (ns Namespace) addTombstone(tableName TableName, rowName RowName, entryName EntryName, point Point) {
+	ns.addEntry(tableName, rowName, entryName, MakeTombstoneEntry([]Point{point}))
 }
 
 func (ns Namespace) IsEmpty() bool {
@@ -197,6 +265,13 @@ func (ns Namespace) JoinNamespace(other Namespace) Namespace {
 	return joined
 }
 
+// AddTable is destructive: the table is joined into the namespace in place,
+// and may be shared with it afterwards.  Joining many tables into one
+// namespace this way avoids copying the namespace for each.
+func (ns Namespace) AddTable(key TableName, table Table) {
+	ns.addTable(key, table)
+}
+
 func (ns Namespace) addTable(key TableName, table Table) {
 	current, present := ns.Tables[key]
 
@@ -461,8 +536,20 @@ func (row Row) Equals(other Row) bool {
 	return true
 }
 
+// Entry is an observed-remove set of Points.  Each add is tagged with a clock
+// reading, and a Tombstone carries the tag of the add it observed.  A Point is
+// removed when a Tombstone with the same text is tagged no earlier than the
+// Point, so adding the text again after a delete brings it back.  Both sets
+// only ever grow, so joins commute and removal converges across peers.
+//
+// An Entry with Counter shards is a counter, and its value is the Counter total.
+// CounterTombstones hold the shards observed when the counter was deleted, so
+// only what was added since is counted.
 type Entry struct {
-	Set []Point
+	Set               []Point
+	Tombstones        []Point `json:",omitempty"`
+	Counter           Counter `json:",omitempty"`
+	CounterTombstones Counter `json:",omitempty"`
 }
 
 func EmptyEntry() Entry {
@@ -475,36 +562,102 @@ func MakeEntry(set []Point) Entry {
 	return Entry{Set: undupes}
 }
 
+// MakeTombstoneEntry creates an Entry that removes points with the same text
+// as the tombstones, and tagged no later than them.
+func MakeTombstoneEntry(tombstones []Point) Entry {
+	entry := EmptyEntry()
+	entry.Tombstones = makeTombstones(tombstones)
+	return entry
+}
+
+// MakeCounterEntry creates an Entry that adds the shards to a counter.
+func MakeCounterEntry(shards []CounterShard) Entry {
+	entry := EmptyEntry()
+	entry.Counter = MakeCounter(shards)
+	return entry
+}
+
+// MakeCounterTombstoneEntry creates an Entry that deletes the counter totals
+// in the shards.
+func MakeCounterTombstoneEntry(shards []CounterShard) Entry {
+	entry := EmptyEntry()
+	entry.CounterTombstones = MakeCounter(shards)
+	return entry
+}
+
+func makeTombstones(tombstones []Point) []Point {
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	sort.Sort(byPointValue(tombstones))
+	return uniqPointSorted(tombstones)
+}
+
+// FilterVerified keeps the Counter shards, which are not signed.  Signed
+// searches only load namespaces through links signed by the keys, and only
+// the writer adds shards to its namespaces, so the shards are trusted.
 func (e Entry) FilterVerified(keys []crypto.PublicKey) Entry {
-	verified := make([]Point, 0, len(e.Set))
+	verifiedEntry := Entry{
+		Set:               filterVerifiedPoints(e.Set, keys),
+		Tombstones:        makeTombstones(filterVerifiedPoints(e.Tombstones, keys)),
+		Counter:           e.Counter.Copy(),
+		CounterTombstones: e.CounterTombstones.Copy(),
+	}
 
-	for _, p := range e.Set {
+	return verifiedEntry
+}
+
+func filterVerifiedPoints(points []Point, keys []crypto.PublicKey) []Point {
+	verified := make([]Point, 0, len(points))
+
+	for _, p := range points {
 		if p.IsVerifiedByAny(keys) {
 			verified = append(verified, p)
 		}
 	}
 
-	verifiedEntry := Entry{Set: verified}
-
-	return verifiedEntry
+	return verified
 }
 
 func (e Entry) Copy() Entry {
-	return MakeEntry(e.Set)
+	cpy := MakeEntry(e.Set)
+	cpy.Tombstones = makeTombstones(e.Tombstones)
+	cpy.Counter = e.Counter.Copy()
+	cpy.CounterTombstones = e.CounterTombstones.Copy()
+	return cpy
 }
 
 func (e Entry) JoinEntry(other Entry) Entry {
-	return MakeEntry(append(e.Set, other.Set...))
+	joined := MakeEntry(concatPoints(e.Set, other.Set))
+	joined.Tombstones = makeTombstones(concatPoints(e.Tombstones, other.Tombstones))
+	joined.Counter = e.Counter.JoinCounter(other.Counter)
+	joined.CounterTombstones = e.CounterTombstones.JoinCounter(other.CounterTombstones)
+	return joined
+}
+
+// concatPoints does not share memory with its arguments, so sorting the result
+// cannot disturb either Entry.
+func concatPoints(mine, theirs []Point) []Point {
+	points := make([]Point, 0, len(mine)+len(theirs))
+	points = append(points, mine...)
+	return append(points, theirs...)
 }
 
 func (e Entry) Equals(other Entry) bool {
 	// Easy because Entry.set is deduplicated and sorted
-	if len(e.Set) != len(other.Set) {
+	ok := pointsEqual(e.Set, other.Set) && pointsEqual(e.Tombstones, other.Tombstones)
+	ok = ok && e.Counter.Equals(other.Counter)
+	return ok && e.CounterTombstones.Equals(other.CounterTombstones)
+}
+
+func pointsEqual(mine, theirs []Point) bool {
+	if len(mine) != len(theirs) {
 		return false
 	}
 
-	for i, myPoint := range e.Set {
-		theirPoint := other.Set[i]
+	for i, myPoint := range mine {
+		theirPoint := theirs[i]
 
 		if !myPoint.Equals(theirPoint) {
 			return false
@@ -514,12 +667,95 @@ func (e Entry) Equals(other Entry) bool {
 	return true
 }
 
+// GetValues returns the points that have not been removed by a tombstone.
+// If any of those points is stamped, the Entry is a last-writer-wins register
+// and only the newest point is returned.  A counter Entry returns its total,
+// unless it has been deleted and not changed since.
 func (e Entry) GetValues() []Point {
-	cpy := make([]Point, len(e.Set))
+	if e.IsCounter() {
+		return e.getCounterValues()
+	}
 
-	for i, p := range e.Set {
+	values := e.GetAllValues()
+
+	if !isLastWriterWins(values) {
+		return values
+	}
+
+	winner := values[0]
+
+	for _, p := range values[1:] {
+		if p.newerThan(winner) {
+			winner = p
+		}
+	}
+
+	return []Point{winner}
+}
+
+// GetAllValues returns the points that have not been removed by a tombstone,
+// including those overwritten in a last-writer-wins register.
+func (e Entry) GetAllValues() []Point {
+	cpy := make([]Point, 0, len(e.Set))
+
+	for _, p := range e.Set {
+		if !e.isRemoved(p) {
+			cpy = append(cpy, p)
+		}
+	}
+
+	return cpy
+}
+
+func (e Entry) getCounterValues() []Point {
+	if e.CounterTombstones.IsEmpty() {
+		return []Point{e.Counter.Point()}
+	}
+
+	remaining := e.Counter.Without(e.CounterTombstones)
+
+	if remaining.IsEmpty() {
+		return []Point{}
+	}
+
+	return []Point{remaining.Point()}
+}
+
+func (e Entry) IsCounter() bool {
+	return !e.Counter.IsEmpty() || !e.CounterTombstones.IsEmpty()
+}
+
+func isLastWriterWins(points []Point) bool {
+	for _, p := range points {
+		if p.IsStamped() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e Entry) GetTombstones() []Point {
+	cpy := make([]Point, len(e.Tombstones))
+
+	for i, p := range e.Tombstones {
 		cpy[i] = p
 	}
 
 	return cpy
 }
+
+// IsRemoved is true when all the points in the Entry have been removed.
+func (e Entry) IsRemoved() bool {
+	return len(e.GetValues()) == 0
+}
+
+func (e Entry) isRemoved(point Point) bool {
+	for _, tomb := range e.Tombstones {
+		if tomb.Text() == point.Text() && !tomb.tag.Less(point.tag) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package crdt
 
 import (
+	"bufio"
 	"io"
 
 	"github.com/johnny-morrice/godless/crypto"
@@ -13,40 +14,102 @@ import (
 
 func ReadNamespaceEntryMessage(message *proto.NamespaceEntryMessage) NamespaceStreamEntry {
 	entry := NamespaceStreamEntry{
-		Table: TableName(message.Table),
-		Row:   RowName(message.Row),
-		Entry: EntryName(message.Entry),
-		Point: ReadPointMessage(message.Point),
+		Table:     TableName(message.Table),
+		Row:       RowName(message.Row),
+		Entry:     EntryName(message.Entry),
+		Point:     ReadPointMessage(message.Point),
+		Tombstone: message.Tombstone,
+	}
+
+	if message.Counter != nil {
+		entry.Counter = ReadCounterShardMessage(message.Counter)
 	}
 
 	return entry
 }
 
 func ReadPointMessage(message *proto.PointMessage) StreamPoint {
-	return StreamPoint{
+	point := StreamPoint{
 		Text:      PointText(message.Text),
 		Signature: crypto.SignatureText(message.Signature),
 	}
+
+	if message.Timestamp != nil {
+		point.Timestamp = ReadTimestampMessage(message.Timestamp)
+	}
+
+	if message.Tag != nil {
+		point.Tag = ReadTimestampMessage(message.Tag)
+	}
+
+	return point
 }
 
 func MakePointMessage(point StreamPoint) *proto.PointMessage {
-	return &proto.PointMessage{
+	message := &proto.PointMessage{
 		Text:      string(point.Text),
 		Signature: string(point.Signature),
 	}
+
+	if !point.Timestamp.IsZero() {
+		message.Timestamp = MakeTimestampMessage(point.Timestamp)
+	}
+
+	if !point.Tag.IsZero() {
+		message.Tag = MakeTimestampMessage(point.Tag)
+	}
+
+	return message
+}
+
+func ReadTimestampMessage(message *proto.TimestampMessage) Timestamp {
+	return Timestamp{
+		Wall:    message.Wall,
+		Logical: message.Logical,
+		Node:    message.Node,
+	}
+}
+
+func MakeTimestampMessage(timestamp Timestamp) *proto.TimestampMessage {
+	return &proto.TimestampMessage{
+		Wall:    timestamp.Wall,
+		Logical: timestamp.Logical,
+		Node:    timestamp.Node,
+	}
 }
 
 func MakeNamespaceEntryMessage(entry NamespaceStreamEntry) *proto.NamespaceEntryMessage {
 	pb := &proto.NamespaceEntryMessage{
-		Table: string(entry.Table),
-		Row:   string(entry.Row),
-		Entry: string(entry.Entry),
-		Point: MakePointMessage(entry.Point),
+		Table:     string(entry.Table),
+		Row:       string(entry.Row),
+		Entry:     string(entry.Entry),
+		Point:     MakePointMessage(entry.Point),
+		Tombstone: entry.Tombstone,
+	}
+
+	if entry.IsCounter() {
+		pb.Counter = MakeCounterShardMessage(entry.Counter)
 	}
 
 	return pb
 }
 
+func ReadCounterShardMessage(message *proto.CounterShardMessage) CounterShard {
+	return CounterShard{
+		Replica:    message.Replica,
+		Increments: message.Increments,
+		Decrements: message.Decrements,
+	}
+}
+
+func MakeCounterShardMessage(shard CounterShard) *proto.CounterShardMessage {
+	return &proto.CounterShardMessage{
+		Replica:    shard.Replica,
+		Increments: shard.Increments,
+		Decrements: shard.Decrements,
+	}
+}
+
 func MakeNamespaceStreamMessage(stream []NamespaceStreamEntry) *proto.NamespaceMessage {
 	message := &proto.NamespaceMessage{Entries: make([]*proto.NamespaceEntryMessage, len(stream))}
 
@@ -110,10 +173,25 @@ func EncodeNamespace(ns Namespace, w io.Writer) ([]InvalidNamespaceEntry, error)
 	return invalid, nil
 }
 
+// DecodeNamespace reads the stream encoding, or the single message encoding.
+// A sharded namespace reads as empty.
 func DecodeNamespace(r io.Reader) (Namespace, []InvalidNamespaceEntry, error) {
 	const failMsg = "DecodeNamespace failed"
+
+	buff := bufio.NewReader(r)
+
+	if IsStreamEncoded(buff) {
+		node, invalid, err := decodeNamespaceNodeStream(buff)
+
+		if err != nil {
+			return EmptyNamespace(), invalid, errors.Wrap(err, failMsg)
+		}
+
+		return node.Namespace, invalid, nil
+	}
+
 	message := &proto.NamespaceMessage{}
-	err := util.Decode(message, r)
+	err := util.Decode(message, buff)
 
 	if err != nil {
 		return EmptyNamespace(), nil, errors.Wrap(err, failMsg)
@@ -0,0 +1,220 @@
+package crdt
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/internal/util"
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// NamespaceNode is a node in a sharded namespace: a hash array mapped trie
+// keyed by RowName.  A leaf holds a Namespace.  Any other node links to its
+// children by the slot of the row name hash at the node's depth, so a row is
+// found by following one link at each depth.
+type NamespaceNode struct {
+	Namespace Namespace
+	Shards    []ShardLink
+}
+
+// ShardLink is the address of a child node.
+type ShardLink struct {
+	Slot uint32
+	Path IPFSPath
+}
+
+func LeafNamespaceNode(namespace Namespace) NamespaceNode {
+	return NamespaceNode{Namespace: namespace}
+}
+
+func (node NamespaceNode) IsLeaf() bool {
+	return len(node.Shards) == 0
+}
+
+// RowShardSlot is the slot for the row at depth.  Each depth uses the next
+// SHARD_BITS of the SHA-256 hash of the row name.
+func RowShardSlot(row RowName, depth int) uint32 {
+	hash := sha256.Sum256([]byte(row))
+	nibble := hash[depth/2]
+
+	if depth%2 == 0 {
+		return uint32(nibble >> SHARD_BITS)
+	}
+
+	return uint32(nibble & (SHARD_WIDTH - 1))
+}
+
+// SplitShards divides the namespace among the slots for its rows at depth.
+func (ns Namespace) SplitShards(depth int) map[uint32]Namespace {
+	shards := map[uint32]Namespace{}
+
+	ns.ForeachRow(func(t TableName, r RowName, row Row) {
+		slot := RowShardSlot(r, depth)
+		shard, present := shards[slot]
+
+		if !present {
+			shard = EmptyNamespace()
+			shards[slot] = shard
+		}
+
+		shard.addRow(t, r, row)
+	})
+
+	return shards
+}
+
+// GroupRowShards divides the rows among their slots at depth.
+func GroupRowShards(rows []RowName, depth int) map[uint32][]RowName {
+	groups := map[uint32][]RowName{}
+
+	for _, r := range rows {
+		slot := RowShardSlot(r, depth)
+		groups[slot] = append(groups[slot], r)
+	}
+
+	return groups
+}
+
+// RowCount is the number of rows across all tables.
+func (ns Namespace) RowCount() int {
+	count := 0
+
+	for _, table := range ns.Tables {
+		count += len(table.Rows)
+	}
+
+	return count
+}
+
+func (ns Namespace) addRow(t TableName, r RowName, row Row) {
+	table, present := ns.Tables[t]
+
+	if !present {
+		table = EmptyTable()
+		ns.Tables[t] = table
+	}
+
+	table.addRow(r, row)
+}
+
+func MakeNamespaceNodeMessage(node NamespaceNode) (*proto.NamespaceMessage, []InvalidNamespaceEntry) {
+	if node.IsLeaf() {
+		return MakeNamespaceMessage(node.Namespace)
+	}
+
+	message := &proto.NamespaceMessage{
+		Shards: make([]*proto.ShardMessage, len(node.Shards)),
+	}
+
+	shards := make([]ShardLink, len(node.Shards))
+	copy(shards, node.Shards)
+	sort.Sort(byShardSlot(shards))
+
+	for i, shard := range shards {
+		message.Shards[i] = &proto.ShardMessage{
+			Slot: shard.Slot,
+			Link: string(shard.Path),
+		}
+	}
+
+	return message, nil
+}
+
+func ReadNamespaceNodeMessage(message *proto.NamespaceMessage) (NamespaceNode, []InvalidNamespaceEntry) {
+	if len(message.Shards) == 0 {
+		namespace, invalid := ReadNamespaceMessage(message)
+		return LeafNamespaceNode(namespace), invalid
+	}
+
+	if len(message.Entries) > 0 {
+		log.Warn("Ignoring %d entries in sharded NamespaceMessage", len(message.Entries))
+	}
+
+	node := NamespaceNode{
+		Namespace: EmptyNamespace(),
+		Shards:    make([]ShardLink, len(message.Shards)),
+	}
+
+	for i, shard := range message.Shards {
+		node.Shards[i] = ShardLink{
+			Slot: shard.Slot,
+			Path: IPFSPath(shard.Link),
+		}
+	}
+
+	return node, nil
+}
+
+func EncodeNamespaceNode(node NamespaceNode, w io.Writer) ([]InvalidNamespaceEntry, error) {
+	const failMsg = "EncodeNamespaceNode failed"
+
+	message, invalid := MakeNamespaceNodeMessage(node)
+
+	invalidCount := len(invalid)
+	if invalidCount > 0 {
+		log.Error("EncodeNamespaceNode: %d invalid points", invalidCount)
+	}
+
+	err := util.Encode(message, w)
+
+	if err != nil {
+		return invalid, errors.Wrap(err, failMsg)
+	}
+
+	return invalid, nil
+}
+
+// DecodeNamespaceNode reads the stream encoding, or the single message
+// encoding.
+func DecodeNamespaceNode(r io.Reader) (NamespaceNode, []InvalidNamespaceEntry, error) {
+	const failMsg = "DecodeNamespaceNode failed"
+
+	buff := bufio.NewReader(r)
+
+	if IsStreamEncoded(buff) {
+		node, invalid, err := decodeNamespaceNodeStream(buff)
+
+		if err != nil {
+			return LeafNamespaceNode(EmptyNamespace()), invalid, errors.Wrap(err, failMsg)
+		}
+
+		return node, invalid, nil
+	}
+
+	message := &proto.NamespaceMessage{}
+	err := util.Decode(message, buff)
+
+	if err != nil {
+		return LeafNamespaceNode(EmptyNamespace()), nil, errors.Wrap(err, failMsg)
+	}
+
+	node, invalid := ReadNamespaceNodeMessage(message)
+	return node, invalid, nil
+}
+
+type byShardSlot []ShardLink
+
+func (shards byShardSlot) Len() int {
+	return len(shards)
+}
+
+func (shards byShardSlot) Swap(i, j int) {
+	shards[i], shards[j] = shards[j], shards[i]
+}
+
+func (shards byShardSlot) Less(i, j int) bool {
+	return shards[i].Slot < shards[j].Slot
+}
+
+// SHARD_BITS of the row hash are used at each depth, giving SHARD_WIDTH
+// slots per node.
+const SHARD_BITS = 4
+const SHARD_WIDTH = 1 << SHARD_BITS
+
+// MAX_SHARD_DEPTH is the deepest node, where every bit of the hash is used.
+const MAX_SHARD_DEPTH = sha256.Size * 8 / SHARD_BITS
@@ -0,0 +1,88 @@
+package crdt
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestSplitShards(t *testing.T) {
+	config := &quick.Config{
+		MaxCount: testutil.ENCODE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(splitShardsOk, config)
+
+	testutil.AssertVerboseErrorIsNil(t, err)
+}
+
+func splitShardsOk(expected Namespace) bool {
+	const depth = 1
+	actual := EmptyNamespace()
+
+	for slot, shard := range expected.SplitShards(depth) {
+		if slot >= SHARD_WIDTH {
+			return false
+		}
+
+		wrongSlot := false
+		shard.ForeachRow(func(t TableName, r RowName, row Row) {
+			wrongSlot = wrongSlot || RowShardSlot(r, depth) != slot
+		})
+
+		if wrongSlot {
+			return false
+		}
+
+		actual = actual.JoinNamespace(shard)
+	}
+
+	return expected.Equals(actual)
+}
+
+func TestRowShardSlot(t *testing.T) {
+	const row = RowName("car1")
+
+	for depth := 0; depth < MAX_SHARD_DEPTH; depth++ {
+		slot := RowShardSlot(row, depth)
+		testutil.Assert(t, "Slot out of range", slot < SHARD_WIDTH)
+		testutil.AssertEquals(t, "Unstable slot", slot, RowShardSlot(row, depth))
+	}
+
+	groups := GroupRowShards([]RowName{row, row}, 0)
+	testutil.AssertEquals(t, "Unexpected group count", 1, len(groups))
+	testutil.AssertEquals(t, "Unexpected group size", 2, len(groups[RowShardSlot(row, 0)]))
+}
+
+func TestEncodeNamespaceNode(t *testing.T) {
+	expected := NamespaceNode{
+		Namespace: EmptyNamespace(),
+		Shards: []ShardLink{
+			ShardLink{Slot: 3, Path: "Addr 3"},
+			ShardLink{Slot: 1, Path: "Addr 1"},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	_, err := EncodeNamespaceNode(expected, buff)
+	testutil.AssertNil(t, err)
+
+	actual, _, err := DecodeNamespaceNode(buff)
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Expected sharded node", !actual.IsLeaf())
+	testutil.AssertEquals(t, "Unexpected shards", []ShardLink{
+		ShardLink{Slot: 1, Path: "Addr 1"},
+		ShardLink{Slot: 3, Path: "Addr 3"},
+	}, actual.Shards)
+
+	// A sharded node reads as an empty flat namespace.
+	buff.Reset()
+	_, err = EncodeNamespaceNode(expected, buff)
+	testutil.AssertNil(t, err)
+
+	namespace, _, err := DecodeNamespace(buff)
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Expected empty namespace", namespace.IsEmpty())
+}
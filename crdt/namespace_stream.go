@@ -10,10 +10,12 @@ import (
 type StreamPoint struct {
 	Text      PointText
 	Signature crypto.SignatureText
+	Timestamp Timestamp
+	Tag       Timestamp
 }
 
 func (point StreamPoint) Equals(other StreamPoint) bool {
-	return point.Text == other.Text && point.Signature == other.Signature
+	return point == other
 }
 
 func (point StreamPoint) Less(other StreamPoint) bool {
@@ -23,24 +25,61 @@ func (point StreamPoint) Less(other StreamPoint) bool {
 		return false
 	}
 
-	return point.Signature < other.Signature
+	if point.Signature < other.Signature {
+		return true
+	} else if point.Signature > other.Signature {
+		return false
+	}
+
+	if point.Timestamp != other.Timestamp {
+		return point.Timestamp.Less(other.Timestamp)
+	}
+
+	return point.Tag.Less(other.Tag)
 }
 
 type InvalidNamespaceEntry NamespaceStreamEntry
 
 // FIXME not really a stream, whole is kept in memory.
 type NamespaceStreamEntry struct {
-	Table TableName
-	Row   RowName
-	Entry EntryName
-	Point StreamPoint
+	Table     TableName
+	Row       RowName
+	Entry     EntryName
+	Point     StreamPoint
+	Tombstone bool
+	// Counter is set instead of Point for counter entries.
+	Counter CounterShard
+}
+
+func (entry NamespaceStreamEntry) IsCounter() bool {
+	return !entry.Counter.IsEmpty()
+}
+
+// kind orders points before tombstones before counter shards before counter
+// tombstones.
+func (entry NamespaceStreamEntry) kind() int {
+	if entry.IsCounter() && entry.Tombstone {
+		return 3
+	}
+
+	if entry.IsCounter() {
+		return 2
+	}
+
+	if entry.Tombstone {
+		return 1
+	}
+
+	return 0
 }
 
 func (entry NamespaceStreamEntry) samePoint(other NamespaceStreamEntry) bool {
 	ok := entry.Table == other.Table
 	ok = ok && entry.Row == other.Row
 	ok = ok && entry.Entry == other.Entry
+	ok = ok && entry.Tombstone == other.Tombstone
 	ok = ok && entry.Point.Text == other.Point.Text
+	ok = ok && entry.Counter.Replica == other.Counter.Replica
 	return ok
 }
 
@@ -59,7 +98,10 @@ func (stream byNamespaceStreamOrder) Swap(i, j int) {
 }
 
 func (stream byNamespaceStreamOrder) Less(i, j int) bool {
-	a, b := stream[i], stream[j]
+	return namespaceStreamLess(stream[i], stream[j])
+}
+
+func namespaceStreamLess(a, b NamespaceStreamEntry) bool {
 
 	if a.Table < b.Table {
 		return true
@@ -79,6 +121,14 @@ func (stream byNamespaceStreamOrder) Less(i, j int) bool {
 		return false
 	}
 
+	if a.kind() != b.kind() {
+		return a.kind() < b.kind()
+	}
+
+	if a.IsCounter() {
+		return counterShardLess(a.Counter, b.Counter)
+	}
+
 	return a.Point.Less(b.Point)
 }
 
@@ -87,6 +137,36 @@ type streamBuilder struct {
 	invalid []InvalidNamespaceEntry
 }
 
+func (builder *streamBuilder) addEntry(t TableName, r RowName, e EntryName, entry Entry) {
+	proto := NamespaceStreamEntry{
+		Table: t,
+		Row:   r,
+		Entry: e,
+	}
+
+	for _, point := range entry.Set {
+		builder.makeStreamPoints(proto, point)
+	}
+
+	tombstone := proto
+	tombstone.Tombstone = true
+	for _, point := range entry.Tombstones {
+		builder.makeStreamPoints(tombstone, point)
+	}
+
+	for _, shard := range entry.Counter.Shards {
+		counter := proto
+		counter.Counter = shard
+		builder.stream = append(builder.stream, counter)
+	}
+
+	for _, shard := range entry.CounterTombstones.Shards {
+		counter := tombstone
+		counter.Counter = shard
+		builder.stream = append(builder.stream, counter)
+	}
+}
+
 func (builder *streamBuilder) uniqueOrder() {
 	sort.Sort(byNamespaceStreamOrder(builder.stream))
 	builder.uniqSorted()
@@ -114,7 +194,7 @@ func (builder *streamBuilder) uniqSorted() {
 func (builder *streamBuilder) makeStreamPoints(proto NamespaceStreamEntry, point Point) {
 	if len(point.Signatures()) == 0 {
 		entry := proto
-		entry.Point = StreamPoint{Text: point.Text()}
+		entry.Point = StreamPoint{Text: point.Text(), Timestamp: point.Timestamp(), Tag: point.Tag()}
 		builder.stream = append(builder.stream, entry)
 	}
 
@@ -128,11 +208,25 @@ func (builder *streamBuilder) makeStreamPoints(proto NamespaceStreamEntry, point
 			continue
 		}
 
+		streamPoint.Timestamp = point.Timestamp()
+		streamPoint.Tag = point.Tag()
 		entry.Point = streamPoint
 		builder.stream = append(builder.stream, entry)
 	}
 }
 
+func counterShardLess(a, b CounterShard) bool {
+	if a.Replica != b.Replica {
+		return a.Replica < b.Replica
+	}
+
+	if a.Increments != b.Increments {
+		return a.Increments < b.Increments
+	}
+
+	return a.Decrements < b.Decrements
+}
+
 func MakeStreamPoint(text PointText, sig crypto.Signature) (StreamPoint, error) {
 	sigText, err := crypto.PrintSignature(sig)
 
@@ -152,6 +246,8 @@ func readStreamPoint(stream []NamespaceStreamEntry) (Point, []InvalidNamespaceEn
 
 	first := stream[0]
 	signatures := make([]crypto.Signature, 0, len(stream))
+	timestamp := Timestamp{}
+	tag := Timestamp{}
 
 	var invalid []InvalidNamespaceEntry
 
@@ -161,6 +257,9 @@ func readStreamPoint(stream []NamespaceStreamEntry) (Point, []InvalidNamespaceEn
 			return Point{}, nil, errors.Wrap(notSame, failMsg)
 		}
 
+		timestamp = latestTimestamp(timestamp, entry.Point.Timestamp)
+		tag = latestTimestamp(tag, entry.Point.Tag)
+
 		if crypto.IsNilSignature(entry.Point.Signature) {
 			continue
 		}
@@ -175,7 +274,7 @@ func readStreamPoint(stream []NamespaceStreamEntry) (Point, []InvalidNamespaceEn
 		signatures = append(signatures, sig)
 	}
 
-	point := PresignedPoint(first.Point.Text, signatures)
+	point := PresignedPoint(first.Point.Text, signatures).Stamp(timestamp).Tagged(tag)
 
 	return point, invalid, nil
 }
@@ -199,40 +298,104 @@ func MakeNamespaceStream(ns Namespace) ([]NamespaceStreamEntry, []InvalidNamespa
 
 	builder := &streamBuilder{stream: make([]NamespaceStreamEntry, 0, count)}
 
-	ns.ForeachEntry(func(t TableName, r RowName, e EntryName, entry Entry) {
-		proto := NamespaceStreamEntry{
-			Table: t,
-			Row:   r,
-			Entry: e,
-		}
-
-		for _, point := range entry.GetValues() {
-			builder.makeStreamPoints(proto, point)
-		}
-	})
+	ns.ForeachEntry(builder.addEntry)
 
 	builder.uniqueOrder()
 
 	return builder.stream, builder.invalid
 }
 
+// foreachEntryStream passes the stream for each entry of the namespace to f,
+// in stream order, so the stream for the whole namespace is never built.
+// The slice passed to f is reused for the next entry.
+func foreachEntryStream(ns Namespace, f func([]NamespaceStreamEntry) error) ([]InvalidNamespaceEntry, error) {
+	builder := &streamBuilder{}
+
+	for _, t := range ns.GetTableNames() {
+		table := ns.Tables[t]
+
+		for _, r := range sortedRowNames(table) {
+			row := table.Rows[r]
+
+			for _, e := range sortedEntryNames(row) {
+				builder.stream = builder.stream[:0]
+				builder.addEntry(t, r, e, row.Entries[e])
+				builder.uniqueOrder()
+
+				err := f(builder.stream)
+
+				if err != nil {
+					return builder.invalid, err
+				}
+			}
+		}
+	}
+
+	return builder.invalid, nil
+}
+
+func sortedRowNames(table Table) []RowName {
+	names := make([]string, 0, len(table.Rows))
+
+	for r := range table.Rows {
+		names = append(names, string(r))
+	}
+
+	sort.Strings(names)
+
+	rows := make([]RowName, len(names))
+	for i, name := range names {
+		rows[i] = RowName(name)
+	}
+
+	return rows
+}
+
+func sortedEntryNames(row Row) []EntryName {
+	names := make([]string, 0, len(row.Entries))
+
+	for e := range row.Entries {
+		names = append(names, string(e))
+	}
+
+	sort.Strings(names)
+
+	entries := make([]EntryName, len(names))
+	for i, name := range names {
+		entries[i] = EntryName(name)
+	}
+
+	return entries
+}
+
 func streamLength(ns Namespace) int {
 	count := 0
 
 	ns.ForeachEntry(func(t TableName, r RowName, e EntryName, entry Entry) {
-		for _, point := range entry.GetValues() {
-			sigCount := len(point.Signatures())
-			if sigCount > 0 {
-				count += sigCount
-			} else {
-				count++
-			}
-		}
+		count += pointStreamLength(entry.Set)
+		count += pointStreamLength(entry.Tombstones)
+		count += len(entry.Counter.Shards)
+		count += len(entry.CounterTombstones.Shards)
 	})
 
 	return count
 }
 
+func pointStreamLength(points []Point) int {
+	count := 0
+
+	for _, point := range points {
+		sigCount := len(point.Signatures())
+		if sigCount > 0 {
+			count += sigCount
+		} else {
+			count++
+		}
+	}
+
+	return count
+}
+
 func ReadNamespaceStream(stream []NamespaceStreamEntry) (Namespace, []InvalidNamespaceEntry) {
 	const failMsg = "ReadNamespaceStream failed"
 
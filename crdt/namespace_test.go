@@ -139,7 +139,7 @@ func (signer namespaceSigner) publicKeys() []crypto.PublicKey {
 }
 
 func (signer namespaceSigner) signEntry(t TableName, r RowName, e EntryName, entry Entry) (Entry, []InvalidNamespaceEntry) {
-	unsigned := entry.GetValues()
+	unsigned := entry.Set
 	signed := make([]Point, len(unsigned))
 	invalidEntries := make([]InvalidNamespaceEntry, 0, len(unsigned))
 
@@ -869,6 +869,182 @@ func TestEntryGetValues(t *testing.T) {
 	}
 }
 
+func TestEntryTombstones(t *testing.T) {
+	entry := MakeEntry([]Point{UnsignedPoint("hello"), UnsignedPoint("world")})
+	tombstone := MakeTombstoneEntry([]Point{UnsignedPoint("hello")})
+
+	removed := entry.JoinEntry(tombstone)
+
+	expected := []Point{UnsignedPoint("world")}
+	actual := removed.GetValues()
+
+	testutil.AssertEquals(t, "Unexpected values", expected, actual)
+	testutil.Assert(t, "Expected commutative join", removed.Equals(tombstone.JoinEntry(entry)))
+	testutil.Assert(t, "Unexpected removal", !removed.IsRemoved())
+
+	allRemoved := removed.JoinEntry(MakeTombstoneEntry([]Point{UnsignedPoint("world")}))
+	testutil.Assert(t, "Expected removal", allRemoved.IsRemoved())
+}
+
+func TestEntryTombstoneReAdd(t *testing.T) {
+	added := UnsignedPoint("hello").Tagged(Timestamp{Wall: 1, Node: "a"})
+	readded := UnsignedPoint("hello").Tagged(Timestamp{Wall: 3, Node: "b"})
+	tombstone := MakeTombstoneEntry([]Point{UnsignedPoint("hello").Tagged(added.Tag())})
+
+	removed := MakeEntry([]Point{added}).JoinEntry(tombstone)
+	testutil.Assert(t, "Expected removal", removed.IsRemoved())
+
+	joined := removed.JoinEntry(MakeEntry([]Point{readded}))
+	testutil.AssertEquals(t, "Unexpected values after re-add", []Point{readded}, joined.GetValues())
+
+	reordered := MakeEntry([]Point{readded}).JoinEntry(tombstone).JoinEntry(MakeEntry([]Point{added}))
+	testutil.Assert(t, "Expected commutative join", joined.Equals(reordered))
+
+	untagged := MakeEntry([]Point{UnsignedPoint("hello")}).JoinEntry(tombstone)
+	testutil.Assert(t, "Expected untagged point removal", untagged.IsRemoved())
+
+	namespace := EmptyNamespace().JoinTable("Table", MakeTable(map[RowName]Row{
+		"Row": MakeRow(map[EntryName]Entry{"Entry": joined}),
+	}))
+
+	serialized := namespaceSerializationPass(namespace)
+	testutil.Assert(t, "Tags lost in serialization", namespace.Equals(serialized))
+	testutil.AssertEquals(t, "Unexpected latest timestamp", readded.Tag(), namespace.LatestTimestamp())
+}
+
+func TestNamespaceApplyTombstones(t *testing.T) {
+	namespace := MakeNamespace(map[TableName]Table{
+		"Table A": MakeTable(map[RowName]Row{
+			"Row A": MakeRow(map[EntryName]Entry{
+				"Entry A": MakeEntry([]Point{UnsignedPoint("hello"), UnsignedPoint("world")}),
+				"Entry B": MakeEntry([]Point{UnsignedPoint("gone")}),
+			}),
+			"Row B": MakeRow(map[EntryName]Entry{
+				"Entry C": MakeEntry([]Point{UnsignedPoint("gone")}),
+			}),
+		}),
+	})
+
+	tombstones := MakeNamespace(map[TableName]Table{
+		"Table A": MakeTable(map[RowName]Row{
+			"Row A": MakeRow(map[EntryName]Entry{
+				"Entry A": MakeTombstoneEntry([]Point{UnsignedPoint("hello")}),
+				"Entry B": MakeTombstoneEntry([]Point{UnsignedPoint("gone")}),
+			}),
+			"Row B": MakeRow(map[EntryName]Entry{
+				"Entry C": MakeTombstoneEntry([]Point{UnsignedPoint("gone")}),
+			}),
+		}),
+	})
+
+	expected := MakeNamespace(map[TableName]Table{
+		"Table A": MakeTable(map[RowName]Row{
+			"Row A": MakeRow(map[EntryName]Entry{
+				"Entry A": MakeEntry([]Point{UnsignedPoint("world")}),
+			}),
+		}),
+	})
+
+	joined := namespace.JoinNamespace(tombstones)
+
+	testutil.Assert(t, "Expected commutative join", joined.Equals(tombstones.JoinNamespace(namespace)))
+
+	actual := joined.ApplyTombstones()
+	assertNamespaceEquals(t, expected, actual)
+
+	serialized := namespaceSerializationPass(joined)
+	testutil.Assert(t, "Tombstones lost in serialization", joined.Equals(serialized))
+}
+
+func TestEntryLastWriterWins(t *testing.T) {
+	early := UnsignedPoint("early").Stamp(Timestamp{Wall: 1, Node: "b"})
+	late := UnsignedPoint("late").Stamp(Timestamp{Wall: 2, Node: "a"})
+	unstamped := UnsignedPoint("unstamped")
+
+	entry := MakeEntry([]Point{late, unstamped}).JoinEntry(MakeEntry([]Point{early}))
+
+	testutil.AssertEquals(t, "Unexpected winner", []Point{late}, entry.GetValues())
+	testutil.AssertEquals(t, "Unexpected all values", []Point{early, late, unstamped}, entry.GetAllValues())
+
+	removed := entry.JoinEntry(MakeTombstoneEntry([]Point{UnsignedPoint("late")}))
+	testutil.AssertEquals(t, "Unexpected winner after removal", []Point{early}, removed.GetValues())
+
+	rewritten := entry.JoinEntry(MakeEntry([]Point{UnsignedPoint("early").Stamp(Timestamp{Wall: 3})}))
+	testutil.AssertEquals(t, "Unexpected winner after rewrite", PointText("early"), rewritten.GetValues()[0].Text())
+
+	namespace := EmptyNamespace().JoinTable("Table", MakeTable(map[RowName]Row{
+		"Row": MakeRow(map[EntryName]Entry{"Entry": entry}),
+	}))
+
+	serialized := namespaceSerializationPass(namespace)
+	testutil.Assert(t, "Timestamps lost in serialization", namespace.Equals(serialized))
+	testutil.AssertEquals(t, "Unexpected latest timestamp", late.Timestamp(), namespace.LatestTimestamp())
+}
+
+func TestEntryCounter(t *testing.T) {
+	mine := MakeCounterEntry([]CounterShard{
+		MakeCounterShard("a", 3),
+		MakeCounterShard("b", -1),
+	})
+
+	theirs := MakeCounterEntry([]CounterShard{
+		MakeCounterShard("a", 5),
+		MakeCounterShard("c", 2),
+	})
+
+	joined := mine.JoinEntry(theirs)
+
+	testutil.Assert(t, "Expected commutative join", joined.Equals(theirs.JoinEntry(mine)))
+	testutil.Assert(t, "Expected idempotent join", joined.Equals(joined.JoinEntry(theirs)))
+	total, err := joined.Counter.Value()
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected total", int64(6), total)
+	testutil.AssertEquals(t, "Unexpected values", []Point{UnsignedPoint("6")}, joined.GetValues())
+
+	namespace := EmptyNamespace().JoinTable("Table", MakeTable(map[RowName]Row{
+		"Row": MakeRow(map[EntryName]Entry{"Entry": joined}),
+	}))
+
+	serialized := namespaceSerializationPass(namespace)
+	testutil.Assert(t, "Counter lost in serialization", namespace.Equals(serialized))
+}
+
+func TestEntryCounterTombstone(t *testing.T) {
+	counter := MakeCounterEntry([]CounterShard{
+		MakeCounterShard("a", 3),
+		MakeCounterShard("b", -1),
+	})
+
+	tombstone := MakeCounterTombstoneEntry(counter.Counter.Shards)
+	removed := counter.JoinEntry(tombstone)
+
+	testutil.Assert(t, "Expected removal", removed.IsRemoved())
+	testutil.Assert(t, "Expected commutative join", removed.Equals(tombstone.JoinEntry(counter)))
+
+	incremented := removed.JoinEntry(MakeCounterEntry([]CounterShard{MakeCounterShard("a", 5)}))
+	testutil.AssertEquals(t, "Unexpected values after increment", []Point{UnsignedPoint("2")}, incremented.GetValues())
+
+	namespace := EmptyNamespace().JoinTable("Table", MakeTable(map[RowName]Row{
+		"Row": MakeRow(map[EntryName]Entry{"Entry": incremented}),
+	}))
+
+	serialized := namespaceSerializationPass(namespace)
+	testutil.Assert(t, "Counter tombstones lost in serialization", namespace.Equals(serialized))
+	testutil.Assert(t, "Expected empty namespace", EmptyNamespace().JoinTable("Table", MakeTable(map[RowName]Row{
+		"Row": MakeRow(map[EntryName]Entry{"Entry": removed}),
+	})).ApplyTombstones().IsEmpty())
+}
+
+func TestEntryFilterVerifiedCounter(t *testing.T) {
+	_, pub, err := crypto.GenerateKey()
+	testutil.AssertNil(t, err)
+
+	entry := MakeCounterEntry([]CounterShard{MakeCounterShard("a", 3)})
+	entry = entry.JoinEntry(MakeCounterTombstoneEntry([]CounterShard{MakeCounterShard("a", 1)}))
+
+	testutil.Assert(t, "Expected counter kept", entry.Equals(entry.FilterVerified([]crypto.PublicKey{pub})))
+}
+
 func assertEntryEquals(t *testing.T, expected, actual Entry) {
 	if !reflect.DeepEqual(expected, actual) {
 		testutil.DebugLine(t)
@@ -9,6 +9,8 @@ type PointText string
 
 type Point struct {
 	signedText
+	timestamp Timestamp
+	tag       Timestamp
 }
 
 func (p Point) Text() PointText {
@@ -19,12 +21,41 @@ func (p Point) Signatures() []crypto.Signature {
 	return p.signatures
 }
 
+// Timestamp is zero unless the Point was written to a last-writer-wins entry.
+func (p Point) Timestamp() Timestamp {
+	return p.timestamp
+}
+
+func (p Point) IsStamped() bool {
+	return !p.timestamp.IsZero()
+}
+
+// Stamp returns a copy of the Point with the Timestamp set.
+func (p Point) Stamp(timestamp Timestamp) Point {
+	p.timestamp = timestamp
+	return p
+}
+
+// Tag is the Timestamp of the add that wrote the Point, or of the add a
+// tombstone removes.  Untagged points are from before tagging, and are removed
+// by any tombstone with the same text.
+func (p Point) Tag() Timestamp {
+	return p.tag
+}
+
+// Tagged returns a copy of the Point with the Tag set.
+func (p Point) Tagged(tag Timestamp) Point {
+	p.tag = tag
+	return p
+}
+
 func (p Point) HasText(text string) bool {
 	return p.Text() == PointText(text)
 }
 
 func (p Point) Equals(other Point) bool {
-	return p.signedText.Equals(other.signedText)
+	ok := p.timestamp == other.timestamp && p.tag == other.tag
+	return ok && p.signedText.Equals(other.signedText)
 }
 
 func PresignedPoint(text PointText, sigs []crypto.Signature) Point {
@@ -64,6 +95,15 @@ func (p byPointValue) Less(i, j int) bool {
 	return p[i].Text() < p[j].Text()
 }
 
+// newerThan orders Points by Timestamp, breaking ties on text.
+func (p Point) newerThan(other Point) bool {
+	if p.timestamp != other.timestamp {
+		return other.timestamp.Less(p.timestamp)
+	}
+
+	return other.Text() < p.Text()
+}
+
 func uniqPointSorted(set []Point) []Point {
 	if len(set) < 2 {
 		return set
@@ -75,6 +115,8 @@ func uniqPointSorted(set []Point) []Point {
 		last := &set[uniqIndex]
 		if p.Text() == last.Text() {
 			last.signedText.signatures = append(last.signedText.signatures, p.Signatures()...)
+			last.timestamp = latestTimestamp(last.timestamp, p.timestamp)
+			last.tag = latestTimestamp(last.tag, p.tag)
 		} else {
 			uniqIndex++
 			set[uniqIndex] = p
@@ -0,0 +1,287 @@
+package crdt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	pb "github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/internal/util"
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+// The stream encoding is STREAM_MAGIC, a length-delimited header message, and
+// then a length-delimited message for each stream entry.  Entries are written
+// and read one at a time, so the whole encoding is never held in memory.
+//
+// The first byte of STREAM_MAGIC is not a valid protobuf field tag, so stream
+// encoded data cannot be mistaken for the single message encoding, which is
+// still decoded.
+const STREAM_MAGIC = "GDLS"
+
+// IsStreamEncoded is true when the reader begins with STREAM_MAGIC.  Nothing
+// is read from the reader.
+func IsStreamEncoded(r *bufio.Reader) bool {
+	magic, err := r.Peek(len(STREAM_MAGIC))
+	return err == nil && string(magic) == STREAM_MAGIC
+}
+
+// EncodeNamespaceNodeStream writes the node in the stream encoding.  The
+// header holds the shard links, and the entries the points of a leaf.  The
+// points are written as each namespace entry is read, so only the stream for
+// one entry is held in memory.
+func EncodeNamespaceNodeStream(node NamespaceNode, w io.Writer) ([]InvalidNamespaceEntry, error) {
+	const failMsg = "EncodeNamespaceNodeStream failed"
+
+	header := &proto.NamespaceMessage{}
+
+	if !node.IsLeaf() {
+		header, _ = MakeNamespaceNodeMessage(node)
+	}
+
+	encoder := makeStreamEncoder(w)
+	encoder.encode(header)
+
+	var invalid []InvalidNamespaceEntry
+
+	if node.IsLeaf() {
+		invalid, _ = foreachEntryStream(node.Namespace, func(stream []NamespaceStreamEntry) error {
+			for _, entry := range stream {
+				encoder.encode(MakeNamespaceEntryMessage(entry))
+			}
+
+			return encoder.err
+		})
+	}
+
+	invalidCount := len(invalid)
+	if invalidCount > 0 {
+		log.Error("EncodeNamespaceNodeStream: %d invalid points", invalidCount)
+	}
+
+	err := encoder.flush()
+
+	if err != nil {
+		return invalid, errors.Wrap(err, failMsg)
+	}
+
+	return invalid, nil
+}
+
+// EncodeIndexStream writes the index in the stream encoding.  The header
+// holds the history, and the entries the links.
+func EncodeIndexStream(index Index, w io.Writer) ([]InvalidIndexEntry, error) {
+	const failMsg = "EncodeIndexStream failed"
+
+	stream, invalid := MakeIndexStream(index)
+
+	invalidCount := len(invalid)
+	if invalidCount > 0 {
+		log.Error("EncodeIndexStream: %d invalid entries", invalidCount)
+	}
+
+	header := &proto.IndexMessage{}
+	writeIndexHistory(index, header)
+
+	encoder := makeStreamEncoder(w)
+	encoder.encode(header)
+
+	for _, entry := range stream {
+		encoder.encode(MakeIndexEntryMessage(entry))
+	}
+
+	err := encoder.flush()
+
+	if err != nil {
+		return invalid, errors.Wrap(err, failMsg)
+	}
+
+	return invalid, nil
+}
+
+// DecodeMessageStream reads stream encoded data.  The header is decoded and
+// passed to f, and then each entry in turn.  The entry message is reset and
+// reused for each entry.
+func DecodeMessageStream(r *bufio.Reader, header, entry pb.Message, f func(pb.Message) error) error {
+	const failMsg = "DecodeMessageStream failed"
+
+	if !IsStreamEncoded(r) {
+		return fmt.Errorf("%s: missing stream magic", failMsg)
+	}
+
+	_, err := r.Discard(len(STREAM_MAGIC))
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	err = util.DecodeDelimited(header, r)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	err = f(header)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	for {
+		entry.Reset()
+		err = util.DecodeDelimited(entry, r)
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Wrap(err, failMsg)
+		}
+
+		err = f(entry)
+
+		if err != nil {
+			return errors.Wrap(err, failMsg)
+		}
+	}
+}
+
+// DecodeNamespaceStream reads a stream encoded namespace node, passing each
+// entry to f as it is read, so the namespace is never held in memory.  The
+// shard links of a sharded node are returned.
+func DecodeNamespaceStream(r *bufio.Reader, f func(NamespaceStreamEntry) error) ([]ShardLink, error) {
+	const failMsg = "DecodeNamespaceStream failed"
+
+	header := &proto.NamespaceMessage{}
+
+	err := DecodeMessageStream(r, header, &proto.NamespaceEntryMessage{}, func(message pb.Message) error {
+		if entry, ok := message.(*proto.NamespaceEntryMessage); ok {
+			return f(ReadNamespaceEntryMessage(entry))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	node, _ := ReadNamespaceNodeMessage(header)
+	return node.Shards, nil
+}
+
+func decodeNamespaceNodeStream(r *bufio.Reader) (NamespaceNode, []InvalidNamespaceEntry, error) {
+	const failMsg = "decodeNamespaceNodeStream failed"
+
+	reader := &namespaceStreamReader{namespace: EmptyNamespace()}
+
+	shards, err := DecodeNamespaceStream(r, reader.add)
+
+	if err == nil {
+		err = reader.flush()
+	}
+
+	if err != nil {
+		return LeafNamespaceNode(EmptyNamespace()), reader.invalid, errors.Wrap(err, failMsg)
+	}
+
+	if len(shards) == 0 {
+		return LeafNamespaceNode(reader.namespace), reader.invalid, nil
+	}
+
+	if !reader.namespace.IsEmpty() {
+		log.Warn("Ignoring entries in sharded namespace stream")
+	}
+
+	node := NamespaceNode{Namespace: EmptyNamespace(), Shards: shards}
+	return node, reader.invalid, nil
+}
+
+func decodeIndexStream(r *bufio.Reader) (Index, []InvalidIndexEntry, error) {
+	const failMsg = "decodeIndexStream failed"
+
+	header := &proto.IndexMessage{}
+	index := EmptyIndex()
+	var invalid []InvalidIndexEntry
+
+	err := DecodeMessageStream(r, header, &proto.IndexEntryMessage{}, func(message pb.Message) error {
+		if emsg, ok := message.(*proto.IndexEntryMessage); ok {
+			entry := ReadIndexEntryMessage(emsg)
+
+			if index.addStreamEntry(entry) != nil {
+				invalid = append(invalid, InvalidIndexEntry(entry))
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return __EMPTY_INDEX, invalid, errors.Wrap(err, failMsg)
+	}
+
+	return readIndexHistory(index, header), invalid, nil
+}
+
+// namespaceStreamReader adds entries to the namespace as they are read.  The
+// entries for a point are batched, so its signatures are joined once.
+type namespaceStreamReader struct {
+	namespace Namespace
+	batch     []NamespaceStreamEntry
+	invalid   []InvalidNamespaceEntry
+}
+
+func (reader *namespaceStreamReader) add(entry NamespaceStreamEntry) error {
+	if len(reader.batch) > 0 && !entry.samePoint(reader.batch[0]) {
+		err := reader.flush()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	reader.batch = append(reader.batch, entry)
+	return nil
+}
+
+func (reader *namespaceStreamReader) flush() error {
+	if len(reader.batch) == 0 {
+		return nil
+	}
+
+	invalid, err := reader.namespace.addPointBatch(reader.batch)
+	reader.invalid = append(reader.invalid, invalid...)
+	reader.batch = reader.batch[:0]
+
+	return err
+}
+
+// streamEncoder writes the stream encoding, keeping the first error.
+type streamEncoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func makeStreamEncoder(w io.Writer) *streamEncoder {
+	encoder := &streamEncoder{w: bufio.NewWriter(w)}
+	encoder.err = util.WriteBytes([]byte(STREAM_MAGIC), encoder.w)
+	return encoder
+}
+
+func (encoder *streamEncoder) encode(message pb.Message) {
+	if encoder.err == nil {
+		encoder.err = util.EncodeDelimited(message, encoder.w)
+	}
+}
+
+func (encoder *streamEncoder) flush() error {
+	if encoder.err != nil {
+		return encoder.err
+	}
+
+	return encoder.w.Flush()
+}
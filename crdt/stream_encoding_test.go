@@ -0,0 +1,176 @@
+package crdt
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"testing/quick"
+
+	pb "github.com/gogo/protobuf/proto"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+	"github.com/johnny-morrice/godless/proto"
+)
+
+func TestEncodeNamespaceStream(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+		return
+	}
+
+	config := &quick.Config{
+		MaxCount: testutil.ENCODE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(namespaceStreamEncodeOk, config)
+
+	testutil.AssertVerboseErrorIsNil(t, err)
+}
+
+func namespaceStreamEncodeOk(expected Namespace) bool {
+	buff := &bytes.Buffer{}
+	_, err := EncodeNamespaceNodeStream(LeafNamespaceNode(expected), buff)
+
+	if err != nil {
+		return false
+	}
+
+	if !IsStreamEncoded(bufio.NewReader(bytes.NewReader(buff.Bytes()))) {
+		return false
+	}
+
+	actual, _, err := DecodeNamespace(buff)
+
+	return err == nil && expected.Equals(actual)
+}
+
+func TestDecodeNamespaceStream(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+		return
+	}
+
+	config := &quick.Config{
+		MaxCount: testutil.ENCODE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(namespaceStreamDecodeOk, config)
+
+	testutil.AssertVerboseErrorIsNil(t, err)
+}
+
+// namespaceStreamDecodeOk checks that the entries are written in stream order.
+func namespaceStreamDecodeOk(ns Namespace) bool {
+	buff := &bytes.Buffer{}
+	_, err := EncodeNamespaceNodeStream(LeafNamespaceNode(ns), buff)
+
+	if err != nil {
+		return false
+	}
+
+	expected, _ := MakeNamespaceStream(ns)
+	actual := []NamespaceStreamEntry{}
+
+	shards, err := DecodeNamespaceStream(bufio.NewReader(buff), func(entry NamespaceStreamEntry) error {
+		actual = append(actual, entry)
+		return nil
+	})
+
+	if err != nil || len(shards) > 0 || len(expected) != len(actual) {
+		return false
+	}
+
+	for i, entry := range expected {
+		if entry != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestEncodeIndexStream(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+		return
+	}
+
+	config := &quick.Config{
+		MaxCount: testutil.ENCODE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(indexStreamEncodeOk, config)
+
+	testutil.AssertVerboseErrorIsNil(t, err)
+}
+
+func indexStreamEncodeOk(expected Index) bool {
+	buff := &bytes.Buffer{}
+	_, err := EncodeIndexStream(expected, buff)
+
+	if err != nil {
+		return false
+	}
+
+	actual, _, err := DecodeIndex(buff)
+
+	return err == nil && expected.Equals(actual) && expected.SameHistory(actual)
+}
+
+func TestEncodeNamespaceNodeStream(t *testing.T) {
+	expected := NamespaceNode{
+		Namespace: EmptyNamespace(),
+		Shards: []ShardLink{
+			ShardLink{Slot: 1, Path: "Addr 1"},
+			ShardLink{Slot: 3, Path: "Addr 3"},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	_, err := EncodeNamespaceNodeStream(expected, buff)
+	testutil.AssertNil(t, err)
+
+	actual, _, err := DecodeNamespaceNode(bytes.NewReader(buff.Bytes()))
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected shards", expected.Shards, actual.Shards)
+
+	count := 0
+	shards, err := DecodeNamespaceStream(bufio.NewReader(buff), func(entry NamespaceStreamEntry) error {
+		count++
+		return nil
+	})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected shards", expected.Shards, shards)
+	testutil.AssertEquals(t, "Unexpected entry count", 0, count)
+}
+
+func TestDecodeMessageStream(t *testing.T) {
+	index := MakeIndex(map[TableName]Link{
+		"Table A": UnsignedLink("Addr A"),
+		"Table B": UnsignedLink("Addr B"),
+	})
+	index.Created = 1
+
+	buff := &bytes.Buffer{}
+	_, err := EncodeIndexStream(index, buff)
+	testutil.AssertNil(t, err)
+
+	header := &proto.IndexMessage{}
+	links := []string{}
+
+	err = DecodeMessageStream(bufio.NewReader(buff), header, &proto.IndexEntryMessage{}, func(message pb.Message) error {
+		if entry, ok := message.(*proto.IndexEntryMessage); ok {
+			links = append(links, entry.Link)
+		}
+
+		return nil
+	})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected created time", int64(1), header.Created)
+	testutil.AssertEquals(t, "Unexpected links", []string{"Addr A", "Addr B"}, links)
+
+	err = DecodeMessageStream(bufio.NewReader(&bytes.Buffer{}), header, &proto.IndexEntryMessage{}, nil)
+	testutil.AssertNonNil(t, err)
+}
@@ -0,0 +1,244 @@
+package function
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/log"
+)
+
+type ComparisonKind uint8
+
+const (
+	COMPARE_EQ = ComparisonKind(iota)
+	COMPARE_LT
+	COMPARE_GT
+	COMPARE_BETWEEN
+)
+
+// Comparison compares its first argument with the arguments that follow.
+// Every value must parse, or there is no match.  When an argument has several
+// values, each of them must satisfy the comparison.  Between is inclusive of
+// both of its bounds.
+type Comparison struct {
+	Name  string
+	Kind  ComparisonKind
+	Parse ValueParser
+}
+
+// ValueParser reads the text of a Point as an ordered value.
+type ValueParser func(text string) (OrderedValue, error)
+
+type OrderedValue interface {
+	// Compare returns a negative number, zero, or a positive number when the
+	// value is less than, equal to, or greater than the other.
+	Compare(other OrderedValue) int
+}
+
+// NumericComparisons are num_eq, num_lt, num_gt and num_between.  Point text
+// is parsed as a 64 bit float after trimming whitespace.  NaN does not parse.
+func NumericComparisons() []NamedMatchFunction {
+	return makeComparisons("num", ParseNumber)
+}
+
+// TimeComparisons are time_eq, time_lt, time_gt and time_between.  Point text
+// is parsed as an RFC3339 timestamp after trimming whitespace.  Timestamps in
+// different zones are compared by the instant they denote.
+func TimeComparisons() []NamedMatchFunction {
+	return makeComparisons("time", ParseTime)
+}
+
+func makeComparisons(prefix string, parser ValueParser) []NamedMatchFunction {
+	return []NamedMatchFunction{
+		Comparison{Name: prefix + "_eq", Kind: COMPARE_EQ, Parse: parser},
+		Comparison{Name: prefix + "_lt", Kind: COMPARE_LT, Parse: parser},
+		Comparison{Name: prefix + "_gt", Kind: COMPARE_GT, Parse: parser},
+		Comparison{Name: prefix + "_between", Kind: COMPARE_BETWEEN, Parse: parser},
+	}
+}
+
+func (comp Comparison) FuncName() string {
+	return comp.Name
+}
+
+func (comp Comparison) CheckArity(count int) error {
+	expected := comp.arity()
+
+	if count != expected {
+		return fmt.Errorf("%s requires %d arguments but found %d", comp.Name, expected, count)
+	}
+
+	return nil
+}
+
+// Match compares the entry values, in order, followed by the literals.  The
+// evaluator calls MatchArguments instead, so that the arguments keep the order
+// they were written in.
+func (comp Comparison) Match(literals []string, entries []crdt.Entry) bool {
+	arguments := make([][]string, 0, len(entries)+len(literals))
+
+	for _, entry := range entries {
+		arguments = append(arguments, entryValues(entry))
+	}
+
+	for _, lit := range literals {
+		arguments = append(arguments, []string{lit})
+	}
+
+	return comp.MatchArguments(arguments)
+}
+
+func (comp Comparison) MatchArguments(arguments [][]string) bool {
+	if comp.CheckArity(len(arguments)) != nil {
+		return false
+	}
+
+	parsed := make([][]OrderedValue, len(arguments))
+
+	for i, arg := range arguments {
+		if len(arg) == 0 {
+			return false
+		}
+
+		for _, text := range arg {
+			value, err := comp.Parse(text)
+
+			if err != nil {
+				log.Debug("%s could not parse '%s': %s", comp.Name, text, err.Error())
+				return false
+			}
+
+			parsed[i] = append(parsed[i], value)
+		}
+	}
+
+	subjects := parsed[0]
+	bounds := parsed[1:]
+
+	for _, subject := range subjects {
+		if !comp.compare(subject, bounds) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (comp Comparison) compare(subject OrderedValue, bounds [][]OrderedValue) bool {
+	switch comp.Kind {
+	case COMPARE_EQ:
+		return allBounds(bounds[0], func(bound OrderedValue) bool {
+			return subject.Compare(bound) == 0
+		})
+	case COMPARE_LT:
+		return allBounds(bounds[0], func(bound OrderedValue) bool {
+			return subject.Compare(bound) < 0
+		})
+	case COMPARE_GT:
+		return allBounds(bounds[0], func(bound OrderedValue) bool {
+			return subject.Compare(bound) > 0
+		})
+	case COMPARE_BETWEEN:
+		lower := allBounds(bounds[0], func(bound OrderedValue) bool {
+			return subject.Compare(bound) >= 0
+		})
+		upper := allBounds(bounds[1], func(bound OrderedValue) bool {
+			return subject.Compare(bound) <= 0
+		})
+		return lower && upper
+	default:
+		panic(fmt.Sprintf("Unknown ComparisonKind: %v", comp.Kind))
+	}
+}
+
+func (comp Comparison) arity() int {
+	if comp.Kind == COMPARE_BETWEEN {
+		return 3
+	}
+
+	return 2
+}
+
+func allBounds(bounds []OrderedValue, test func(bound OrderedValue) bool) bool {
+	for _, bound := range bounds {
+		if !test(bound) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func entryValues(entry crdt.Entry) []string {
+	points := entry.GetValues()
+	values := make([]string, len(points))
+
+	for i, p := range points {
+		values[i] = string(p.Text())
+	}
+
+	return values
+}
+
+type numberValue float64
+
+func ParseNumber(text string) (OrderedValue, error) {
+	const failMsg = "ParseNumber failed"
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	if math.IsNaN(number) {
+		return nil, errors.New("NaN is not a number")
+	}
+
+	return numberValue(number), nil
+}
+
+func (number numberValue) Compare(other OrderedValue) int {
+	otherNumber := other.(numberValue)
+
+	if number < otherNumber {
+		return -1
+	} else if number > otherNumber {
+		return 1
+	}
+
+	return 0
+}
+
+type timeValue time.Time
+
+func ParseTime(text string) (OrderedValue, error) {
+	const failMsg = "ParseTime failed"
+
+	stamp, err := time.Parse(time.RFC3339, strings.TrimSpace(text))
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	return timeValue(stamp), nil
+}
+
+func (stamp timeValue) Compare(other OrderedValue) int {
+	mine := time.Time(stamp)
+	theirs := time.Time(other.(timeValue))
+
+	if mine.Before(theirs) {
+		return -1
+	} else if mine.After(theirs) {
+		return 1
+	}
+
+	return 0
+}
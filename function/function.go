@@ -20,6 +20,20 @@ type NamedMatchFunction interface {
 	MatchFunction
 }
 
+// OrderedMatchFunction is a MatchFunction whose arguments are not
+// interchangeable.  The evaluator passes each argument in the order it was
+// written: the row key first, when included, followed by the predicate values.
+// A literal argument has one value and an entry argument has the entry values.
+type OrderedMatchFunction interface {
+	MatchFunction
+	MatchArguments(arguments [][]string) bool
+}
+
+// ArityFunction is a function that accepts only some numbers of arguments.
+type ArityFunction interface {
+	CheckArity(count int) error
+}
+
 type NamedMatchFunctionLambda struct {
 	Function MatchFunction
 	Name     string
@@ -1,6 +1,7 @@
 package function
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/johnny-morrice/godless/crdt"
@@ -48,6 +49,105 @@ func TestStrRegexp(t *testing.T) {
 	testMatchFunction(t, regexp, "barx.*", "barxxxxx")
 }
 
+func TestNumericComparisons(t *testing.T) {
+	functions := StandardFunctions()
+
+	entry := crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("9.5"), crdt.UnsignedPoint(" 12 ")})
+	junk := crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("twelve")})
+
+	matches := []comparisonTest{
+		comparisonTest{name: "num_eq", arguments: [][]string{{"1e1"}, {"10"}}},
+		comparisonTest{name: "num_lt", arguments: [][]string{{"9.5", "12"}, {"12.1"}}},
+		comparisonTest{name: "num_gt", arguments: [][]string{{"-1"}, {"-2"}}},
+		comparisonTest{name: "num_between", arguments: [][]string{{"10"}, {"10"}, {"11"}}},
+		comparisonTest{name: "num_lt", literals: []string{"13"}, entries: []crdt.Entry{entry}},
+	}
+
+	nonMatches := []comparisonTest{
+		comparisonTest{name: "num_eq", arguments: [][]string{{"1"}, {"2"}}},
+		comparisonTest{name: "num_lt", arguments: [][]string{{"9.5", "12"}, {"10"}}},
+		comparisonTest{name: "num_gt", arguments: [][]string{{"NaN"}, {"1"}}},
+		comparisonTest{name: "num_between", arguments: [][]string{{"12"}, {"10"}, {"11"}}},
+		comparisonTest{name: "num_eq", arguments: [][]string{{}, {"1"}}},
+		comparisonTest{name: "num_lt", arguments: [][]string{{"1"}}},
+		comparisonTest{name: "num_lt", literals: []string{"13"}, entries: []crdt.Entry{junk}},
+	}
+
+	testComparisons(t, functions, matches, nonMatches)
+}
+
+func TestTimeComparisons(t *testing.T) {
+	functions := StandardFunctions()
+
+	const morning = "2017-06-01T09:00:00Z"
+	const noon = "2017-06-01T12:00:00Z"
+	const noonParis = "2017-06-01T14:00:00+02:00"
+
+	matches := []comparisonTest{
+		comparisonTest{name: "time_eq", arguments: [][]string{{noon}, {noonParis}}},
+		comparisonTest{name: "time_lt", arguments: [][]string{{morning}, {noon}}},
+		comparisonTest{name: "time_gt", arguments: [][]string{{noon}, {morning}}},
+		comparisonTest{name: "time_between", arguments: [][]string{{"2017-06-01T10:30:00.5Z"}, {morning}, {noon}}},
+	}
+
+	nonMatches := []comparisonTest{
+		comparisonTest{name: "time_lt", arguments: [][]string{{noonParis}, {noon}}},
+		comparisonTest{name: "time_gt", arguments: [][]string{{"2017-06-01"}, {morning}}},
+		comparisonTest{name: "time_between", arguments: [][]string{{morning}, {noon}, {noonParis}}},
+	}
+
+	testComparisons(t, functions, matches, nonMatches)
+}
+
+type comparisonTest struct {
+	name      string
+	arguments [][]string
+	literals  []string
+	entries   []crdt.Entry
+}
+
+func (test comparisonTest) match(functions FunctionNamespace) bool {
+	function, err := functions.GetFunction(test.name)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if test.arguments != nil {
+		return function.(OrderedMatchFunction).MatchArguments(test.arguments)
+	}
+
+	return function.Match(test.literals, test.entries)
+}
+
+func testComparisons(t *testing.T, functions FunctionNamespace, matches, nonMatches []comparisonTest) {
+	for i, test := range matches {
+		testutil.Assert(t, fmt.Sprintf("Expected match at %d", i), test.match(functions))
+	}
+
+	for i, test := range nonMatches {
+		testutil.Assert(t, fmt.Sprintf("Unexpected match at %d", i), !test.match(functions))
+	}
+}
+
+func TestExists(t *testing.T) {
+	present := crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("foo")})
+	missing := crdt.EmptyEntry()
+
+	testutil.Assert(t, "Expected exists", Exists{}.Match(nil, []crdt.Entry{present}))
+	testutil.Assert(t, "Unexpected exists", !Exists{}.Match(nil, []crdt.Entry{present, missing}))
+	testutil.Assert(t, "Unexpected exists", !Exists{}.Match(nil, nil))
+}
+
+func TestMissing(t *testing.T) {
+	present := crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("foo")})
+	missing := crdt.EmptyEntry()
+
+	testutil.Assert(t, "Expected missing", Missing{}.Match(nil, []crdt.Entry{missing}))
+	testutil.Assert(t, "Unexpected missing", !Missing{}.Match(nil, []crdt.Entry{present, missing}))
+	testutil.Assert(t, "Unexpected missing", !Missing{}.Match(nil, nil))
+}
+
 func testMatchFunction(t *testing.T, function MatchFunction, pattern, text string) {
 	const size = 50
 
@@ -0,0 +1,81 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+// PresenceFunction is a MatchFunction that tests whether a row has entries.
+// When AllowMissing is true, the function is passed an empty Entry in place
+// of each entry the row lacks, instead of the predicate failing to match.
+type PresenceFunction interface {
+	MatchFunction
+	AllowMissing() bool
+}
+
+func checkPresenceArity(name string, count int) error {
+	if count == 0 {
+		return fmt.Errorf("%s requires at least one entry", name)
+	}
+
+	return nil
+}
+
+// Exists matches when every entry has a value.
+type Exists struct{}
+
+func (Exists) FuncName() string {
+	return "exists"
+}
+
+func (Exists) AllowMissing() bool {
+	return true
+}
+
+func (Exists) CheckArity(count int) error {
+	return checkPresenceArity("exists", count)
+}
+
+func (Exists) Match(literals []string, entries []crdt.Entry) bool {
+	if len(entries) == 0 {
+		return false
+	}
+
+	for _, entry := range entries {
+		if entry.IsRemoved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Missing matches when no entry has a value.
+type Missing struct{}
+
+func (Missing) FuncName() string {
+	return "missing"
+}
+
+func (Missing) AllowMissing() bool {
+	return true
+}
+
+func (Missing) CheckArity(count int) error {
+	return checkPresenceArity("missing", count)
+}
+
+func (Missing) Match(literals []string, entries []crdt.Entry) bool {
+	if len(entries) == 0 {
+		return false
+	}
+
+	for _, entry := range entries {
+		if !entry.IsRemoved() {
+			return false
+		}
+	}
+
+	return true
+}
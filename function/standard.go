@@ -7,8 +7,13 @@ func StandardFunctions() FunctionNamespace {
 		StrEq{},
 		&StrGlob{},
 		&StrRegexp{},
+		Exists{},
+		Missing{},
 	}
 
+	funcs = append(funcs, NumericComparisons()...)
+	funcs = append(funcs, TimeComparisons()...)
+
 	for _, f := range funcs {
 		err := functions.PutFunction(f)
 
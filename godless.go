@@ -66,6 +66,17 @@ type Options struct {
 	PublicServer bool
 	// WebService is optional.
 	WebService api.WebService
+	// NodeID is optional.  Identifies this server in timestamps and counter shards.  Random if not set,
+	// in which case each run writes its own counter shards.
+	NodeID string
+	// LWWTables is optional.  Joins to these tables will always be last-writer-wins.
+	LWWTables []crdt.TableName
+	// CompactInterval is optional.  The duration between index compactions.  Zero disables background compaction.
+	CompactInterval time.Duration
+	// CompactThreshold is optional.  Tables with fewer namespace links than this are not compacted in the background.
+	CompactThreshold int
+	// ShardRows is optional.  Namespaces with more rows are written to IPFS as a sharded trie.  Zero disables sharding.
+	ShardRows int
 	// Shutdown mechanism
 	shutdownLock         sync.Mutex
 	isShutdownInProgress bool
@@ -131,6 +142,11 @@ func (godless *Godless) Send(request api.Request) (api.Response, error) {
 	return resp, nil
 }
 
+// Watch streams the results of a watch request, until stop is closed.
+func (godless *Godless) Watch(request api.Request, stop <-chan struct{}) (<-chan api.Response, error) {
+	return godless.api.Watch(request, stop)
+}
+
 func (godless *Godless) report() {
 	if godless.PublicServer {
 		log.Info("Running public Godless API")
@@ -229,7 +245,8 @@ func (godless *Godless) connectDataPeer() error {
 func (godless *Godless) connectRemoteStore() error {
 	if godless.RemoteStore == nil {
 		ipfs := &service.ContentAddressableRemoteStore{
-			Shell: godless.DataPeer,
+			Shell:     godless.DataPeer,
+			ShardRows: godless.ShardRows,
 		}
 
 		if godless.FailEarly {
@@ -270,13 +287,20 @@ func (godless *Godless) setupNamespace() error {
 	}
 
 	namespaceOptions := service.RemoteNamespaceCoreOptions{
-		Pulse:         godless.Pulse,
-		Store:         godless.RemoteStore,
-		Cache:         godless.Cache,
-		KeyStore:      godless.KeyStore,
-		IsPublicIndex: godless.PublicServer,
-		MemoryImage:   godless.MemoryImage,
-		Functions:     godless.Functions,
+		Pulse:            godless.Pulse,
+		Store:            godless.RemoteStore,
+		Cache:            godless.Cache,
+		KeyStore:         godless.KeyStore,
+		IsPublicIndex:    godless.PublicServer,
+		MemoryImage:      godless.MemoryImage,
+		Functions:        godless.Functions,
+		LWWTables:        godless.LWWTables,
+		CompactInterval:  godless.CompactInterval,
+		CompactThreshold: godless.CompactThreshold,
+	}
+
+	if godless.NodeID != "" {
+		namespaceOptions.Clock = crdt.MakeHybridClock(godless.NodeID)
 	}
 
 	godless.remote = service.MakeRemoteNamespaceCore(namespaceOptions)
@@ -321,7 +345,8 @@ func (godless *Godless) setupWebService() error {
 	}
 
 	options := http.WebServiceOptions{
-		Api: godless.api,
+		Api:   godless.api,
+		Watch: godless.api,
 	}
 
 	godless.WebService = http.MakeWebService(options)
@@ -356,17 +381,11 @@ func (godless *Godless) replicate() error {
 		return nil
 	}
 
-	pubsubTopics := make([]api.PubSubTopic, len(topics))
-
-	for i, t := range topics {
-		pubsubTopics[i] = api.PubSubTopic(t)
-	}
-
 	options := service.ReplicateOptions{
 		API:         godless.api,
 		RemoteStore: godless.RemoteStore,
 		Interval:    interval,
-		Topics:      pubsubTopics,
+		Topics:      godless.pubsubTopics(),
 		KeyStore:    godless.KeyStore,
 	}
 	closer, errch := service.Replicate(options)
@@ -375,6 +394,16 @@ func (godless *Godless) replicate() error {
 	return nil
 }
 
+func (godless *Godless) pubsubTopics() []api.PubSubTopic {
+	pubsubTopics := make([]api.PubSubTopic, len(godless.Topics))
+
+	for i, t := range godless.Topics {
+		pubsubTopics[i] = api.PubSubTopic(t)
+	}
+
+	return pubsubTopics
+}
+
 func (godless *Godless) addCloser(closer api.Closer) {
 	if godless.stopch == nil {
 		godless.stopch = make(chan struct{})
@@ -406,6 +435,23 @@ func MakeKeyStore() api.KeyStore {
 	return &crypto.KeyStore{}
 }
 
+// MakeRemoteStore connects to IPFS to read godless data without running a
+// server.
+func MakeRemoteStore(ipfsServiceUrl string) (api.RemoteStore, error) {
+	options := datapeer.IpfsWebServiceOptions{
+		Url: ipfsServiceUrl,
+	}
+	peer := datapeer.MakeIpfsWebService(options)
+
+	err := peer.Connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return service.MakeContentAddressableRemoteStore(peer), nil
+}
+
 func breakOnError(pipeline []func() error) error {
 	for _, f := range pipeline {
 		err := f()
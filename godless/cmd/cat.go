@@ -14,6 +14,8 @@
 package cmd
 
 import (
+	"bufio"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -23,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	ipfs "github.com/ipfs/go-ipfs-api"
+	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/proto"
 )
 
@@ -46,7 +49,14 @@ func catMessage(cmd *cobra.Command, streamer catStreamer) {
 
 	defer drainInput(input)
 
-	message, decodeErr := streamer.decode(input)
+	buff := bufio.NewReader(input)
+
+	if messageStreamer, ok := streamer.(catMessageStreamer); ok && catBinaryIn && crdt.IsStreamEncoded(buff) {
+		catStream(buff, messageStreamer)
+		return
+	}
+
+	message, decodeErr := streamer.decode(buff)
 
 	if decodeErr != nil {
 		die(decodeErr)
@@ -59,6 +69,35 @@ func catMessage(cmd *cobra.Command, streamer catStreamer) {
 	}
 }
 
+// catStream writes stream encoded data one message at a time, so that large
+// namespaces are not held in memory.
+func catStream(r *bufio.Reader, streamer catMessageStreamer) {
+	if catBinaryOut {
+		_, err := io.Copy(os.Stdout, r)
+
+		if err != nil {
+			die(err)
+		}
+
+		return
+	}
+
+	err := streamer.stream(r, func(message pb.Message) error {
+		err := pb.MarshalText(os.Stdout, message)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Println()
+		return err
+	})
+
+	if err != nil {
+		die(err)
+	}
+}
+
 var filePath string
 var catBinaryOut bool
 var catBinaryIn bool
@@ -77,6 +116,13 @@ type catStreamer interface {
 	decode(io.Reader) (pb.Message, error)
 }
 
+// catMessageStreamer can also read the stream encoding of namespaces and
+// indices, passing each message to f as it is read.
+type catMessageStreamer interface {
+	catStreamer
+	stream(r *bufio.Reader, f func(pb.Message) error) error
+}
+
 func catEncode(message pb.Message) error {
 	if catBinaryOut {
 		bs, err := pb.Marshal(message)
@@ -107,6 +153,27 @@ func (streamer namespaceStreamer) decode(r io.Reader) (pb.Message, error) {
 	return pb, err
 }
 
+// stream passes each namespace entry to f.  A sharded namespace has no
+// entries, so its shard links are passed afterwards.
+func (streamer namespaceStreamer) stream(r *bufio.Reader, f func(pb.Message) error) error {
+	shards, err := crdt.DecodeNamespaceStream(r, func(entry crdt.NamespaceStreamEntry) error {
+		return f(crdt.MakeNamespaceEntryMessage(entry))
+	})
+
+	if err != nil || len(shards) == 0 {
+		return err
+	}
+
+	node := crdt.NamespaceNode{Namespace: crdt.EmptyNamespace(), Shards: shards}
+	header, _ := crdt.MakeNamespaceNodeMessage(node)
+
+	return f(header)
+}
+
+func (streamer indexStreamer) stream(r *bufio.Reader, f func(pb.Message) error) error {
+	return crdt.DecodeMessageStream(r, &proto.IndexMessage{}, &proto.IndexEntryMessage{}, f)
+}
+
 func (streamer indexStreamer) decode(r io.Reader) (pb.Message, error) {
 	pb := &proto.IndexMessage{}
 	err := catDecode(r, pb)
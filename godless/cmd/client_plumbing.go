@@ -192,6 +192,8 @@ func parseReflect() (api.ReflectionType, error) {
 		return api.REFLECT_HEAD_PATH, nil
 	case "namespace":
 		return api.REFLECT_DUMP_NAMESPACE, nil
+	case "log":
+		return api.REFLECT_INDEX_LOG, nil
 	default:
 		return api.REFLECT_NOOP, fmt.Errorf("Unknown reflect type: %v", reflect)
 	}
@@ -201,7 +203,7 @@ func init() {
 	queryCmd.AddCommand(clientPlumbingCmd)
 
 	clientPlumbingCmd.Flags().StringVar(&replicate, "replicate", "", "Replicate index from hash")
-	clientPlumbingCmd.Flags().StringVar(&reflect, "reflect", "", "Reflect on server state. (index|head|namespace)")
+	clientPlumbingCmd.Flags().StringVar(&reflect, "reflect", "", "Reflect on server state. (index|head|namespace|log)")
 	clientPlumbingCmd.Flags().BoolVar(&queryBinary, "binary", false, "Output protocol buffer binary")
 	clientPlumbingCmd.Flags().BoolVar(&dryrun, "dryrun", false, "Don't send query to server")
 	clientPlumbingCmd.Flags().StringVar(&source, "query", "", "Godless NoSQL query text")
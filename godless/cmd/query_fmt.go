@@ -0,0 +1,154 @@
+// Copyright © 2017 Johnny Morrice <john@functorama.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/query"
+)
+
+var queryFmtCmd = &cobra.Command{
+	Use:   "fmt [FILE]...",
+	Short: "Format query files",
+	Long: `Rewrite queries in canonical layout.  The formatted query always compiles to the same query as the original.
+
+With no files, the query is read from stdin and written to stdout.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		options := query.FormatOptions{
+			Indent:  fmtIndent,
+			Compact: fmtCompact,
+		}
+
+		if len(args) == 0 {
+			formatStdin(options)
+			return
+		}
+
+		unformatted := 0
+		for _, path := range args {
+			changed, err := formatFile(path, options)
+
+			if err != nil {
+				die(err)
+			}
+
+			if changed && fmtCheck {
+				fmt.Println(path)
+				unformatted++
+			}
+		}
+
+		if unformatted > 0 {
+			die(fmt.Errorf("%d files not formatted", unformatted))
+		}
+	},
+}
+
+var fmtWrite bool
+var fmtCheck bool
+var fmtCompact bool
+var fmtIndent string
+
+func formatStdin(options query.FormatOptions) {
+	source, err := ioutil.ReadAll(os.Stdin)
+
+	if err != nil {
+		die(err)
+	}
+
+	formatted, err := formatQuerySource(string(source), options)
+
+	if err != nil {
+		die(err)
+	}
+
+	if fmtCheck {
+		if formatted != string(source) {
+			die(fmt.Errorf("stdin not formatted"))
+		}
+
+		return
+	}
+
+	fmt.Print(formatted)
+}
+
+// formatFile reports whether the file was not already formatted.
+func formatFile(path string, options query.FormatOptions) (bool, error) {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return false, err
+	}
+
+	source, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return false, err
+	}
+
+	formatted, err := formatQuerySource(string(source), options)
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	changed := formatted != string(source)
+
+	if fmtCheck {
+		return changed, nil
+	}
+
+	if !fmtWrite {
+		fmt.Print(formatted)
+		return changed, nil
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, ioutil.WriteFile(path, []byte(formatted), info.Mode())
+}
+
+func formatQuerySource(source string, options query.FormatOptions) (string, error) {
+	formatted, err := query.FormatSource(source, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	return formatted + "\n", nil
+}
+
+func init() {
+	queryCmd.AddCommand(queryFmtCmd)
+
+	queryFmtCmd.Flags().BoolVarP(&fmtWrite, "write", "w", false, "Write result to the query file instead of stdout")
+	queryFmtCmd.Flags().BoolVarP(&fmtCheck, "check", "c", false, "List files that are not formatted, and fail if there are any")
+	queryFmtCmd.Flags().BoolVar(&fmtCompact, "compact", false, "Format each query on a single line")
+	queryFmtCmd.Flags().StringVar(&fmtIndent, "indent", "\t", "Indent for each level of nesting")
+}
@@ -0,0 +1,113 @@
+// Copyright © 2017 Johnny Morrice <john@functorama.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/query"
+)
+
+var queryRunCmd = &cobra.Command{
+	Use:   "run SCRIPT",
+	Short: "Run a query script",
+	Long: `Run each statement in a script file, in order, and report the result of each.
+
+Statements are separated by ';'.  Text from '--' to the end of the line is a comment.  The script stops at the first failed statement, unless --keep-going is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			die(errors.New("Expected script file"))
+		}
+
+		source, err := ioutil.ReadFile(args[0])
+
+		if err != nil {
+			die(err)
+		}
+
+		script, err := query.CompileScript(string(source))
+
+		if err != nil {
+			die(err)
+		}
+
+		client := makeClient()
+		failed := runScript(client, script)
+
+		if failed > 0 {
+			die(fmt.Errorf("%d of %d statements failed", failed, len(script)))
+		}
+	},
+}
+
+var keepGoing bool
+
+func runScript(client api.Client, script []*query.Query) int {
+	failed := 0
+
+	for i, q := range script {
+		text, err := q.FormatText(query.FormatOptions{Compact: true})
+
+		if err != nil {
+			die(err)
+		}
+
+		fmt.Printf("-- Statement %d of %d: %s\n", i+1, len(script), text)
+
+		response, err := client.Send(api.MakeQueryRequest(q))
+
+		if err == nil {
+			err = response.Err
+		}
+
+		if err != nil {
+			fmt.Printf("Error: %s\n\n", err.Error())
+			failed++
+
+			if !keepGoing {
+				return failed
+			}
+
+			continue
+		}
+
+		output, err := response.AsText()
+
+		if err != nil {
+			die(err)
+		}
+
+		fmt.Println(output)
+	}
+
+	return failed
+}
+
+func init() {
+	queryCmd.AddCommand(queryRunCmd)
+
+	queryRunCmd.Flags().BoolVar(&keepGoing, "keep-going", false, "Run the remaining statements after a failure")
+}
@@ -33,6 +33,7 @@ import (
 	lib "github.com/johnny-morrice/godless"
 	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/cache"
+	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/http"
 	"github.com/johnny-morrice/godless/log"
 )
@@ -76,6 +77,11 @@ func serve(cmd *cobra.Command) {
 		PriorityQueue:     queue,
 		Cache:             cache,
 		MemoryImage:       memimg,
+		NodeID:            nodeID,
+		LWWTables:         makeTableNames(lwwTables),
+		CompactInterval:   compactInterval,
+		CompactThreshold:  compactThreshold,
+		ShardRows:         shardRows,
 	}
 
 	godless, err := lib.New(options)
@@ -104,6 +110,21 @@ var serverTimeout time.Duration
 var cacheType string
 var databaseFilePath string
 var boltFactory *cache.BoltFactory
+var nodeID string
+var lwwTables []string
+var compactInterval time.Duration
+var compactThreshold int
+var shardRows int
+
+func makeTableNames(tables []string) []crdt.TableName {
+	names := make([]crdt.TableName, len(tables))
+
+	for i, t := range tables {
+		names[i] = crdt.TableName(t)
+	}
+
+	return names
+}
 
 func makeCache(cmd *cobra.Command) (api.Cache, error) {
 	switch cacheType {
@@ -201,6 +222,11 @@ func init() {
 	serveCmd.PersistentFlags().StringVar(&cacheType, "cache", __DEFAULT_CACHE_TYPE, "Cache type (disk|memory)")
 	serveCmd.PersistentFlags().IntVar(&memoryBufferLength, "buffer", __DEFAULT_MEMORY_BUFFER_LENGTH, "Buffer length if using memory cache")
 	serveCmd.PersistentFlags().StringVar(&databaseFilePath, "dbpath", defaultBoltDb, "Embedded database file path")
+	serveCmd.PersistentFlags().StringVar(&nodeID, "node", "", "Node ID for timestamps and counter shards (random if not set)")
+	serveCmd.PersistentFlags().StringSliceVar(&lwwTables, "lww", []string{}, "Comma separated list of tables that are always joined last-writer-wins")
+	serveCmd.PersistentFlags().DurationVar(&compactInterval, "compact", __DEFAULT_COMPACT_INTERVAL, "Interval between index compactions (0 to disable)")
+	serveCmd.PersistentFlags().IntVar(&compactThreshold, "compact-links", __DEFAULT_COMPACT_THRESHOLD, "Compact tables with at least this many namespace links")
+	serveCmd.PersistentFlags().IntVar(&shardRows, "shard-rows", __DEFAULT_SHARD_ROWS, "Shard namespaces with more rows than this (0 to disable)")
 }
 
 const __MEMORY_CACHE_TYPE = "memory"
@@ -215,4 +241,7 @@ const __DEFAULT_SERVER_TIMEOUT = time.Minute * 10
 const __DEFAULT_QUEUE_LENGTH = 4096
 const __DEFAULT_PULSE = time.Second * 10
 const __DEFAULT_REPLICATION_INTERVAL = time.Minute
+const __DEFAULT_COMPACT_INTERVAL = 0
+const __DEFAULT_COMPACT_THRESHOLD = 16
+const __DEFAULT_SHARD_ROWS = 0
 const __DEFAULT_MEMORY_BUFFER_LENGTH = -1
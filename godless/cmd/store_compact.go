@@ -0,0 +1,63 @@
+// Copyright © 2017 NAME HERE <EMAIL ADDRESS>
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+var storeCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Merge the namespaces behind each table",
+	Long: `Merge the namespaces linked for each table into a few new namespaces, and publish a smaller index as HEAD.
+
+Only namespaces signed by the server's own keys are compacted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			die(errors.New("Unexpected arguments"))
+		}
+
+		client := makeClient()
+
+		response, err := client.Send(api.MakeCompactRequest())
+
+		if err != nil {
+			die(err)
+		}
+
+		if response.Err != nil {
+			die(response.Err)
+		}
+
+		if crdt.IsNilPath(response.Path) {
+			fmt.Println("Nothing to compact")
+			return
+		}
+
+		fmt.Printf("Compacted index, HEAD is now: %s\n", response.Path)
+	},
+}
+
+func init() {
+	storeCmd.AddCommand(storeCompactCmd)
+
+	storeCompactCmd.Flags().StringVar(&serverAddr, "server", __DEFAULT_QUERY_SERVER, "Server address")
+	storeCompactCmd.Flags().DurationVar(&queryTimeout, "timeout", __DEFAULT_QUERY_TIMEOUT, "Query timeout")
+}
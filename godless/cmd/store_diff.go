@@ -0,0 +1,129 @@
+// Copyright © 2017 NAME HERE <EMAIL ADDRESS>
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	lib "github.com/johnny-morrice/godless"
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/cli"
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+var storeDiffCmd = &cobra.Command{
+	Use:   "diff HASHA HASHB",
+	Short: "Compare two namespaces or indices",
+	Long: `Show what the data at HASHB adds to the data at HASHA, and what it lacks.  Points and links are shown once for each signature, so data that has only been signed differently is shown.  To review the index a peer published, do:
+
+	godless store diff --index MYINDEX PEERINDEX`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			die(errors.New("Expected two hashes"))
+		}
+
+		store, err := lib.MakeRemoteStore(ipfsService)
+
+		if err != nil {
+			die(err)
+		}
+
+		a := crdt.IPFSPath(args[0])
+		b := crdt.IPFSPath(args[1])
+
+		if diffIndex {
+			printIndexDiff(store, a, b)
+		} else {
+			printNamespaceDiff(store, a, b)
+		}
+	},
+}
+
+var diffIndex bool
+var diffJson bool
+
+func printNamespaceDiff(store api.RemoteStore, a, b crdt.IPFSPath) {
+	namespaceA, err := store.CatNamespace(a)
+
+	if err != nil {
+		die(err)
+	}
+
+	namespaceB, err := store.CatNamespace(b)
+
+	if err != nil {
+		die(err)
+	}
+
+	added := crdt.DiffNamespace(namespaceA, namespaceB)
+	removed := crdt.DiffNamespace(namespaceB, namespaceA)
+
+	if diffJson {
+		printDiffJson(added, removed)
+		return
+	}
+
+	cli.FprintNamespaceDiff(os.Stdout, added, removed)
+}
+
+func printIndexDiff(store api.RemoteStore, a, b crdt.IPFSPath) {
+	indexA, err := store.CatIndex(a)
+
+	if err != nil {
+		die(err)
+	}
+
+	indexB, err := store.CatIndex(b)
+
+	if err != nil {
+		die(err)
+	}
+
+	added := crdt.DiffIndex(indexA, indexB)
+	removed := crdt.DiffIndex(indexB, indexA)
+
+	if diffJson {
+		printDiffJson(added, removed)
+		return
+	}
+
+	cli.FprintIndexDiff(os.Stdout, added, removed)
+}
+
+func printDiffJson(added, removed interface{}) {
+	diff := map[string]interface{}{
+		"Added":   added,
+		"Removed": removed,
+	}
+
+	bs, err := json.MarshalIndent(diff, "", "  ")
+
+	if err != nil {
+		die(err)
+	}
+
+	fmt.Println(string(bs))
+}
+
+func init() {
+	storeCmd.AddCommand(storeDiffCmd)
+
+	storeDiffCmd.Flags().BoolVar(&diffIndex, "index", false, "Compare indices instead of namespaces")
+	storeDiffCmd.Flags().BoolVar(&diffJson, "json", false, "Output JSON instead of a table")
+}
@@ -0,0 +1,55 @@
+// Copyright © 2017 NAME HERE <EMAIL ADDRESS>
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/cli"
+)
+
+var storeLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the index history of a godless server",
+	Long:  `Walk the index chain back from HEAD, showing when each index was created and which tables changed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := makeClient()
+
+		request := api.MakeIndexLogRequest(logLimit)
+		response, err := client.Send(request)
+
+		if err != nil {
+			die(err)
+		}
+
+		if response.Err != nil {
+			die(response.Err)
+		}
+
+		cli.FprintIndexLog(os.Stdout, response)
+	},
+}
+
+var logLimit uint32
+
+func init() {
+	storeCmd.AddCommand(storeLogCmd)
+
+	storeLogCmd.Flags().StringVar(&serverAddr, "server", __DEFAULT_QUERY_SERVER, "Server address")
+	storeLogCmd.Flags().DurationVar(&queryTimeout, "timeout", __DEFAULT_QUERY_TIMEOUT, "Query timeout")
+	storeLogCmd.Flags().Uint32Var(&logLimit, "limit", api.DEFAULT_LOG_LIMIT, "Most index log entries to show")
+}
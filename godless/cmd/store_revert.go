@@ -0,0 +1,59 @@
+// Copyright © 2017 NAME HERE <EMAIL ADDRESS>
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+var storeRevertCmd = &cobra.Command{
+	Use:   "revert INDEXHASH",
+	Short: "Make a past index HEAD",
+	Long: `Reset HEAD to a past index, recording the revert in the index log.
+
+Namespaces dropped by the revert are ignored in peer indices until the server restarts, so replicating peers do not merge them straight back.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			die(errors.New("Expected index hash"))
+		}
+
+		client := makeClient()
+
+		target := crdt.IPFSPath(args[0])
+		response, err := client.Send(api.MakeRevertRequest(target))
+
+		if err != nil {
+			die(err)
+		}
+
+		if response.Err != nil {
+			die(response.Err)
+		}
+
+		fmt.Printf("Reverted to %s, HEAD is now: %s\n", target, response.Path)
+	},
+}
+
+func init() {
+	storeCmd.AddCommand(storeRevertCmd)
+
+	storeRevertCmd.Flags().StringVar(&serverAddr, "server", __DEFAULT_QUERY_SERVER, "Server address")
+	storeRevertCmd.Flags().DurationVar(&queryTimeout, "timeout", __DEFAULT_QUERY_TIMEOUT, "Query timeout")
+}
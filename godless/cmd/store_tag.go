@@ -0,0 +1,116 @@
+// Copyright © 2017 NAME HERE <EMAIL ADDRESS>
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/cli"
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+var storeTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Name index heads",
+	Long: `Tags give a stable name to an index, so it can be found after later joins.  To tag HEAD, do:
+
+	godless store tag create release`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := cmd.Help()
+
+		if err != nil {
+			die(err)
+		}
+	},
+}
+
+var storeTagCreateCmd = &cobra.Command{
+	Use:   "create NAME [INDEXHASH]",
+	Short: "Tag an index",
+	Long:  `Tag an index by hash.  HEAD is tagged if no hash is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 || len(args) > 2 {
+			die(errors.New("Expected tag name and optional index hash"))
+		}
+
+		tag := api.TagRequest{
+			Command: api.TAG_CREATE,
+			Name:    args[0],
+		}
+
+		if len(args) > 1 {
+			tag.Path = crdt.IPFSPath(args[1])
+		}
+
+		response := sendTagRequest(tag)
+		fmt.Printf("Tagged '%s' at: %s\n", tag.Name, response.Path)
+	},
+}
+
+var storeTagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags",
+	Run: func(cmd *cobra.Command, args []string) {
+		response := sendTagRequest(api.TagRequest{Command: api.TAG_LIST})
+		cli.FprintTags(os.Stdout, response)
+	},
+}
+
+var storeTagCheckoutCmd = &cobra.Command{
+	Use:   "checkout NAME",
+	Short: "Make a tagged index HEAD",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			die(errors.New("Expected tag name"))
+		}
+
+		tag := api.TagRequest{
+			Command: api.TAG_CHECKOUT,
+			Name:    args[0],
+		}
+
+		response := sendTagRequest(tag)
+		fmt.Printf("HEAD is now '%s' at: %s\n", tag.Name, response.Path)
+	},
+}
+
+func sendTagRequest(tag api.TagRequest) api.Response {
+	client := makeClient()
+	response, err := client.Send(api.MakeTagRequest(tag))
+
+	if err != nil {
+		die(err)
+	}
+
+	if response.Err != nil {
+		die(response.Err)
+	}
+
+	return response
+}
+
+func init() {
+	storeCmd.AddCommand(storeTagCmd)
+	storeTagCmd.AddCommand(storeTagCreateCmd)
+	storeTagCmd.AddCommand(storeTagListCmd)
+	storeTagCmd.AddCommand(storeTagCheckoutCmd)
+
+	storeTagCmd.PersistentFlags().StringVar(&serverAddr, "server", __DEFAULT_QUERY_SERVER, "Server address")
+	storeTagCmd.PersistentFlags().DurationVar(&queryTimeout, "timeout", __DEFAULT_QUERY_TIMEOUT, "Query timeout")
+}
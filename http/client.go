@@ -1,7 +1,9 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	gohttp "net/http"
@@ -61,6 +63,108 @@ func (client *client) Send(request api.Request) (api.Response, error) {
 	return client.Post(client.CommandEndpoint, MIME_PROTO, buff)
 }
 
+// Watch posts a watch request and streams the Responses until stop is closed
+// or the server ends the stream.  A failure part way through the stream is
+// sent as a Response with an Err.
+func (client *client) Watch(request api.Request, stop <-chan struct{}) (<-chan api.Response, error) {
+	const failMsg = "Watch failed"
+
+	err := request.Validate(client.Validator)
+
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Cowardly refusing to send invalid Request: %v", request))
+	}
+
+	buff := &bytes.Buffer{}
+	err = api.EncodeRequest(request, buff)
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	addr := client.ServerAddr + client.WatchEndpoint
+	log.Info("HTTP POST watch to %s", addr)
+
+	httpRequest, err := gohttp.NewRequest("POST", addr, buff)
+
+	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpRequest = httpRequest.WithContext(ctx)
+	httpRequest.Header.Set(CONTENT_TYPE, MIME_PROTO)
+
+	// The stream lasts as long as the watch, so the usual timeout does not apply.
+	streamClient := &gohttp.Client{Transport: client.Http.Transport}
+	resp, err := streamClient.Do(httpRequest)
+
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	if !HasContentType(resp.Header, MIME_PROTO_STREAM) {
+		defer cancel()
+		defer resp.Body.Close()
+
+		apiresp, err := client.decodeHttpResponse(resp)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error decoding API response")
+		}
+
+		return nil, errors.Wrap(apiresp.Err, "API returned error")
+	}
+
+	respch := make(chan api.Response)
+	donech := make(chan struct{})
+
+	go func() {
+		select {
+		case <-stop:
+		case <-donech:
+		}
+
+		cancel()
+	}()
+
+	go client.readWatchStream(resp, stop, respch, donech)
+
+	return respch, nil
+}
+
+func (client *client) readWatchStream(resp *gohttp.Response, stop <-chan struct{}, respch chan<- api.Response, donech chan<- struct{}) {
+	defer close(donech)
+	defer close(respch)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+
+	for {
+		apiresp, err := api.DecodeResponseFrame(reader)
+
+		if err == io.EOF {
+			return
+		}
+
+		if err != nil {
+			apiresp = api.RESPONSE_FAIL
+			apiresp.Err = errors.Wrap(err, "Error decoding API response")
+		}
+
+		select {
+		case respch <- apiresp:
+		case <-stop:
+			return
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
 func (client *client) Post(path, bodyType string, body io.Reader) (api.Response, error) {
 	addr := client.ServerAddr + path
 	log.Info("HTTP POST to %s", addr)
@@ -6,16 +6,25 @@ import (
 
 type Endpoints struct {
 	CommandEndpoint string
+	WatchEndpoint   string
 }
 
 func (endpoint *Endpoints) IsCommandEndpoint(url *url.URL) bool {
 	return endpoint.CommandEndpoint == url.Path
 }
 
+func (endpoint *Endpoints) IsWatchEndpoint(url *url.URL) bool {
+	return endpoint.WatchEndpoint == url.Path
+}
+
 // UseDefaultEndpoints overwrites empty endpoints with Defaults.
 // It does not overwrite a non-empty endpoints.
 func (endpoint *Endpoints) UseDefaultEndpoints() {
 	if endpoint.CommandEndpoint == "" {
 		endpoint.CommandEndpoint = API_ROOT
 	}
+
+	if endpoint.WatchEndpoint == "" {
+		endpoint.WatchEndpoint = API_ROOT + WATCH_API_ROOT
+	}
 }
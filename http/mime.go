@@ -10,6 +10,9 @@ import (
 const MIME_PROTO_TEXT = "text/plain"
 const MIME_PROTO = "application/octet-stream"
 
+// MIME_PROTO_STREAM is a stream of length delimited protobuf messages.
+const MIME_PROTO_STREAM = "application/x-protobuf-stream"
+
 // TODO That we have a MIME_EMPTY indicates design flaw.
 const MIME_EMPTY = "text/plain"
 const CONTENT_TYPE = "Content-Type"
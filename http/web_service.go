@@ -13,10 +13,13 @@ import (
 const API_ROOT = "/api"
 const QUERY_API_ROOT = "/query"
 const REFLECT_API_ROOT = "/reflect"
+const WATCH_API_ROOT = "/watch"
 
 type WebServiceOptions struct {
 	Endpoints
 	Api api.RequestService
+	// Watch is optional.  Watch requests are refused if it is nil.
+	Watch api.WatchService
 }
 
 type WebService struct {
@@ -44,6 +47,11 @@ func (service *WebService) GetApiRequestHandler() gohttp.Handler {
 }
 
 func (service *WebService) handleApiRequest(rw gohttp.ResponseWriter, req *gohttp.Request) {
+	if service.IsWatchEndpoint(req.URL) {
+		service.handleWatchRequest(rw, req)
+		return
+	}
+
 	if !service.IsCommandEndpoint(req.URL) {
 		log.Info("Bad URL for ApiRequestHandler")
 		rw.WriteHeader(NOT_FOUND)
@@ -62,6 +70,62 @@ func (service *WebService) handleApiRequest(rw gohttp.ResponseWriter, req *gohtt
 	service.respond(rw, respch, err)
 }
 
+// handleWatchRequest streams a Response frame each time the watched results
+// change, until the client goes away or the WebService closes.
+func (service *WebService) handleWatchRequest(rw gohttp.ResponseWriter, req *gohttp.Request) {
+	log.Info("WebService watch api.Request at: %v", req.RequestURI)
+
+	if service.Watch == nil {
+		invalidRequest(rw, errors.New("WebService does not support watch requests"))
+		return
+	}
+
+	request, err := api.DecodeRequest(req.Body)
+
+	if err != nil {
+		invalidRequest(rw, err)
+		return
+	}
+
+	stopch := make(chan struct{})
+	defer close(stopch)
+
+	respch, err := service.Watch.Watch(request, stopch)
+
+	if err != nil {
+		invalidRequest(rw, err)
+		return
+	}
+
+	rw.Header()[CONTENT_TYPE] = []string{MIME_PROTO_STREAM}
+	flusher, canFlush := rw.(gohttp.Flusher)
+
+	for {
+		select {
+		case resp, ok := <-respch:
+			if !ok {
+				return
+			}
+
+			err := api.EncodeResponseFrame(resp, rw)
+
+			if err != nil {
+				log.Error("Error sending watch response: %v", err)
+				return
+			}
+
+			if canFlush {
+				flusher.Flush()
+			}
+		case <-req.Context().Done():
+			log.Info("Watch client went away")
+			return
+		case <-service.stopch:
+			return
+		}
+	}
+}
+
 func invalidRequest(rw gohttp.ResponseWriter, err error) {
 	log.Info("Invalid Request details: %s", err.Error())
 	reportErr := sendErr(rw, err)
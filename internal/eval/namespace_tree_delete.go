@@ -0,0 +1,241 @@
+package eval
+
+import (
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/crypto"
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/query"
+	"github.com/pkg/errors"
+)
+
+// NamespaceTreeDelete removes data by joining tombstones for every point it
+// can observe in the selected rows.  Each tombstone carries the tag of the
+// point it removes, so points added by peers that we have not yet seen, or
+// added again later, are left alone.
+type NamespaceTreeDelete struct {
+	query.NoSelectVisitor
+	query.NoJoinVisitor
+	query.NoDebugVisitor
+	query.ErrorCollectVisitor
+	Namespace          api.RemoteNamespace
+	tableKey           crdt.TableName
+	rows               []query.QueryRowDelete
+	observed           crdt.Namespace
+	privateKeys        []crypto.PrivateKey
+	keyStore           api.KeyStore
+	namespaceLoadError bool
+	indexLoadError     bool
+}
+
+func MakeNamespaceTreeDelete(ns api.RemoteNamespace, keyStore api.KeyStore) *NamespaceTreeDelete {
+	return &NamespaceTreeDelete{
+		Namespace: ns,
+		keyStore:  keyStore,
+		observed:  crdt.EmptyNamespace(),
+	}
+}
+
+func (visitor *NamespaceTreeDelete) RunQuery() api.Response {
+	const failMsg = "NamespaceTreeDelete failed"
+
+	fail := api.RESPONSE_FAIL
+	fail.Type = api.API_QUERY
+
+	err := visitor.Error()
+	if err != nil {
+		fail.Err = err
+		return fail
+	}
+
+	if visitor.tableKey == "" {
+		panic("Expected table key")
+	}
+
+	searcher := api.SignedTableSearcher{
+		Reader: api.SearchResultLambda(visitor.ReadSearchResult),
+		Tables: []crdt.TableName{visitor.tableKey},
+	}
+
+	err = visitor.Namespace.LoadTraverse(searcher)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	if visitor.indexLoadError {
+		fail.Err = errors.New("Index load failure")
+		return fail
+	}
+
+	table, err := visitor.makeTombstoneTable()
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	resp := api.RESPONSE_QUERY
+
+	if visitor.namespaceLoadError {
+		resp.Msg = "ok with load errors"
+	}
+
+	if len(table.Rows) == 0 {
+		log.Info("Nothing to delete")
+		return resp
+	}
+
+	path, err := visitor.Namespace.JoinTable(visitor.tableKey, table)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	resp.Path = path
+
+	return resp
+}
+
+func (visitor *NamespaceTreeDelete) ReadSearchResult(result api.SearchResult) api.TraversalUpdate {
+	if result.NamespaceLoadFailure {
+		visitor.namespaceLoadError = true
+		return api.TraversalUpdate{More: true}
+	}
+
+	if result.IndexLoadFailure {
+		visitor.indexLoadError = true
+		return api.TraversalUpdate{}
+	}
+
+	visitor.observed = visitor.observed.JoinNamespace(result.Namespace)
+
+	return api.TraversalUpdate{More: true}
+}
+
+func (visitor *NamespaceTreeDelete) makeTombstoneTable() (crdt.Table, error) {
+	tombstones := crdt.EmptyTable()
+
+	observedTable, err := visitor.observed.GetTable(visitor.tableKey)
+
+	if err != nil {
+		return tombstones, nil
+	}
+
+	for _, rowDelete := range visitor.rows {
+		row, err := observedTable.GetRow(rowDelete.RowKey)
+
+		if err != nil {
+			continue
+		}
+
+		tombstoneRow, err := visitor.makeTombstoneRow(rowDelete, row)
+
+		if err != nil {
+			return crdt.EmptyTable(), err
+		}
+
+		if len(tombstoneRow.Entries) > 0 {
+			tombstones = tombstones.JoinRow(rowDelete.RowKey, tombstoneRow)
+		}
+	}
+
+	return tombstones, nil
+}
+
+func (visitor *NamespaceTreeDelete) makeTombstoneRow(rowDelete query.QueryRowDelete, row crdt.Row) (crdt.Row, error) {
+	tombstoneRow := crdt.EmptyRow()
+
+	for entryName, entry := range row.Entries {
+		if !rowDelete.IsWholeRow() && !containsEntryName(rowDelete.Entries, entryName) {
+			continue
+		}
+
+		if entry.IsCounter() {
+			if entry.IsRemoved() {
+				continue
+			}
+
+			counterTombstone := crdt.MakeCounterTombstoneEntry(entry.Counter.Copy().Shards)
+			tombstoneRow = tombstoneRow.JoinEntry(entryName, counterTombstone)
+			continue
+		}
+
+		// Overwritten points in a last-writer-wins entry are removed too, so
+		// that they do not win once the newest point is gone.
+		values := entry.GetAllValues()
+
+		if len(values) == 0 {
+			continue
+		}
+
+		tombstones := make([]crdt.Point, len(values))
+
+		for i, point := range values {
+			tombstone, err := crdt.SignedPoint(point.Text(), visitor.privateKeys)
+
+			if err != nil {
+				return crdt.EmptyRow(), errors.Wrap(err, "Failed to sign tombstone")
+			}
+
+			tombstones[i] = tombstone.Tagged(point.Tag())
+		}
+
+		tombstoneRow = tombstoneRow.JoinEntry(entryName, crdt.MakeTombstoneEntry(tombstones))
+	}
+
+	return tombstoneRow, nil
+}
+
+func (visitor *NamespaceTreeDelete) VisitPublicKeyHash(hash crypto.PublicKeyHash) {
+	priv, matchErr := visitor.keyStore.GetPrivateKey(hash)
+
+	if matchErr != nil {
+		log.Warn("Private key lookup failed with: %s", matchErr.Error())
+		visitor.BadPublicKey(hash)
+		return
+	}
+
+	log.Info("Deleting with private key for %s", string(hash))
+	visitor.privateKeys = append(visitor.privateKeys, priv)
+}
+
+func (visitor *NamespaceTreeDelete) VisitOpCode(opCode query.QueryOpCode) {
+	if opCode != query.DELETE {
+		visitor.CollectError(errors.New("Expected DELETE OpCode"))
+	}
+}
+
+func (visitor *NamespaceTreeDelete) VisitTableKey(tableKey crdt.TableName) {
+	if visitor.Error() != nil {
+		return
+	}
+
+	visitor.tableKey = tableKey
+}
+
+func (visitor *NamespaceTreeDelete) VisitDelete(*query.QueryDelete) {
+}
+
+func (visitor *NamespaceTreeDelete) LeaveDelete(*query.QueryDelete) {
+}
+
+func (visitor *NamespaceTreeDelete) VisitRowDelete(position int, rowDelete *query.QueryRowDelete) {
+	if visitor.Error() != nil {
+		return
+	}
+
+	visitor.rows = append(visitor.rows, *rowDelete)
+}
+
+func containsEntryName(entries []crdt.EntryName, entryName crdt.EntryName) bool {
+	for _, e := range entries {
+		if e == entryName {
+			return true
+		}
+	}
+
+	return false
+}
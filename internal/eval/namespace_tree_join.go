@@ -1,6 +1,8 @@
 package eval
 
 import (
+	"sync"
+
 	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/crypto"
@@ -10,20 +12,46 @@ import (
 )
 
 type NamespaceTreeJoin struct {
+	JoinOptions
 	query.NoSelectVisitor
+	query.NoDeleteVisitor
 	query.NoDebugVisitor
 	query.ErrorCollectVisitor
-	Namespace   api.RemoteNamespace
 	tableKey    crdt.TableName
-	table       crdt.Table
+	rows        []query.QueryRowJoin
 	privateKeys []crypto.PrivateKey
-	keyStore    api.KeyStore
+	lww         bool
+	timestamp   crdt.Timestamp
+	counters    map[counterPath]crdt.Counter
+	loadFailed  bool
+}
+
+// counterPath names a counter entry in the joined table.
+type counterPath struct {
+	row   crdt.RowName
+	entry crdt.EntryName
 }
 
-func MakeNamespaceTreeJoin(ns api.RemoteNamespace, keyStore api.KeyStore) *NamespaceTreeJoin {
+type JoinOptions struct {
+	Namespace api.RemoteNamespace
+	KeyStore  api.KeyStore
+	// Clock is required to join last-writer-wins entries.  Points are tagged
+	// with its reading, so that joining a point again after it was deleted
+	// brings it back.  Before a last-writer-wins join, the clock observes the
+	// rows it overwrites.  Counters are changed in the shard for the clock's
+	// node.
+	Clock *crdt.HybridClock
+	// CounterLock is optional.  It is held while counters are read and
+	// written, so that concurrent joins do not lose changes.
+	CounterLock sync.Locker
+	// LWWTables are always joined as last-writer-wins, whether or not the query asks.
+	LWWTables []crdt.TableName
+}
+
+func MakeNamespaceTreeJoin(options JoinOptions) *NamespaceTreeJoin {
 	return &NamespaceTreeJoin{
-		Namespace: ns,
-		keyStore:  keyStore,
+		JoinOptions: options,
+		counters:    map[counterPath]crdt.Counter{},
 	}
 }
 
@@ -41,7 +69,39 @@ func (visitor *NamespaceTreeJoin) RunQuery() api.Response {
 		panic("Expected table key")
 	}
 
-	path, err := visitor.Namespace.JoinTable(visitor.tableKey, visitor.table)
+	hasCounters := visitor.hasCounters()
+
+	if hasCounters && visitor.CounterLock != nil {
+		visitor.CounterLock.Lock()
+		defer visitor.CounterLock.Unlock()
+	}
+
+	if visitor.Clock != nil {
+		if visitor.lww || hasCounters {
+			err = visitor.loadRows()
+		}
+
+		if err != nil && hasCounters {
+			fail.Err = errors.Wrap(err, "NamespaceTreeJoin failed to read counters")
+			return fail
+		}
+
+		if err != nil {
+			log.Warn("NamespaceTreeJoin failed to observe rows: %s", err.Error())
+		}
+
+		// Every point in the query is written at the same instant.
+		visitor.timestamp = visitor.Clock.Now()
+	}
+
+	table, err := visitor.makeTable()
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, "NamespaceTreeJoin failed")
+		return fail
+	}
+
+	path, err := visitor.Namespace.JoinTable(visitor.tableKey, table)
 
 	if err != nil {
 		fail.Err = errors.Wrap(err, "NamespaceTreeJoin failed")
@@ -55,7 +115,7 @@ func (visitor *NamespaceTreeJoin) RunQuery() api.Response {
 }
 
 func (visitor *NamespaceTreeJoin) VisitPublicKeyHash(hash crypto.PublicKeyHash) {
-	priv, matchErr := visitor.keyStore.GetPrivateKey(hash)
+	priv, matchErr := visitor.KeyStore.GetPrivateKey(hash)
 
 	if matchErr != nil {
 		log.Warn("Private key lookup failed with: %s", matchErr.Error())
@@ -79,9 +139,26 @@ func (visitor *NamespaceTreeJoin) VisitTableKey(tableKey crdt.TableName) {
 	}
 
 	visitor.tableKey = tableKey
+
+	for _, lwwTable := range visitor.LWWTables {
+		if lwwTable == tableKey {
+			visitor.lww = true
+		}
+	}
 }
 
-func (visitor *NamespaceTreeJoin) VisitJoin(*query.QueryJoin) {
+func (visitor *NamespaceTreeJoin) VisitJoin(join *query.QueryJoin) {
+	if visitor.Error() != nil {
+		return
+	}
+
+	if join.LWW {
+		visitor.lww = true
+	}
+
+	if visitor.lww && visitor.Clock == nil {
+		visitor.CollectError(errors.New("No clock for last-writer-wins join"))
+	}
 }
 
 func (visitor *NamespaceTreeJoin) LeaveJoin(*query.QueryJoin) {
@@ -92,29 +169,150 @@ func (visitor *NamespaceTreeJoin) VisitRowJoin(position int, rowJoin *query.Quer
 		return
 	}
 
-	row := crdt.Row{}
+	if len(rowJoin.Counters) > 0 && visitor.Clock == nil {
+		visitor.CollectError(errors.New("No clock for counter join"))
+		return
+	}
+
+	visitor.rows = append(visitor.rows, *rowJoin)
+}
+
+func (visitor *NamespaceTreeJoin) hasCounters() bool {
+	for _, rowJoin := range visitor.rows {
+		if len(rowJoin.Counters) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadRows reads the rows to be written.  The clock observes them, so that a
+// last-writer-wins join is newer than what it overwrites, and the counters
+// in them are kept to be added to.
+func (visitor *NamespaceTreeJoin) loadRows() error {
+	rowKeys := make([]crdt.RowName, len(visitor.rows))
+
+	for i, rowJoin := range visitor.rows {
+		rowKeys[i] = rowJoin.RowKey
+	}
+
+	searcher := api.SignedTableSearcher{
+		Reader: api.SearchResultLambda(visitor.readRows),
+		Tables: []crdt.TableName{visitor.tableKey},
+		Rows:   rowKeys,
+	}
+
+	err := visitor.Namespace.LoadTraverse(searcher)
+
+	if err != nil {
+		return err
+	}
+
+	if visitor.loadFailed {
+		return errors.New("Failed to load rows")
+	}
+
+	return nil
+}
+
+func (visitor *NamespaceTreeJoin) readRows(result api.SearchResult) api.TraversalUpdate {
+	if result.IndexLoadFailure || result.NamespaceLoadFailure {
+		visitor.loadFailed = true
+		return api.TraversalUpdate{More: !result.IndexLoadFailure}
+	}
+
+	visitor.Clock.Observe(result.Namespace.LatestTimestamp())
+
+	table, err := result.Namespace.GetTable(visitor.tableKey)
+
+	if err != nil {
+		return api.TraversalUpdate{More: true}
+	}
+
+	for _, rowJoin := range visitor.rows {
+		row, err := table.GetRow(rowJoin.RowKey)
+
+		if err != nil {
+			continue
+		}
+
+		for entryName := range rowJoin.Counters {
+			entry, err := row.GetEntry(entryName)
+
+			if err != nil {
+				continue
+			}
+
+			path := counterPath{row: rowJoin.RowKey, entry: entryName}
+			visitor.counters[path] = visitor.counters[path].JoinCounter(entry.Counter)
+		}
+	}
+
+	return api.TraversalUpdate{More: true}
+}
+
+func (visitor *NamespaceTreeJoin) makeTable() (crdt.Table, error) {
+	rows := map[crdt.RowName]crdt.Row{}
+
+	for _, rowJoin := range visitor.rows {
+		row, err := visitor.makeRow(rowJoin)
+
+		if err != nil {
+			return crdt.EmptyTable(), err
+		}
+
+		if existing, present := rows[rowJoin.RowKey]; present {
+			row = existing.JoinRow(row)
+		}
+
+		rows[rowJoin.RowKey] = row
+	}
+
+	return crdt.MakeTable(rows), nil
+}
+
+func (visitor *NamespaceTreeJoin) makeRow(rowJoin query.QueryRowJoin) (crdt.Row, error) {
+	row := crdt.EmptyRow()
 
 	for k, entryValue := range rowJoin.Entries {
 		point, err := visitor.makePoint(entryValue)
 
 		if err != nil {
-			visitor.badPrivateKey()
+			return crdt.EmptyRow(), errors.Wrap(err, "Failed to sign Point with bad private key")
 		}
 
 		entry := crdt.MakeEntry([]crdt.Point{point})
 		row = row.JoinEntry(k, entry)
 	}
 
-	joined := visitor.table.JoinRow(rowJoin.RowKey, row)
+	for k, delta := range rowJoin.Counters {
+		path := counterPath{row: rowJoin.RowKey, entry: k}
+		stored := visitor.counters[path]
+		shard, err := stored.GetShard(visitor.Clock.Node()).Add(delta)
+
+		if err != nil {
+			return crdt.EmptyRow(), errors.Wrapf(err, "Failed to add to counter '%s'", k)
+		}
+
+		shards := []crdt.CounterShard{shard}
+		visitor.counters[path] = stored.JoinCounter(crdt.MakeCounter(shards))
+		row = row.JoinEntry(k, crdt.MakeCounterEntry(shards))
+	}
 
-	visitor.table = joined
+	return row, nil
 }
 
 func (visitor *NamespaceTreeJoin) makePoint(text crdt.PointText) (crdt.Point, error) {
-	return crdt.SignedPoint(text, visitor.privateKeys)
-}
+	point, err := crdt.SignedPoint(text, visitor.privateKeys)
+
+	if err != nil {
+		return point, err
+	}
+
+	if visitor.lww {
+		point = point.Stamp(visitor.timestamp)
+	}
 
-func (visitor *NamespaceTreeJoin) badPrivateKey() {
-	err := errors.New("Failed to sign Point with bad private key")
-	visitor.CollectError(err)
+	return point.Tagged(visitor.timestamp), nil
 }
@@ -2,6 +2,7 @@ package eval
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/crdt"
@@ -15,18 +16,25 @@ import (
 type NamespaceTreeSelect struct {
 	SelectOptions
 	query.NoJoinVisitor
+	query.NoDeleteVisitor
 	query.NoDebugVisitor
 	query.ErrorCollectVisitor
 	crit               *rowCriteria
 	keys               []crypto.PublicKey
+	joined             crdt.Namespace
 	namespaceLoadError bool
 	indexLoadError     bool
+	explain            bool
+	indexPath          crdt.IPFSPath
+	recorder           *planRecorder
 }
 
 type SelectOptions struct {
 	Namespace api.RemoteNamespace
 	KeyStore  api.KeyStore
 	Functions function.FunctionNamespace
+	// Clock is optional.  If set, it observes the timestamps of selected data.
+	Clock *crdt.HybridClock
 }
 
 func MakeNamespaceTreeSelect(options SelectOptions) *NamespaceTreeSelect {
@@ -36,7 +44,9 @@ func MakeNamespaceTreeSelect(options SelectOptions) *NamespaceTreeSelect {
 			result:    []crdt.NamespaceStreamEntry{},
 			functions: options.Functions,
 		},
-		keys: []crypto.PublicKey{},
+		keys:     []crypto.PublicKey{},
+		joined:   crdt.EmptyNamespace(),
+		recorder: &planRecorder{},
 	}
 }
 
@@ -58,11 +68,30 @@ func (visitor *NamespaceTreeSelect) RunQuery() api.Response {
 
 	log.Info("Searching namespaces...")
 
-	searcher := api.SignedTableSearcher{
-		Reader: api.SearchResultLambda(visitor.ReadSearchResult),
-		Tables: []crdt.TableName{visitor.crit.tableKey},
+	tables := visitor.crit.tableKeys()
+	var rows []crdt.RowName
+
+	if visitor.crit.tableJoin.IsEmpty() {
+		rows = keyRows(*visitor.crit.rootWhere)
 	}
-	searchErr := visitor.Namespace.LoadTraverse(searcher)
+
+	searcher := planSearcher{
+		NamespaceSearcher: api.SignedTableSearcher{
+			Reader: api.SearchResultLambda(visitor.ReadSearchResult),
+			Tables: tables,
+			Rows:   rows,
+		},
+		recorder: visitor.recorder,
+	}
+
+	visitor.recorder.startPhase()
+	var searchErr error
+	if crdt.IsNilPath(visitor.indexPath) {
+		searchErr = visitor.Namespace.LoadTraverse(searcher)
+	} else {
+		searchErr = visitor.Namespace.LoadTraverseIndex(visitor.indexPath, searcher)
+	}
+	visitor.recorder.endPhase("search")
 
 	if searchErr != nil {
 		fail.Err = errors.Wrap(searchErr, failMsg)
@@ -76,19 +105,49 @@ func (visitor *NamespaceTreeSelect) RunQuery() api.Response {
 
 	log.Info("Search complete")
 
-	response := api.RESPONSE_QUERY
+	visitor.recorder.startPhase()
 
-	namespace := visitor.getSelectResults()
+	if visitor.Clock != nil {
+		visitor.Clock.Observe(visitor.joined.LatestTimestamp())
+	}
+
+	visible := visitor.joined.ApplyTombstones()
+
+	if !visitor.crit.tableJoin.IsEmpty() {
+		visible = visitor.crit.combineTables(visible)
+	}
+
+	visitor.recorder.endPhase("merge")
+
+	visitor.recorder.startPhase()
+	visitor.crit.selectMatching(visible)
+	visitor.recorder.endPhase("evaluate")
+
+	response := api.RESPONSE_QUERY
 
 	if visitor.namespaceLoadError {
 		response.Msg = "ok with load errors"
 	}
 
-	response.Namespace = namespace
+	if visitor.explain {
+		response.Plan = visitor.recorder.plan
+		response.Plan.RowsEvaluated = uint32(visitor.crit.evaluated)
+		return response
+	}
+
+	if visitor.crit.isAggregate() {
+		response.Table = visitor.crit.table
+		return response
+	}
+
+	response.Namespace = visitor.getSelectResults()
+	response.RowOrder = visitor.crit.order
 	return response
 }
 
 func (visitor *NamespaceTreeSelect) ReadSearchResult(result api.SearchResult) api.TraversalUpdate {
+	visitor.recorder.recordLoad(result)
+
 	if result.NamespaceLoadFailure {
 		visitor.namespaceLoadError = true
 		return api.TraversalUpdate{More: true}
@@ -99,9 +158,19 @@ func (visitor *NamespaceTreeSelect) ReadSearchResult(result api.SearchResult) ap
 		return api.TraversalUpdate{}
 	}
 
+	// Tombstones may be stored apart from the points they remove, so we join
+	// everything before selecting.
 	verified := visitor.filterVerified(result.Namespace)
 
-	return visitor.crit.selectMatching(verified)
+	for _, tableKey := range visitor.crit.tableKeys() {
+		table, err := verified.GetTable(tableKey)
+
+		if err == nil {
+			visitor.joined.AddTable(tableKey, table)
+		}
+	}
+
+	return api.TraversalUpdate{More: true}
 }
 
 func (visitor *NamespaceTreeSelect) getSelectResults() crdt.Namespace {
@@ -176,6 +245,14 @@ func (visitor *NamespaceTreeSelect) VisitSelect(qselect *query.QuerySelect) {
 	}
 
 	visitor.crit.limit = int(qselect.Limit)
+	visitor.crit.offset = int(qselect.Offset)
+	visitor.crit.fields = qselect.Fields
+	visitor.crit.orderBy = qselect.OrderBy
+	visitor.crit.aggregates = qselect.Aggregates
+	visitor.crit.groupBy = qselect.GroupBy
+	visitor.crit.tableJoin = qselect.TableJoin
+	visitor.explain = qselect.Explain
+	visitor.indexPath = qselect.IndexPath
 
 	visitor.crit.rootWhere = &qselect.Where
 }
@@ -193,58 +270,82 @@ func (visitor *NamespaceTreeSelect) VisitPredicate(predicate *query.QueryPredica
 }
 
 type rowCriteria struct {
-	functions function.FunctionNamespace
-	tableKey  crdt.TableName
-	count     int
-	limit     int
-	result    []crdt.NamespaceStreamEntry
-	rootWhere *query.QueryWhere
-}
+	functions  function.FunctionNamespace
+	tableKey   crdt.TableName
+	limit      int
+	offset     int
+	fields     []crdt.EntryName
+	orderBy    query.QueryOrderBy
+	aggregates []query.QueryAggregate
+	groupBy    crdt.EntryName
+	tableJoin  query.QueryTableJoin
+	result     []crdt.NamespaceStreamEntry
+	order      []crdt.RowName
+	table      api.ResultTable
+	rootWhere  *query.QueryWhere
+	// evaluated counts the rows tested against the where clause.
+	evaluated int
+}
+
+type selectedRow struct {
+	rowKey crdt.RowName
+	row    crdt.Row
+}
+
+func (crit *rowCriteria) selectMatching(namespace crdt.Namespace) {
+	rows := crit.findRows(namespace)
+	crit.sortRows(rows)
 
-func (crit *rowCriteria) selectMatching(namespace crdt.Namespace) api.TraversalUpdate {
-	if crit.limit > 0 {
-		return crit.selectToLimit(namespace)
+	if crit.isAggregate() {
+		crit.table = crit.aggregate(rows)
+		return
 	}
 
-	rows := crit.findRows(namespace)
-	crit.appendResult(rows)
-	return api.TraversalUpdate{More: true}
-}
+	start, end := crit.pageBounds(len(rows))
+	rows = rows[start:end]
 
-func (crit *rowCriteria) selectToLimit(namespace crdt.Namespace) api.TraversalUpdate {
-	remaining := crit.limit - crit.count
+	invalidEntries := []crdt.InvalidNamespaceEntry{}
 
-	if remaining < 0 {
-		panic("Selected over limit")
-	}
+	for _, selected := range rows {
+		stream, invalid := crdt.MakeRowStream(crit.tableKey, selected.rowKey, crit.projectRow(selected.row))
 
-	if remaining <= 0 {
-		return api.TraversalUpdate{}
+		if len(stream) == 0 {
+			continue
+		}
+
+		crit.result = append(crit.result, stream...)
+		crit.order = append(crit.order, selected.rowKey)
+		invalidEntries = append(invalidEntries, invalid...)
 	}
 
-	rows := crit.findRows(namespace)
+	crit.logInvalid(invalidEntries)
+}
 
-	var slurp int
-	if len(rows) <= remaining {
-		slurp = len(rows)
-	} else {
-		slurp = remaining
-	}
+func (crit *rowCriteria) tableKeys() []crdt.TableName {
+	tables := []crdt.TableName{crit.tableKey}
 
-	crit.appendResult(rows[:slurp])
+	if !crit.tableJoin.IsEmpty() {
+		tables = append(tables, crit.tableJoin.TableKey)
+	}
 
-	// logdbg("Found %v more results. Total: %v.  Limit: %v.", slurp, crit.count, crit.limit)
-	return api.TraversalUpdate{More: true}
+	return tables
 }
 
-func (crit *rowCriteria) appendResult(stream []crdt.NamespaceStreamEntry) {
-	crit.result = append(crit.result, stream...)
-	crit.count = crit.count + len(stream)
+func (crit *rowCriteria) pageBounds(count int) (int, int) {
+	if crit.offset >= count {
+		return count, count
+	}
+
+	end := count
+	if crit.limit > 0 && crit.offset+crit.limit < count {
+		end = crit.offset + crit.limit
+	}
+
+	return crit.offset, end
 }
 
-func (crit *rowCriteria) findRows(namespace crdt.Namespace) []crdt.NamespaceStreamEntry {
-	out := []crdt.NamespaceStreamEntry{}
-	invalidEntries := []crdt.InvalidNamespaceEntry{}
+func (crit *rowCriteria) findRows(namespace crdt.Namespace) []selectedRow {
+	out := []selectedRow{}
 
 	table, err := namespace.GetTable(crit.tableKey)
 
@@ -252,26 +353,127 @@ func (crit *rowCriteria) findRows(namespace crdt.Namespace) []crdt.NamespaceStre
 		return out
 	}
 
+	table.ForeachRow(func(rowKey crdt.RowName, r crdt.Row) {
+		crit.evaluated++
+
+		if crit.matches(rowKey, r) {
+			out = append(out, selectedRow{rowKey: rowKey, row: r})
+		}
+	})
+
+	return out
+}
+
+func (crit *rowCriteria) matches(rowKey crdt.RowName, r crdt.Row) bool {
 	if crit.rootWhere.OpCode == query.WHERE_NOOP {
-		stream, invalid := crdt.MakeTableStream(crit.tableKey, table)
-		crit.logInvalid(invalid)
-		return stream
+		return true
 	}
 
-	table.ForeachRow(func(rowKey crdt.RowName, r crdt.Row) {
-		eval := makeSelectEvalTree(rowKey, r, crit.functions)
-		where := query.MakeWhereStack(crit.rootWhere)
+	eval := makeSelectEvalTree(rowKey, r, crit.functions)
+	where := query.MakeWhereStack(crit.rootWhere)
 
-		if eval.evaluate(where) {
-			stream, invalid := crdt.MakeRowStream(crit.tableKey, rowKey, r)
-			out = append(out, stream...)
-			invalidEntries = append(invalidEntries, invalid...)
+	return eval.evaluate(where)
+}
+
+// sortRows puts rows in a deterministic order, so that a page of results is
+// the same on every peer holding the same index.  Rows missing the order
+// entry come last.  Ties are broken by row key.
+func (crit *rowCriteria) sortRows(rows []selectedRow) {
+	sortKeys := make(map[crdt.RowName]string, len(rows))
+	missing := map[crdt.RowName]bool{}
+
+	if !crit.orderBy.RowKey && crit.orderBy.Key != "" {
+		for _, selected := range rows {
+			text, ok := orderText(selected.row, crit.orderBy.Key)
+			sortKeys[selected.rowKey] = text
+			missing[selected.rowKey] = !ok
 		}
+	}
+
+	sort.Sort(byRowOrder{
+		rows:       rows,
+		sortKeys:   sortKeys,
+		missing:    missing,
+		descending: crit.orderBy.Descending,
 	})
+}
 
-	crit.logInvalid(invalidEntries)
+type byRowOrder struct {
+	rows       []selectedRow
+	sortKeys   map[crdt.RowName]string
+	missing    map[crdt.RowName]bool
+	descending bool
+}
 
-	return out
+func (order byRowOrder) Len() int {
+	return len(order.rows)
+}
+
+func (order byRowOrder) Swap(i, j int) {
+	order.rows[i], order.rows[j] = order.rows[j], order.rows[i]
+}
+
+func (order byRowOrder) Less(i, j int) bool {
+	iKey := order.rows[i].rowKey
+	jKey := order.rows[j].rowKey
+
+	if order.missing[iKey] != order.missing[jKey] {
+		return order.missing[jKey]
+	}
+
+	iText := order.sortKeys[iKey]
+	jText := order.sortKeys[jKey]
+
+	if iText != jText {
+		return (iText < jText) != order.descending
+	}
+
+	return (iKey < jKey) != order.descending
+}
+
+// orderText is the lowest value of the entry.
+func orderText(row crdt.Row, entryName crdt.EntryName) (string, bool) {
+	entry, err := row.GetEntry(entryName)
+
+	if err != nil {
+		return "", false
+	}
+
+	values := entry.GetValues()
+
+	if len(values) == 0 {
+		return "", false
+	}
+
+	lowest := string(values[0].Text())
+	for _, point := range values[1:] {
+		text := string(point.Text())
+		if text < lowest {
+			lowest = text
+		}
+	}
+
+	return lowest, true
+}
+
+// projectRow keeps only the selected fields.  The where clause is evaluated
+// against the whole row beforehand.
+func (crit *rowCriteria) projectRow(row crdt.Row) crdt.Row {
+	if len(crit.fields) == 0 {
+		return row
+	}
+
+	projected := crdt.EmptyRow()
+
+	for _, field := range crit.fields {
+		entry, err := row.GetEntry(field)
+
+		if err == nil {
+			projected = projected.JoinEntry(field, entry)
+		}
+	}
+
+	return projected
 }
 
 func (crit *rowCriteria) logInvalid(invalid []crdt.InvalidNamespaceEntry) {
@@ -299,6 +501,7 @@ type exprOpCode uint8
 const (
 	EXPR_AND = exprOpCode(iota)
 	EXPR_OR
+	EXPR_NOT
 	EXPR_TRUE
 	EXPR_FALSE
 )
@@ -347,6 +550,8 @@ func (eval *selectEvalTree) evalWhere(where *query.QueryWhere) *expr {
 		return &expr{state: EXPR_AND, source: where}
 	case query.OR:
 		return &expr{state: EXPR_OR, source: where}
+	case query.NOT:
+		return &expr{state: EXPR_NOT, source: where}
 	case query.PREDICATE:
 		return eval.evalPred(where)
 	default:
@@ -368,6 +573,14 @@ func (eval *selectEvalTree) evalPred(where *query.QueryWhere) *expr {
 		literals = append(literals, string(eval.rowKey))
 	}
 
+	matcher := eval.findMatchFunction(pred)
+	presence, isPresence := matcher.(function.PresenceFunction)
+	allowMissing := isPresence && presence.AllowMissing()
+
+	if ordered, ok := matcher.(function.OrderedMatchFunction); ok {
+		return eval.evalOrderedPred(where, ordered)
+	}
+
 	entries := []crdt.Entry{}
 
 	for _, key := range pred.Keys() {
@@ -376,6 +589,8 @@ func (eval *selectEvalTree) evalPred(where *query.QueryWhere) *expr {
 		if err == nil {
 			// logdbg("entry %v: %v", key, more)
 			entries = append(entries, more)
+		} else if allowMissing {
+			entries = append(entries, crdt.EmptyEntry())
 		} else {
 			// No key = no match.
 			// logdbg("no key = no match for pred %v", pred)
@@ -383,8 +598,7 @@ func (eval *selectEvalTree) evalPred(where *query.QueryWhere) *expr {
 		}
 	}
 
-	function := eval.findMatchFunction(pred)
-	isMatch := function.Match(literals, entries)
+	isMatch := matcher.Match(literals, entries)
 
 	if isMatch {
 		return &expr{source: where, state: EXPR_TRUE}
@@ -393,6 +607,41 @@ func (eval *selectEvalTree) evalPred(where *query.QueryWhere) *expr {
 	return &expr{source: where, state: EXPR_FALSE}
 }
 
+func (eval *selectEvalTree) evalOrderedPred(where *query.QueryWhere, matcher function.OrderedMatchFunction) *expr {
+	pred := where.Predicate
+	arguments := [][]string{}
+
+	if pred.IncludeRowKey {
+		arguments = append(arguments, []string{string(eval.rowKey)})
+	}
+
+	for _, val := range pred.Values {
+		if !val.IsKey {
+			arguments = append(arguments, []string{string(val.Literal)})
+			continue
+		}
+
+		entry, err := eval.row.GetEntry(val.Key)
+
+		if err != nil {
+			return &expr{source: where, state: EXPR_FALSE}
+		}
+
+		values := []string{}
+		for _, point := range entry.GetValues() {
+			values = append(values, string(point.Text()))
+		}
+
+		arguments = append(arguments, values)
+	}
+
+	if matcher.MatchArguments(arguments) {
+		return &expr{source: where, state: EXPR_TRUE}
+	}
+
+	return &expr{source: where, state: EXPR_FALSE}
+}
+
 func (eval *selectEvalTree) findMatchFunction(pred query.QueryPredicate) function.MatchFunction {
 	functionName := pred.FunctionName
 
@@ -465,6 +714,19 @@ func (eval *selectEvalTree) LeaveWhere(where *query.QueryWhere) {
 			}
 		}
 		head.state = EXPR_FALSE
+	case EXPR_NOT:
+		if len(head.children) != 1 {
+			panic(fmt.Sprintf("not expr requires one child: %v", head))
+		}
+
+		switch child := head.children[0]; child.state {
+		case EXPR_TRUE:
+			head.state = EXPR_FALSE
+		case EXPR_FALSE:
+			head.state = EXPR_TRUE
+		default:
+			panic(fmt.Sprintf("Unevaluated expr: %v", child))
+		}
 	case EXPR_TRUE:
 	case EXPR_FALSE:
 		// Do nothing
@@ -4,20 +4,16 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/crypto"
 	"github.com/johnny-morrice/godless/function"
 	"github.com/johnny-morrice/godless/query"
 )
 
-// TODO implement test.
-// func TestRowCriteria_selectMatching(t *testing.T) {
-// 	t.Fail()
-// }
-
 // The various where predicates are tested elsewhere.  This test focusses
 // on whether the correct rows will be discovered for any predicate.
-func TestRowCriteria_findRows(t *testing.T) {
+func TestRowCriteria_selectMatching(t *testing.T) {
 	pointA := crdt.UnsignedPoint("hello")
 	pointB := crdt.UnsignedPoint("world")
 
@@ -96,9 +92,8 @@ func TestRowCriteria_findRows(t *testing.T) {
 			functions: function.StandardFunctions(),
 		}
 
-		actual := rc.findRows(namespace)
-
-		crdt.SortNamespaceStream(actual)
+		rc.selectMatching(namespace)
+		actual := rc.result
 
 		if !reflect.DeepEqual(e, actual) {
 			t.Error(i, "Expected", e, "but was", actual)
@@ -106,6 +101,237 @@ func TestRowCriteria_findRows(t *testing.T) {
 	}
 }
 
+func TestRowCriteria_notPresence(t *testing.T) {
+	namespace := crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		TABLE_KEY: crdt.MakeTable(map[crdt.RowName]crdt.Row{
+			"a": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+				"foo": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("hello")}),
+			}),
+			"b": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+				"foo": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("world")}),
+			}),
+			"c": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+				"bar": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("hello")}),
+			}),
+		}),
+	})
+
+	strEq := query.QueryWhere{
+		OpCode: query.PREDICATE,
+		Predicate: query.QueryPredicate{
+			FunctionName: "str_eq",
+			Values:       []query.PredicateValue{query.PredicateKey("foo"), query.PredicateLiteral("hello")},
+		},
+	}
+
+	presence := func(functionName string) query.QueryWhere {
+		return query.QueryWhere{
+			OpCode: query.PREDICATE,
+			Predicate: query.QueryPredicate{
+				FunctionName: functionName,
+				Values:       []query.PredicateValue{query.PredicateKey("foo")},
+			},
+		}
+	}
+
+	where := []query.QueryWhere{
+		query.QueryWhere{OpCode: query.NOT, Clauses: []query.QueryWhere{strEq}},
+		presence("exists"),
+		presence("missing"),
+		query.QueryWhere{OpCode: query.NOT, Clauses: []query.QueryWhere{presence("missing")}},
+	}
+
+	expected := [][]crdt.RowName{
+		[]crdt.RowName{"b", "c"},
+		[]crdt.RowName{"a", "b"},
+		[]crdt.RowName{"c"},
+		[]crdt.RowName{"a", "b"},
+	}
+
+	for i, e := range expected {
+		w := where[i]
+		rc := &rowCriteria{
+			tableKey:  TABLE_KEY,
+			rootWhere: &w,
+			functions: function.StandardFunctions(),
+		}
+
+		rc.selectMatching(namespace)
+
+		if !reflect.DeepEqual(e, rc.order) {
+			t.Error(i, "Expected", e, "but was", rc.order)
+		}
+	}
+}
+
+func TestRowCriteria_comparison(t *testing.T) {
+	mkrow := func(text crdt.PointText) crdt.Row {
+		return crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"price": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(text)}),
+		})
+	}
+
+	namespace := crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		TABLE_KEY: crdt.MakeTable(map[crdt.RowName]crdt.Row{
+			"5":  mkrow("12"),
+			"10": mkrow("9"),
+			"15": mkrow("cheap"),
+		}),
+	})
+
+	mkwhere := func(functionName string, includeRowKey bool, values ...query.PredicateValue) query.QueryWhere {
+		return query.QueryWhere{
+			OpCode: query.PREDICATE,
+			Predicate: query.QueryPredicate{
+				FunctionName:  functionName,
+				IncludeRowKey: includeRowKey,
+				Values:        values,
+			},
+		}
+	}
+
+	where := []query.QueryWhere{
+		mkwhere("num_lt", false, query.PredicateKey("price"), query.PredicateLiteral("10")),
+		mkwhere("num_lt", false, query.PredicateLiteral("10"), query.PredicateKey("price")),
+		mkwhere("num_gt", true, query.PredicateKey("price")),
+		mkwhere("num_between", true, query.PredicateLiteral("5"), query.PredicateLiteral("12")),
+	}
+
+	expected := [][]crdt.RowName{
+		[]crdt.RowName{"10"},
+		[]crdt.RowName{"5"},
+		[]crdt.RowName{"10"},
+		[]crdt.RowName{"10", "5"},
+	}
+
+	for i, e := range expected {
+		w := where[i]
+		rc := &rowCriteria{
+			tableKey:  TABLE_KEY,
+			rootWhere: &w,
+			functions: function.StandardFunctions(),
+		}
+
+		rc.selectMatching(namespace)
+
+		if !reflect.DeepEqual(e, rc.order) {
+			t.Error(i, "Expected", e, "but was", rc.order)
+		}
+	}
+}
+
+func TestRowCriteria_order(t *testing.T) {
+	mkrow := func(text crdt.PointText) crdt.Row {
+		return crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"foo": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(text)}),
+		})
+	}
+
+	namespace := crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		TABLE_KEY: crdt.MakeTable(map[crdt.RowName]crdt.Row{
+			"a": mkrow("3"),
+			"b": mkrow("1"),
+			"c": mkrow("2"),
+			"d": crdt.EmptyRow(),
+			"e": mkrow("1"),
+		}),
+	})
+
+	criteria := []*rowCriteria{
+		&rowCriteria{},
+		&rowCriteria{orderBy: query.QueryOrderBy{RowKey: true, Descending: true}},
+		&rowCriteria{orderBy: query.QueryOrderBy{Key: "foo"}},
+		&rowCriteria{orderBy: query.QueryOrderBy{Key: "foo", Descending: true}},
+		&rowCriteria{orderBy: query.QueryOrderBy{Key: "foo"}, limit: 2, offset: 1},
+		&rowCriteria{offset: 5},
+	}
+
+	expected := [][]crdt.RowName{
+		[]crdt.RowName{"a", "b", "c", "e"},
+		[]crdt.RowName{"e", "c", "b", "a"},
+		[]crdt.RowName{"b", "e", "c", "a"},
+		[]crdt.RowName{"a", "c", "e", "b"},
+		[]crdt.RowName{"e", "c"},
+		nil,
+	}
+
+	for i, rc := range criteria {
+		rc.tableKey = TABLE_KEY
+		rc.rootWhere = &query.QueryWhere{}
+		rc.functions = function.StandardFunctions()
+
+		rc.selectMatching(namespace)
+
+		if !reflect.DeepEqual(expected[i], rc.order) {
+			t.Error(i, "Expected", expected[i], "but was", rc.order)
+		}
+	}
+}
+
+func TestRowCriteria_aggregate(t *testing.T) {
+	mkrow := func(publisher, price crdt.PointText) crdt.Row {
+		return crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"publisher": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(publisher)}),
+			"price":     crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(price)}),
+		})
+	}
+
+	namespace := crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		TABLE_KEY: crdt.MakeTable(map[crdt.RowName]crdt.Row{
+			"a": mkrow("Penguin", "9"),
+			"b": mkrow("Faber", "12"),
+			"c": mkrow("Penguin", "10"),
+			"d": crdt.EmptyRow(),
+		}),
+	})
+
+	count := query.QueryAggregate{Function: query.COUNT}
+	distinct := query.QueryAggregate{Function: query.DISTINCT, Key: "publisher"}
+	min := query.QueryAggregate{Function: query.MIN, Key: "price"}
+	max := query.QueryAggregate{Function: query.MAX, Key: "price"}
+
+	criteria := []*rowCriteria{
+		&rowCriteria{aggregates: []query.QueryAggregate{count}},
+		&rowCriteria{aggregates: []query.QueryAggregate{distinct}},
+		&rowCriteria{aggregates: []query.QueryAggregate{count, min, max}, groupBy: "publisher"},
+		&rowCriteria{aggregates: []query.QueryAggregate{distinct, count}, limit: 1, offset: 1},
+	}
+
+	expected := []api.ResultTable{
+		api.ResultTable{
+			Columns: []string{"count"},
+			Rows:    [][]string{[]string{"4"}},
+		},
+		api.ResultTable{
+			Columns: []string{"publisher"},
+			Rows:    [][]string{[]string{"Faber"}, []string{"Penguin"}},
+		},
+		api.ResultTable{
+			Columns: []string{"publisher", "count", "min(price)", "max(price)"},
+			Rows: [][]string{
+				[]string{"Faber", "1", "12", "12"},
+				[]string{"Penguin", "2", "9", "10"},
+			},
+		},
+		api.ResultTable{
+			Columns: []string{"publisher", "count"},
+			Rows:    [][]string{[]string{"Penguin", "2"}},
+		},
+	}
+
+	for i, rc := range criteria {
+		rc.tableKey = TABLE_KEY
+		rc.rootWhere = &query.QueryWhere{}
+		rc.functions = function.StandardFunctions()
+
+		rc.selectMatching(namespace)
+
+		if !expected[i].Equals(rc.table) {
+			t.Error(i, "Expected", expected[i], "but was", rc.table)
+		}
+	}
+}
+
 func TestRowCriteria_isReady(t *testing.T) {
 	bad := []*rowCriteria{
 		&rowCriteria{},
@@ -129,6 +355,33 @@ func TestRowCriteria_isReady(t *testing.T) {
 	}
 }
 
+func TestKeyRows(t *testing.T) {
+	cases := []struct {
+		source   string
+		expected []crdt.RowName
+	}{
+		{"select cars", nil},
+		{`select cars where str_eq(@key, "car1")`, []crdt.RowName{"car1"}},
+		{`select cars where str_eq(driver, "Mr Fast")`, nil},
+		{`select cars where and(str_eq(@key, "car1"), str_eq(driver, "Mr Fast"))`, []crdt.RowName{"car1"}},
+		{`select cars where or(str_eq(@key, "car1"), str_eq(@key, "car2"))`, []crdt.RowName{"car1", "car2"}},
+		{`select cars where or(str_eq(@key, "car1"), str_eq(driver, "Mr Fast"))`, nil},
+		{`select cars where and(str_eq(@key, "car1"), str_eq(@key, "car2"))`, []crdt.RowName{}},
+		{`select cars where not(str_eq(@key, "car1"))`, nil},
+	}
+
+	for i, c := range cases {
+		q, err := query.Compile(c.source)
+		setupPanic(err)
+
+		actual := keyRows(q.Select.Where)
+
+		if !reflect.DeepEqual(c.expected, actual) {
+			t.Error("Unexpected rows at", i, ":", actual)
+		}
+	}
+}
+
 func makeStreamPoint(text crdt.PointText, sig crypto.Signature) crdt.StreamPoint {
 	streamPoint, err := crdt.MakeStreamPoint(text, sig)
 	setupPanic(err)
@@ -0,0 +1,161 @@
+package eval
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/query"
+)
+
+// aggregate summarises the selected rows.  When grouping, a row joins a
+// group for each value of the group entry, and rows without the entry are
+// left out.  Groups are ordered by value, and offset and limit page through
+// the groups.
+func (crit *rowCriteria) aggregate(rows []selectedRow) api.ResultTable {
+	groupKey := crit.groupKey()
+	table := api.ResultTable{}
+
+	if groupKey != "" {
+		table.Columns = append(table.Columns, string(groupKey))
+	}
+
+	for _, aggregate := range crit.aggregates {
+		if aggregate.Function != query.DISTINCT {
+			table.Columns = append(table.Columns, aggregate.Column())
+		}
+	}
+
+	if groupKey == "" {
+		table.Rows = [][]string{crit.aggregateRow(rows)}
+		return table
+	}
+
+	groups := map[string][]selectedRow{}
+
+	for _, selected := range rows {
+		entry, err := selected.row.GetEntry(groupKey)
+
+		if err != nil {
+			continue
+		}
+
+		for _, point := range entry.GetValues() {
+			text := string(point.Text())
+			groups[text] = append(groups[text], selected)
+		}
+	}
+
+	groupValues := make([]string, 0, len(groups))
+	for value := range groups {
+		groupValues = append(groupValues, value)
+	}
+
+	sort.Strings(groupValues)
+
+	start, end := crit.pageBounds(len(groupValues))
+
+	for _, value := range groupValues[start:end] {
+		row := append([]string{value}, crit.aggregateRow(groups[value])...)
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+func (crit *rowCriteria) isAggregate() bool {
+	return len(crit.aggregates) > 0 || crit.groupBy != ""
+}
+
+// groupKey is the group by entry, or the distinct entry if there is no group by.
+func (crit *rowCriteria) groupKey() crdt.EntryName {
+	if crit.groupBy != "" {
+		return crit.groupBy
+	}
+
+	for _, aggregate := range crit.aggregates {
+		if aggregate.Function == query.DISTINCT {
+			return aggregate.Key
+		}
+	}
+
+	return ""
+}
+
+func (crit *rowCriteria) aggregateRow(rows []selectedRow) []string {
+	values := []string{}
+
+	for _, aggregate := range crit.aggregates {
+		switch aggregate.Function {
+		case query.COUNT:
+			values = append(values, strconv.Itoa(len(rows)))
+		case query.MIN:
+			values = append(values, extremeValue(rows, aggregate.Key, false))
+		case query.MAX:
+			values = append(values, extremeValue(rows, aggregate.Key, true))
+		}
+	}
+
+	return values
+}
+
+// extremeValue finds the least or greatest value of an entry.  Values are
+// compared as numbers if they are all numeric, and as text otherwise.
+func extremeValue(rows []selectedRow, entryName crdt.EntryName, greatest bool) string {
+	texts := []string{}
+
+	for _, selected := range rows {
+		entry, err := selected.row.GetEntry(entryName)
+
+		if err != nil {
+			continue
+		}
+
+		for _, point := range entry.GetValues() {
+			texts = append(texts, string(point.Text()))
+		}
+	}
+
+	if len(texts) == 0 {
+		return ""
+	}
+
+	numbers, numeric := parseNumbers(texts)
+
+	extreme := 0
+	for i := 1; i < len(texts); i++ {
+		var better bool
+		if numeric && greatest {
+			better = numbers[i] > numbers[extreme]
+		} else if numeric {
+			better = numbers[i] < numbers[extreme]
+		} else if greatest {
+			better = texts[i] > texts[extreme]
+		} else {
+			better = texts[i] < texts[extreme]
+		}
+
+		if better {
+			extreme = i
+		}
+	}
+
+	return texts[extreme]
+}
+
+func parseNumbers(texts []string) ([]float64, bool) {
+	numbers := make([]float64, len(texts))
+
+	for i, text := range texts {
+		num, err := strconv.ParseFloat(text, 64)
+
+		if err != nil {
+			return nil, false
+		}
+
+		numbers[i] = num
+	}
+
+	return numbers, true
+}
@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"time"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+)
+
+// planRecorder notes what a select loaded and how long each phase took, so
+// that an explain query can report it.
+type planRecorder struct {
+	plan       api.QueryPlan
+	phaseStart time.Time
+}
+
+func (recorder *planRecorder) startPhase() {
+	recorder.phaseStart = time.Now()
+}
+
+func (recorder *planRecorder) endPhase(name string) {
+	phase := api.PlanPhase{
+		Name:     name,
+		Duration: time.Since(recorder.phaseStart),
+	}
+
+	recorder.plan.Phases = append(recorder.plan.Phases, phase)
+}
+
+func (recorder *planRecorder) recordLoad(result api.SearchResult) {
+	if result.IndexLoadFailure {
+		return
+	}
+
+	load := api.NamespaceLoad{
+		Path:   result.Path,
+		Source: result.Source,
+		Failed: result.NamespaceLoadFailure,
+	}
+
+	recorder.plan.Loads = append(recorder.plan.Loads, load)
+}
+
+// planSearcher records the index links chosen by its NamespaceSearcher.
+type planSearcher struct {
+	api.NamespaceSearcher
+	recorder *planRecorder
+}
+
+func (searcher planSearcher) Search(index crdt.Index) []crdt.Link {
+	links := searcher.NamespaceSearcher.Search(index)
+
+	for _, link := range links {
+		searcher.recorder.plan.IndexLinks = append(searcher.recorder.plan.IndexLinks, link.Path())
+	}
+
+	return links
+}
+
+func (searcher planSearcher) SearchRows() []crdt.RowName {
+	if rowSearch, ok := searcher.NamespaceSearcher.(api.RowSearch); ok {
+		return rowSearch.SearchRows()
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package eval
+
+import (
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/function"
+	"github.com/johnny-morrice/godless/query"
+)
+
+// keyRows are the only row keys that can match the where clause, found from
+// str_eq predicates on @key.  It is nil when any row might match.
+func keyRows(where query.QueryWhere) []crdt.RowName {
+	switch where.OpCode {
+	case query.PREDICATE:
+		return predicateKeyRows(where.Predicate)
+	case query.AND:
+		var rows []crdt.RowName
+
+		for _, clause := range where.Clauses {
+			clauseRows := keyRows(clause)
+
+			if clauseRows == nil {
+				continue
+			}
+
+			if rows == nil {
+				rows = clauseRows
+			} else {
+				rows = intersectRows(rows, clauseRows)
+			}
+		}
+
+		return rows
+	case query.OR:
+		if len(where.Clauses) == 0 {
+			return nil
+		}
+
+		rows := []crdt.RowName{}
+
+		for _, clause := range where.Clauses {
+			clauseRows := keyRows(clause)
+
+			if clauseRows == nil {
+				return nil
+			}
+
+			rows = append(rows, clauseRows...)
+		}
+
+		return rows
+	default:
+		return nil
+	}
+}
+
+func predicateKeyRows(pred query.QueryPredicate) []crdt.RowName {
+	if pred.FunctionName != (function.StrEq{}).FuncName() || !pred.IncludeRowKey {
+		return nil
+	}
+
+	literals := pred.Literals()
+
+	if len(literals) == 0 {
+		return nil
+	}
+
+	for _, lit := range literals[1:] {
+		if lit != literals[0] {
+			return []crdt.RowName{}
+		}
+	}
+
+	return []crdt.RowName{crdt.RowName(literals[0])}
+}
+
+func intersectRows(rows, other []crdt.RowName) []crdt.RowName {
+	both := []crdt.RowName{}
+
+	for _, r := range rows {
+		for _, o := range other {
+			if r == o {
+				both = append(both, r)
+				break
+			}
+		}
+	}
+
+	return both
+}
@@ -0,0 +1,121 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/query"
+)
+
+// combineTables joins the rows of the selected table to the rows of the
+// joined table with a matching column value.  The combined rows replace
+// the selected table, so that the rest of the query runs over them.
+func (crit *rowCriteria) combineTables(namespace crdt.Namespace) crdt.Namespace {
+	join := crit.tableJoin
+	mainColumn, joinColumn := join.Left, join.Right
+
+	if mainColumn.TableKey != crit.tableKey {
+		mainColumn, joinColumn = joinColumn, mainColumn
+	}
+
+	mainTable, mainErr := namespace.GetTable(crit.tableKey)
+	joinTable, joinErr := namespace.GetTable(join.TableKey)
+
+	if mainErr != nil || joinErr != nil {
+		return crdt.EmptyNamespace()
+	}
+
+	// Rows are collected before the table is made, so that it is not copied
+	// for each matched pair.
+	combined := map[crdt.RowName]crdt.Row{}
+
+	index := map[string][]crdt.RowName{}
+
+	joinTable.ForeachRow(func(rowKey crdt.RowName, row crdt.Row) {
+		for _, value := range columnValues(joinColumn, rowKey, row) {
+			index[value] = append(index[value], rowKey)
+		}
+	})
+
+	mainTable.ForeachRow(func(mainKey crdt.RowName, mainRow crdt.Row) {
+		matched := map[crdt.RowName]bool{}
+
+		for _, value := range columnValues(mainColumn, mainKey, mainRow) {
+			for _, joinKey := range index[value] {
+				if matched[joinKey] {
+					continue
+				}
+
+				matched[joinKey] = true
+
+				joinRow, err := joinTable.GetRow(joinKey)
+
+				if err != nil {
+					panic(err)
+				}
+
+				row := qualifyRow(crit.tableKey, mainKey, mainRow)
+				row = row.JoinRow(qualifyRow(join.TableKey, joinKey, joinRow))
+				rowKey := combinedRowName(mainKey, joinKey)
+
+				if other, present := combined[rowKey]; present {
+					row = row.JoinRow(other)
+				}
+
+				combined[rowKey] = row
+			}
+		}
+	})
+
+	return crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		crit.tableKey: crdt.MakeTable(combined),
+	})
+}
+
+func columnValues(column query.QueryColumn, rowKey crdt.RowName, row crdt.Row) []string {
+	if column.RowKey {
+		return []string{string(rowKey)}
+	}
+
+	entry, err := row.GetEntry(column.Entry)
+
+	if err != nil {
+		return nil
+	}
+
+	values := []string{}
+	for _, point := range entry.GetValues() {
+		values = append(values, string(point.Text()))
+	}
+
+	return values
+}
+
+// qualifyRow names each entry after its table, and records the row key
+// under "<table>.@key".
+func qualifyRow(tableKey crdt.TableName, rowKey crdt.RowName, row crdt.Row) crdt.Row {
+	qualified := make(map[crdt.EntryName]crdt.Entry, len(row.Entries)+1)
+
+	for entryName, entry := range row.Entries {
+		qualified[qualifiedEntryName(tableKey, string(entryName))] = entry
+	}
+
+	keyEntry := crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(crdt.PointText(rowKey))})
+	keyName := qualifiedEntryName(tableKey, "@key")
+
+	if entry, present := qualified[keyName]; present {
+		keyEntry = keyEntry.JoinEntry(entry)
+	}
+
+	qualified[keyName] = keyEntry
+
+	return crdt.MakeRow(qualified)
+}
+
+func qualifiedEntryName(tableKey crdt.TableName, entryName string) crdt.EntryName {
+	return crdt.EntryName(fmt.Sprintf("%s.%s", tableKey, entryName))
+}
+
+func combinedRowName(mainKey, joinKey crdt.RowName) crdt.RowName {
+	return crdt.RowName(fmt.Sprintf("%s/%s", mainKey, joinKey))
+}
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"sync"
@@ -15,17 +15,17 @@ import (
 )
 
 type namespaceRecord struct {
-	Namespace crdt.Namespace
+	Node crdt.NamespaceNode
 }
 
 func makeNamespaceRecord(namespace crdt.Namespace) *namespaceRecord {
 	return &namespaceRecord{
-		Namespace: namespace,
+		Node: crdt.LeafNamespaceNode(namespace),
 	}
 }
 
 func (record *namespaceRecord) encode(w io.Writer) error {
-	invalid, err := crdt.EncodeNamespace(record.Namespace, w)
+	invalid, err := crdt.EncodeNamespaceNodeStream(record.Node, w)
 
 	record.logInvalid(invalid)
 
@@ -33,7 +33,7 @@ func (record *namespaceRecord) encode(w io.Writer) error {
 }
 
 func (record *namespaceRecord) decode(r io.Reader) error {
-	ns, invalid, err := crdt.DecodeNamespace(r)
+	node, invalid, err := crdt.DecodeNamespaceNode(r)
 
 	record.logInvalid(invalid)
 
@@ -41,7 +41,7 @@ func (record *namespaceRecord) decode(r io.Reader) error {
 		return err
 	}
 
-	record.Namespace = ns
+	record.Node = node
 	return nil
 }
 
@@ -72,7 +72,7 @@ func makeIndexRecord(index crdt.Index) *indexRecord {
 }
 
 func (index *indexRecord) encode(w io.Writer) error {
-	invalid, err := crdt.EncodeIndex(index.Index, w)
+	invalid, err := crdt.EncodeIndexStream(index.Index, w)
 
 	index.logInvalid(invalid)
 
@@ -125,8 +125,12 @@ func (closer *ipfsCloser) closeIpfs() {
 }
 
 type ContentAddressableRemoteStore struct {
-	Shell  api.DataPeer
-	closer ipfsCloser
+	Shell api.DataPeer
+	// ShardRows is optional.  Namespaces with more rows are written as a
+	// sharded trie, so that selects by row key load only part of them.  Zero
+	// writes every namespace flat.
+	ShardRows int
+	closer    ipfsCloser
 }
 
 func MakeContentAddressableRemoteStore(peer api.DataPeer) api.RemoteStore {
@@ -320,9 +324,7 @@ func (peer *ContentAddressableRemoteStore) AddNamespace(namespace crdt.Namespace
 		return crdt.NIL_PATH, verr
 	}
 
-	chunk := makeNamespaceRecord(namespace)
-
-	path, err := peer.add(chunk)
+	path, err := peer.addNamespaceShard(namespace, 0)
 
 	if err != nil {
 		return crdt.NIL_PATH, errors.Wrap(err, "ContentAddressableRemoteStore.AddNamespace failed")
@@ -333,6 +335,32 @@ func (peer *ContentAddressableRemoteStore) AddNamespace(namespace crdt.Namespace
 	return path, nil
 }
 
+// addNamespaceShard writes a namespace with more than ShardRows rows as a
+// node, linking to a shard for each slot at depth.
+func (peer *ContentAddressableRemoteStore) addNamespaceShard(namespace crdt.Namespace, depth int) (crdt.IPFSPath, error) {
+	const failMsg = "ContentAddressableRemoteStore.addNamespaceShard failed"
+
+	isSmall := peer.ShardRows <= 0 || namespace.RowCount() <= peer.ShardRows
+
+	if isSmall || depth >= crdt.MAX_SHARD_DEPTH {
+		return peer.add(makeNamespaceRecord(namespace))
+	}
+
+	node := crdt.NamespaceNode{Namespace: crdt.EmptyNamespace()}
+
+	for slot, shard := range namespace.SplitShards(depth) {
+		path, err := peer.addNamespaceShard(shard, depth+1)
+
+		if err != nil {
+			return crdt.NIL_PATH, errors.Wrap(err, failMsg)
+		}
+
+		node.Shards = append(node.Shards, crdt.ShardLink{Slot: slot, Path: path})
+	}
+
+	return peer.add(&namespaceRecord{Node: node})
+}
+
 func (peer *ContentAddressableRemoteStore) CatNamespace(addr crdt.IPFSPath) (crdt.Namespace, error) {
 	log.Info("Catting namespace from IPFS at: %s ...", addr)
 
@@ -340,8 +368,7 @@ func (peer *ContentAddressableRemoteStore) CatNamespace(addr crdt.IPFSPath) (crd
 		return crdt.EmptyNamespace(), verr
 	}
 
-	chunk := &namespaceRecord{}
-	caterr := peer.cat(addr, chunk)
+	namespace, caterr := peer.catNamespaceShard(addr, nil, 0)
 
 	if caterr != nil {
 		return crdt.EmptyNamespace(), errors.Wrap(caterr, "ContentAddressableRemoteStore.CatNamespace failed")
@@ -349,24 +376,117 @@ func (peer *ContentAddressableRemoteStore) CatNamespace(addr crdt.IPFSPath) (crd
 
 	log.Info("Catted namespace")
 
-	return chunk.Namespace, nil
+	return namespace, nil
+}
+
+// CatNamespaceRows loads only the shards of a sharded namespace that may hold
+// the rows.  A flat namespace is loaded whole, so the result can hold other
+// rows too.
+func (peer *ContentAddressableRemoteStore) CatNamespaceRows(addr crdt.IPFSPath, rows []crdt.RowName) (crdt.Namespace, error) {
+	log.Info("Catting %d rows of namespace from IPFS at: %s ...", len(rows), addr)
+
+	if verr := peer.validateShell(); verr != nil {
+		return crdt.EmptyNamespace(), verr
+	}
+
+	if rows == nil {
+		rows = []crdt.RowName{}
+	}
+
+	namespace, caterr := peer.catNamespaceShard(addr, rows, 0)
+
+	if caterr != nil {
+		return crdt.EmptyNamespace(), errors.Wrap(caterr, "ContentAddressableRemoteStore.CatNamespaceRows failed")
+	}
+
+	log.Info("Catted namespace rows")
+
+	return namespace, nil
 }
 
+// catNamespaceShard loads the node at addr, and then the shards under it that
+// may hold the rows.  Every shard is loaded when rows is nil.
+func (peer *ContentAddressableRemoteStore) catNamespaceShard(addr crdt.IPFSPath, rows []crdt.RowName, depth int) (crdt.Namespace, error) {
+	const failMsg = "ContentAddressableRemoteStore.catNamespaceShard failed"
+
+	chunk := &namespaceRecord{}
+	caterr := peer.cat(addr, chunk)
+
+	if caterr != nil {
+		return crdt.EmptyNamespace(), errors.Wrap(caterr, failMsg)
+	}
+
+	node := chunk.Node
+
+	if node.IsLeaf() {
+		return node.Namespace, nil
+	}
+
+	if depth >= crdt.MAX_SHARD_DEPTH {
+		return crdt.EmptyNamespace(), fmt.Errorf("%s: shard too deep at: %s", failMsg, addr)
+	}
+
+	var groups map[uint32][]crdt.RowName
+
+	if rows != nil {
+		groups = crdt.GroupRowShards(rows, depth)
+	}
+
+	namespace := crdt.EmptyNamespace()
+
+	for _, shard := range node.Shards {
+		var shardRows []crdt.RowName
+
+		if rows != nil {
+			shardRows = groups[shard.Slot]
+
+			if len(shardRows) == 0 {
+				continue
+			}
+		}
+
+		part, err := peer.catNamespaceShard(shard.Path, shardRows, depth+1)
+
+		if err != nil {
+			return crdt.EmptyNamespace(), errors.Wrap(err, failMsg)
+		}
+
+		// Each shard holds different rows, and part is not shared, so it
+		// is joined in place rather than copying the namespace.
+		for t, table := range part.Tables {
+			namespace.AddTable(t, table)
+		}
+	}
+
+	return namespace, nil
+}
+
+// add streams the encoded chunk to the data peer as it is written, so the
+// encoding is not held in memory.
 func (peer *ContentAddressableRemoteStore) add(chunk encoder) (crdt.IPFSPath, error) {
 	const failMsg = "ContentAddressableRemoteStore.add failed"
-	buff := &bytes.Buffer{}
-	err := chunk.encode(buff)
 
-	if err != nil {
-		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
-	}
+	reader, writer := io.Pipe()
+	encodech := make(chan error, 1)
+
+	go func() {
+		err := chunk.encode(writer)
+		writer.CloseWithError(err)
+		encodech <- err
+	}()
 
-	path, err := peer.Shell.Add(buff)
+	path, err := peer.Shell.Add(reader)
+	reader.Close()
+	encodeErr := <-encodech
 
 	if err != nil {
 		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
 	}
 
+	if encodeErr != nil {
+		return crdt.NIL_PATH, errors.Wrap(encodeErr, failMsg)
+	}
+
 	return crdt.IPFSPath(path), nil
 }
 
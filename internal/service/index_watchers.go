@@ -0,0 +1,55 @@
+package service
+
+import "sync"
+
+// indexWatchers signals its watchers each time the index changes.  A watcher
+// that is slow to read sees several changes as one.
+type indexWatchers struct {
+	sync.Mutex
+	watchers []chan struct{}
+}
+
+func (watchers *indexWatchers) watch(stop, done <-chan struct{}) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+
+	watchers.Lock()
+	watchers.watchers = append(watchers.watchers, changes)
+	watchers.Unlock()
+
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+
+		watchers.remove(changes)
+	}()
+
+	return changes
+}
+
+func (watchers *indexWatchers) remove(changes chan struct{}) {
+	watchers.Lock()
+	defer watchers.Unlock()
+
+	for i, other := range watchers.watchers {
+		if other == changes {
+			watchers.watchers = append(watchers.watchers[:i], watchers.watchers[i+1:]...)
+			break
+		}
+	}
+
+	close(changes)
+}
+
+func (watchers *indexWatchers) notify() {
+	watchers.Lock()
+	defer watchers.Unlock()
+
+	for _, changes := range watchers.watchers {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
 
 	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/query"
 )
 
 type QueuedApiServiceOptions struct {
@@ -12,13 +17,16 @@ type QueuedApiServiceOptions struct {
 	Queue      api.RequestPriorityQueue
 	QueryLimit int
 	Validator  api.RequestValidator
+	// StatementLimit is the number of prepared statements held.
+	StatementLimit int
 }
 
 type queuedApiService struct {
 	QueuedApiServiceOptions
-	Debug     bool
-	semaphore chan struct{}
-	stopch    chan struct{}
+	Debug      bool
+	semaphore  chan struct{}
+	stopch     chan struct{}
+	statements *statementCache
 }
 
 func LaunchQueuedApiService(options QueuedApiServiceOptions) (api.Service, <-chan error) {
@@ -27,6 +35,7 @@ func LaunchQueuedApiService(options QueuedApiServiceOptions) (api.Service, <-cha
 	service := &queuedApiService{
 		QueuedApiServiceOptions: options,
 		stopch:                  make(chan struct{}),
+		statements:              makeStatementCache(options.StatementLimit),
 	}
 
 	if service.QueryLimit > 0 {
@@ -121,6 +130,20 @@ func (service *queuedApiService) Call(request api.Request) (<-chan api.Response,
 		return service.replicate(request)
 	case api.API_REFLECT:
 		return service.reflect(request)
+	case api.API_TAG:
+		return service.tag(request)
+	case api.API_REVERT:
+		return service.revert(request)
+	case api.API_BATCH:
+		return service.batch(request)
+	case api.API_COMPACT:
+		return service.compact(request)
+	case api.API_PREPARE:
+		return service.prepare(request)
+	case api.API_EXECUTE:
+		return service.execute(request)
+	case api.API_WATCH:
+		return nil, fmt.Errorf("Watch requests are streamed by Watch, not Call")
 	default:
 		return nil, fmt.Errorf("Unknown request.Type: %v", request.Type)
 	}
@@ -148,8 +171,8 @@ func (service *queuedApiService) enqueue(kvq api.Command) {
 	})
 }
 
-func (service *queuedApiService) replicate(request api.Request) (<-chan api.Response, error) {
-	log.Info("api.APIService Replicating...")
+// enqueueRequest queues the command for the request, to be run by the Core.
+func (service *queuedApiService) enqueueRequest(request api.Request) (<-chan api.Response, error) {
 	command, err := request.MakeCommand()
 
 	if err != nil {
@@ -161,6 +184,11 @@ func (service *queuedApiService) replicate(request api.Request) (<-chan api.Resp
 	return command.Response, nil
 }
 
+func (service *queuedApiService) replicate(request api.Request) (<-chan api.Response, error) {
+	log.Info("api.APIService Replicating...")
+	return service.enqueueRequest(request)
+}
+
 func (service *queuedApiService) runQuery(request api.Request) (<-chan api.Response, error) {
 	query := request.Query
 	if log.CanLog(log.LOG_INFO) {
@@ -173,28 +201,223 @@ func (service *queuedApiService) runQuery(request api.Request) (<-chan api.Respo
 
 	}
 
-	command, err := request.MakeCommand()
+	return service.enqueueRequest(request)
+}
+
+func (service *queuedApiService) reflect(request api.Request) (<-chan api.Response, error) {
+	log.Info("api.APIService running reflect request...")
+	return service.enqueueRequest(request)
+}
+
+func (service *queuedApiService) tag(request api.Request) (<-chan api.Response, error) {
+	log.Info("api.APIService running tag request...")
+	return service.enqueueRequest(request)
+}
+
+func (service *queuedApiService) revert(request api.Request) (<-chan api.Response, error) {
+	log.Warn("api.APIService reverting HEAD to: %s", request.Revert)
+	return service.enqueueRequest(request)
+}
+
+func (service *queuedApiService) batch(request api.Request) (<-chan api.Response, error) {
+	log.Info("api.APIService running batch of %d queries...", len(request.Batch))
+	return service.enqueueRequest(request)
+}
+
+func (service *queuedApiService) compact(request api.Request) (<-chan api.Response, error) {
+	log.Info("api.APIService compacting index...")
+	return service.enqueueRequest(request)
+}
+
+// prepare parses the statement once and holds it for later execute requests.
+// A statement without placeholders is compiled and validated now.
+func (service *queuedApiService) prepare(request api.Request) (<-chan api.Response, error) {
+	const failMsg = "prepare failed"
+
+	log.Info("api.APIService preparing statement...")
+	statement, err := query.Prepare(request.Prepare)
 
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, failMsg)
 	}
 
-	service.enqueue(command)
+	prepared := &preparedStatement{statement: statement}
 
-	return command.Response, nil
+	if statement.PlaceholderCount() == 0 {
+		_, err = prepared.bind(nil, service.Validator.QueryValidationContext())
+
+		if err != nil {
+			return nil, errors.Wrap(err, failMsg)
+		}
+	}
+
+	handle := service.statements.add(prepared)
+
+	resp := api.RESPONSE_PREPARE
+	resp.Statement = handle
+
+	respch := make(chan api.Response, 1)
+	respch <- resp
+	close(respch)
+
+	return respch, nil
 }
 
-func (service *queuedApiService) reflect(request api.Request) (<-chan api.Response, error) {
-	log.Info("api.APIService running reflect request...")
-	command, err := request.MakeCommand()
+// execute binds a prepared statement and runs it as a query.
+func (service *queuedApiService) execute(request api.Request) (<-chan api.Response, error) {
+	const failMsg = "execute failed"
+
+	handle := request.Execute.Statement
+	prepared, present := service.statements.get(handle)
+
+	if !present {
+		return nil, fmt.Errorf("Unknown statement: %s", handle)
+	}
+
+	query, err := prepared.bind(request.Execute.Variables, service.Validator.QueryValidationContext())
 
 	if err != nil {
+		return nil, errors.Wrap(err, failMsg)
+	}
+
+	return service.runQuery(api.MakeQueryRequest(query))
+}
+
+// Watch runs the select in a watch request, and runs it again each time the
+// index changes.  The first Response holds the results, and each later one
+// the changes: the rows that were added or changed, and the RemovedRows that
+// no longer match.  RowOrder is always the order of all the results.  Nothing
+// is sent when the results are unchanged.  Aggregate and explained selects
+// are sent in full each time they change.
+func (service *queuedApiService) Watch(request api.Request, stop <-chan struct{}) (<-chan api.Response, error) {
+	err := service.validateRequest(request)
+	if err != nil {
+		log.Warn("API received invalid watch request")
 		return nil, err
 	}
 
+	if request.Type != api.API_WATCH {
+		return nil, fmt.Errorf("Expected watch request but request.Type was: %v", request.Type)
+	}
+
+	log.Info("api.APIService watching query...")
+	respch := make(chan api.Response)
+	changes := service.Core.WatchIndex(stop)
+	go service.watchLoop(request.Query, changes, stop, respch)
+
+	return respch, nil
+}
+
+func (service *queuedApiService) watchLoop(q *query.Query, changes, stop <-chan struct{}, respch chan<- api.Response) {
+	defer close(respch)
+
+	var last api.Response
+	sent := false
+
+	for {
+		resp, ok := service.runWatchedQuery(q, stop)
+
+		if !ok {
+			return
+		}
+
+		update := resp
+		changed := !sent
+
+		if sent {
+			update, changed = makeWatchUpdate(last, resp)
+		}
+
+		if changed {
+			select {
+			case respch <- update:
+			case <-stop:
+				return
+			case <-service.stopch:
+				return
+			}
+
+			last = resp
+			sent = true
+		}
+
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		case <-stop:
+			return
+		case <-service.stopch:
+			return
+		}
+	}
+}
+
+// makeWatchUpdate finds the changes from the last results to the next.  It is
+// false when there are none.
+func makeWatchUpdate(last, next api.Response) (api.Response, bool) {
+	if next.Equals(last) {
+		return api.Response{}, false
+	}
+
+	isRows := next.Err == nil && last.Err == nil
+	isRows = isRows && next.Table.IsEmpty() && last.Table.IsEmpty()
+	isRows = isRows && next.Plan.IsEmpty() && last.Plan.IsEmpty()
+
+	if !isRows {
+		return next, true
+	}
+
+	update := next
+	update.Namespace = crdt.EmptyNamespace()
+
+	for tableName, table := range next.Namespace.Tables {
+		lastTable, _ := last.Namespace.GetTable(tableName)
+
+		for rowName, row := range table.Rows {
+			lastRow, err := lastTable.GetRow(rowName)
+
+			if err != nil || !row.Equals(lastRow) {
+				update.Namespace.AddTable(tableName, crdt.MakeTable(map[crdt.RowName]crdt.Row{rowName: row}))
+			}
+		}
+	}
+
+	for tableName, lastTable := range last.Namespace.Tables {
+		table, _ := next.Namespace.GetTable(tableName)
+
+		for rowName := range lastTable.Rows {
+			if _, err := table.GetRow(rowName); err != nil {
+				update.RemovedRows = append(update.RemovedRows, rowName)
+			}
+		}
+	}
+
+	sort.Sort(byRowName(update.RemovedRows))
+
+	return update, true
+}
+
+func (service *queuedApiService) runWatchedQuery(q *query.Query, stop <-chan struct{}) (api.Response, bool) {
+	command, err := api.MakeQueryRequest(q).MakeCommand()
+
+	if err != nil {
+		fail := api.RESPONSE_FAIL
+		fail.Err = err
+		return fail, true
+	}
+
 	service.enqueue(command)
 
-	return command.Response, nil
+	select {
+	case resp := <-command.Response:
+		return resp, true
+	case <-stop:
+		return api.RESPONSE_FAIL, false
+	case <-service.stopch:
+		return api.RESPONSE_FAIL, false
+	}
 }
 
 func (service *queuedApiService) CloseAPI() {
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/query"
+)
+
+// Batch runs several join and delete queries as one change.  Their tables are
+// written to a single namespace, which is added with a single index, so
+// readers and peers see all of the batch or none of it.  Nothing is written
+// if any query fails.
+//
+// Queries observe the data from before the batch and the tables joined
+// earlier in the batch.  Counter changes are serialised for the whole batch.
+func (rn *remoteNamespace) Batch(queries []*query.Query, kvq api.Command) {
+	runner := api.ResponderLambda(func() api.Response { return rn.runBatch(queries) })
+	response := runner.RunQuery()
+	kvq.WriteResponse(response)
+}
+
+func (rn *remoteNamespace) runBatch(queries []*query.Query) api.Response {
+	const failMsg = "remoteNamespace.runBatch failed"
+
+	fail := api.RESPONSE_BATCH
+	fail.Msg = api.RESPONSE_FAIL_MSG
+
+	batch := &batchNamespace{
+		RemoteNamespace: rn,
+		namespace:       crdt.EmptyNamespace(),
+	}
+
+	rn.counterLock.Lock()
+	defer rn.counterLock.Unlock()
+
+	for i, q := range queries {
+		switch q.OpCode {
+		case query.JOIN, query.DELETE:
+		default:
+			fail.Err = fmt.Errorf("Query %d in batch was not a join or delete", i)
+			return fail
+		}
+
+		response := rn.writeRunner(q, batch, nil).RunQuery()
+
+		if response.Err != nil {
+			fail.Err = errors.Wrapf(response.Err, "Query %d in batch failed", i)
+			return fail
+		}
+	}
+
+	if batch.namespace.IsEmpty() {
+		log.Info("Batch made no changes")
+		return api.RESPONSE_BATCH
+	}
+
+	path, err := rn.joinNamespace(batch.namespace)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	log.Info("Joined batch of %d queries at: %s", len(queries), path)
+
+	response := api.RESPONSE_BATCH
+	response.Path = path
+	return response
+}
+
+// batchNamespace collects the tables joined by a batch, instead of writing
+// them.  Searches read the collected tables and the underlying
+// RemoteNamespace.
+type batchNamespace struct {
+	api.RemoteNamespace
+	namespace crdt.Namespace
+}
+
+func (batch *batchNamespace) JoinTable(tableKey crdt.TableName, table crdt.Table) (crdt.IPFSPath, error) {
+	batch.namespace = batch.namespace.JoinTable(tableKey, table)
+	return crdt.NIL_PATH, nil
+}
+
+func (batch *batchNamespace) LoadTraverse(searcher api.NamespaceSearcher) error {
+	if !batch.namespace.IsEmpty() {
+		update := searcher.ReadSearchResult(api.SearchResult{Namespace: batch.namespace})
+
+		if update.Error != nil {
+			return errors.Wrap(update.Error, "batchNamespace.LoadTraverse failed")
+		}
+
+		if !update.More {
+			return nil
+		}
+	}
+
+	return batch.RemoteNamespace.LoadTraverse(searcher)
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/crypto"
+	"github.com/johnny-morrice/godless/log"
+)
+
+// Compact merges the namespaces linked for each table into a few new
+// namespaces, and makes HEAD an index that links only those.  Selects then
+// fetch one namespace per block, instead of one for every join.
+//
+// Only links signed by exactly this server's keys are compacted, because the
+// new links are signed with those keys.  The replaced links are quarantined,
+// so peers that have not compacted do not bring them back, even after the
+// server restarts.
+func (rn *remoteNamespace) Compact(kvq api.Command) {
+	runner := api.ResponderLambda(func() api.Response { return rn.compactIndex(__MIN_COMPACT_LINKS) })
+	response := runner.RunQuery()
+	kvq.WriteResponse(response)
+}
+
+func (rn *remoteNamespace) compactLoop() {
+	defer rn.wg.Done()
+
+	ticker := time.NewTicker(rn.CompactInterval)
+	defer ticker.Stop()
+
+	threshold := rn.CompactThreshold
+	if threshold < __MIN_COMPACT_LINKS {
+		threshold = __DEFAULT_COMPACT_THRESHOLD
+	}
+
+	for {
+		select {
+		case <-rn.stopch:
+			return
+		case <-ticker.C:
+			response := rn.compactIndex(threshold)
+
+			if response.Err != nil {
+				log.Error("Background compaction failed: %s", response.Err.Error())
+			}
+		}
+	}
+}
+
+// compactIndex compacts the tables with at least minLinks compactable links.
+func (rn *remoteNamespace) compactIndex(minLinks int) api.Response {
+	const failMsg = "remoteNamespace.compactIndex failed"
+
+	fail := api.RESPONSE_COMPACT
+	fail.Msg = api.RESPONSE_FAIL_MSG
+
+	rn.compactLock.Lock()
+	defer rn.compactLock.Unlock()
+
+	index, err := rn.loadCurrentIndex()
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	keys := rn.signingKeys()
+
+	replaced := crdt.EmptyIndex()
+	compacted := crdt.EmptyIndex()
+	someFailed := false
+
+	for _, tableName := range index.AllTables() {
+		links := compactableLinks(index.Index[tableName], keys)
+
+		if len(links) < minLinks {
+			continue
+		}
+
+		table, err := rn.loadCompactTable(tableName, links)
+
+		if err != nil {
+			log.Error("Skipping compaction of table '%s': %s", tableName, err.Error())
+			someFailed = true
+			continue
+		}
+
+		blocks := splitTable(tableName, table, rn.compactBlockRows())
+
+		if len(blocks) == 0 || len(blocks) >= len(links) {
+			continue
+		}
+
+		blockLinks, err := rn.writeBlocks(blocks)
+
+		if err != nil {
+			fail.Err = errors.Wrap(err, failMsg)
+			return fail
+		}
+
+		log.Info("Compacted %d links for table '%s' into %d", len(links), tableName, len(blockLinks))
+
+		replaced = replaced.JoinTable(tableName, links...)
+		compacted = compacted.JoinTable(tableName, blockLinks...)
+	}
+
+	if replaced.IsEmpty() {
+		log.Info("No compaction necessary")
+		resp := api.RESPONSE_COMPACT
+		resp.Msg = "Ok with no updates"
+
+		if someFailed {
+			resp.Msg = "Ok with load failures"
+		}
+
+		return resp
+	}
+
+	rn.headLock.Lock()
+	path, err := rn.writeCompaction(replaced, compacted)
+	rn.headLock.Unlock()
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	log.Info("Compacted %d tables at: %s", len(replaced.Index), path)
+
+	response := api.RESPONSE_COMPACT
+	response.Path = path
+
+	if someFailed {
+		response.Msg = "Ok with load failures"
+	}
+
+	return response
+}
+
+// loadCompactTable joins the table from each linked namespace.  Other tables
+// in those namespaces are still reached through their own links.
+func (rn *remoteNamespace) loadCompactTable(tableName crdt.TableName, links []crdt.Link) (crdt.Table, error) {
+	const failMsg = "remoteNamespace.loadCompactTable failed"
+
+	table := crdt.EmptyTable()
+
+	for _, link := range links {
+		namespace, _, err := rn.loadNamespace(link.Path())
+
+		if err != nil {
+			return crdt.EmptyTable(), errors.Wrap(err, failMsg)
+		}
+
+		part, err := namespace.GetTable(tableName)
+
+		if err != nil {
+			log.Warn("No table '%s' in namespace at: %s", tableName, link.Path())
+			continue
+		}
+
+		table = table.JoinTable(part)
+	}
+
+	return table, nil
+}
+
+func (rn *remoteNamespace) writeBlocks(blocks []crdt.Namespace) ([]crdt.Link, error) {
+	const failMsg = "remoteNamespace.writeBlocks failed"
+
+	links := make([]crdt.Link, len(blocks))
+
+	for i, block := range blocks {
+		addr, err := rn.insertNamespace(block)
+
+		if err != nil {
+			return nil, errors.Wrap(err, failMsg)
+		}
+
+		signed, err := crdt.SignedLink(addr, rn.KeyStore.GetAllPrivateKeys())
+
+		if err != nil {
+			return nil, errors.Wrap(err, failMsg)
+		}
+
+		links[i] = signed
+	}
+
+	return links, nil
+}
+
+// writeCompaction swaps the replaced links for the compacted ones.  Joins
+// made while the blocks were written are kept.  It must be called with
+// headLock held for writing.
+func (rn *remoteNamespace) writeCompaction(replaced, compacted crdt.Index) (crdt.IPFSPath, error) {
+	head, err := rn.getHead()
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	current, err := rn.MemoryImage.GetIndex()
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	index := current.Difference(replaced).JoinIndex(compacted)
+
+	record := index.Copy()
+
+	if !crdt.IsNilPath(head) {
+		record.Parents = []crdt.IPFSPath{head}
+	}
+
+	record.Created = rn.Clock.Now().Wall
+
+	path, err := rn.persistIndex(record)
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	err = rn.replaceQuarantinedHead(index, path, replaced)
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	return path, nil
+}
+
+func (rn *remoteNamespace) signingKeys() []crypto.PublicKey {
+	privateKeys := rn.KeyStore.GetAllPrivateKeys()
+	keys := make([]crypto.PublicKey, len(privateKeys))
+
+	for i, priv := range privateKeys {
+		keys[i] = priv.GetPublicKey()
+	}
+
+	return keys
+}
+
+func (rn *remoteNamespace) compactBlockRows() int {
+	if rn.CompactBlockRows > 0 {
+		return rn.CompactBlockRows
+	}
+
+	return __DEFAULT_COMPACT_BLOCK_ROWS
+}
+
+// compactableLinks keeps the links signed by every key, and by no other key.
+// Signing the compacted namespace with a different set of keys would change
+// which signed queries see its data.
+func compactableLinks(links []crdt.Link, keys []crypto.PublicKey) []crdt.Link {
+	compactable := []crdt.Link{}
+
+	for _, link := range links {
+		if isSignedByExactly(link, keys) {
+			compactable = append(compactable, link)
+		}
+	}
+
+	return compactable
+}
+
+func isSignedByExactly(link crdt.Link, keys []crypto.PublicKey) bool {
+	for _, pub := range keys {
+		if !link.IsVerifiedBy(pub) {
+			return false
+		}
+	}
+
+	text := []byte(link.Path())
+
+	for _, sig := range link.Signatures() {
+		if !isVerifiedByAny(text, sig, keys) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isVerifiedByAny(text []byte, sig crypto.Signature, keys []crypto.PublicKey) bool {
+	for _, pub := range keys {
+		ok, err := crypto.Verify(pub, text, sig)
+
+		if err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitTable makes namespaces of at most blockRows rows, in row name order,
+// so that the same table is always split the same way.
+func splitTable(tableName crdt.TableName, table crdt.Table, blockRows int) []crdt.Namespace {
+	rowNames := make([]crdt.RowName, 0, len(table.Rows))
+
+	for rowName := range table.Rows {
+		rowNames = append(rowNames, rowName)
+	}
+
+	sort.Sort(byRowName(rowNames))
+
+	blocks := []crdt.Namespace{}
+
+	for start := 0; start < len(rowNames); start += blockRows {
+		end := start + blockRows
+
+		if end > len(rowNames) {
+			end = len(rowNames)
+		}
+
+		rows := map[crdt.RowName]crdt.Row{}
+
+		for _, rowName := range rowNames[start:end] {
+			rows[rowName] = table.Rows[rowName]
+		}
+
+		block := crdt.EmptyNamespace().JoinTable(tableName, crdt.MakeTable(rows))
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+type byRowName []crdt.RowName
+
+func (rowNames byRowName) Len() int {
+	return len(rowNames)
+}
+
+func (rowNames byRowName) Swap(i, j int) {
+	rowNames[i], rowNames[j] = rowNames[j], rowNames[i]
+}
+
+func (rowNames byRowName) Less(i, j int) bool {
+	return rowNames[i] < rowNames[j]
+}
+
+const __MIN_COMPACT_LINKS = 2
+const __DEFAULT_COMPACT_THRESHOLD = 16
+const __DEFAULT_COMPACT_BLOCK_ROWS = 1024
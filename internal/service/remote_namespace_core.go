@@ -24,6 +24,16 @@ type RemoteNamespaceCoreOptions struct {
 	Pulse         time.Duration
 	Debug         bool
 	Functions     function.FunctionNamespace
+	// Clock is optional.  One is made with a random node ID if not set.
+	Clock *crdt.HybridClock
+	// LWWTables is optional.  Joins to these tables are always last-writer-wins.
+	LWWTables []crdt.TableName
+	// CompactInterval is optional.  The duration between background compactions.  Zero disables background compaction.
+	CompactInterval time.Duration
+	// CompactThreshold is optional.  Background compaction skips tables with fewer links than this.
+	CompactThreshold int
+	// CompactBlockRows is optional.  The most rows written to each compacted namespace.
+	CompactBlockRows int
 }
 
 func checkOptions(options RemoteNamespaceCoreOptions) {
@@ -49,6 +59,19 @@ type remoteNamespace struct {
 	stopch        chan struct{}
 	wg            *sync.WaitGroup
 	memImgTracker dirtyTracker
+	watchers      *indexWatchers
+	// headLock is held for writing while HEAD and the MemoryImage are reset together.
+	headLock sync.RWMutex
+	// quarantine holds the namespace links dropped by reverts, and those
+	// replaced by compaction.  It is persisted in the Cache.
+	quarantine crdt.Index
+	// compactLock is held while a compaction runs.
+	compactLock sync.Mutex
+	// counterLock is held while a join reads and writes counters.
+	counterLock sync.Mutex
+	// peerHeads are the peer HEADs merged by replication, so that index
+	// deltas based on them can be merged alone.
+	peerHeads *knownHeads
 }
 
 func MakeRemoteNamespaceCore(options RemoteNamespaceCoreOptions) api.RemoteNamespaceCore {
@@ -59,6 +82,10 @@ func MakeRemoteNamespaceCore(options RemoteNamespaceCoreOptions) api.RemoteNames
 
 	checkOptions(options)
 
+	if options.Clock == nil {
+		options.Clock = makeRandomClock()
+	}
+
 	remote := &remoteNamespace{
 		RemoteNamespaceCoreOptions: options,
 		namespaceTube:              make(chan addNamespaceRequest),
@@ -67,6 +94,9 @@ func MakeRemoteNamespaceCore(options RemoteNamespaceCoreOptions) api.RemoteNames
 		stopch:                     make(chan struct{}),
 		wg:                         &sync.WaitGroup{},
 		memImgTracker:              makeDirtyTracker(),
+		watchers:                   &indexWatchers{},
+		quarantine:                 loadQuarantine(options.Cache),
+		peerHeads:                  &knownHeads{},
 	}
 
 	remote.wg.Add(__REMOTE_NAMESPACE_PROCESS_COUNT)
@@ -75,6 +105,11 @@ func MakeRemoteNamespaceCore(options RemoteNamespaceCoreOptions) api.RemoteNames
 	go remote.addIndices()
 	go remote.memoryImageWriteLoop()
 
+	if remote.CompactInterval > 0 {
+		remote.wg.Add(1)
+		go remote.compactLoop()
+	}
+
 	if initWait != nil {
 		<-initWait
 	}
@@ -82,6 +117,18 @@ func MakeRemoteNamespaceCore(options RemoteNamespaceCoreOptions) api.RemoteNames
 	return remote
 }
 
+func makeRandomClock() *crdt.HybridClock {
+	node, err := crdt.GenerateNodeID()
+
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info("Clock node ID: %s", node)
+
+	return crdt.MakeHybridClock(node)
+}
+
 func (rn *remoteNamespace) initializeMemoryImage() <-chan struct{} {
 	donech := make(chan struct{})
 	head, err := rn.getHead()
@@ -143,12 +190,38 @@ func (rn *remoteNamespace) writeDirtyMemoryImage() {
 }
 
 func (rn *remoteNamespace) WriteMemoryImage() error {
+	rn.headLock.RLock()
+	defer rn.headLock.RUnlock()
+
 	index, err := rn.MemoryImage.GetIndex()
 
 	if err != nil {
 		return errors.Wrap(err, "Error joining MemoryImage indices")
 	}
 
+	head, err := rn.getHead()
+
+	if err != nil {
+		return errors.Wrap(err, "Failed to read HEAD cache")
+	}
+
+	if !crdt.IsNilPath(head) {
+		parent, err := rn.loadIndex(head)
+
+		if err != nil {
+			return errors.Wrap(err, "Failed to load HEAD Index")
+		}
+
+		if len(index.ChangedTables(parent)) == 0 {
+			log.Info("MemoryImage unchanged from HEAD at: %s", head)
+			return nil
+		}
+
+		index.Parents = []crdt.IPFSPath{head}
+	}
+
+	index.Created = rn.Clock.Now().Wall
+
 	path, err := rn.persistIndex(index)
 
 	if err != nil {
@@ -202,8 +275,15 @@ func (rn *remoteNamespace) addIndex(addRequest addIndexRequest) {
 	errch := make(chan error, 1)
 
 	go func() {
+		rn.headLock.RLock()
 		err := rn.MemoryImage.JoinIndex(index)
+		rn.headLock.RUnlock()
 		rn.memImgTracker.markDirty()
+
+		if err == nil {
+			rn.watchers.notify()
+		}
+
 		errch <- err
 	}()
 
@@ -238,6 +318,10 @@ func (rn *remoteNamespace) persistIndex(index crdt.Index) (crdt.IPFSPath, error)
 	return indexAddr, nil
 }
 
+func (rn *remoteNamespace) WatchIndex(stop <-chan struct{}) <-chan struct{} {
+	return rn.watchers.watch(stop, rn.stopch)
+}
+
 func (rn *remoteNamespace) Close() {
 	close(rn.stopch)
 	rn.wg.Wait()
@@ -259,6 +343,7 @@ func (rn *remoteNamespace) joinPeerIndex(links []crdt.Link) api.Response {
 	keys := rn.KeyStore.GetAllPublicKeys()
 
 	joined := crdt.EmptyIndex()
+	merged := []crdt.IPFSPath{}
 
 	someFailed := false
 	updateHappened := false
@@ -288,7 +373,7 @@ func (rn *remoteNamespace) joinPeerIndex(links []crdt.Link) api.Response {
 			log.Error("Could not get HEAD to prevent potentially useless replication of %s", peerAddr)
 		}
 
-		theirIndex, theirErr := rn.loadIndex(peerAddr)
+		theirIndex, theirErr := rn.loadPeerIndex(peerAddr, myAddr)
 
 		if theirErr != nil {
 			log.Error("Failed to replicate Index at: %s", peerAddr)
@@ -296,7 +381,14 @@ func (rn *remoteNamespace) joinPeerIndex(links []crdt.Link) api.Response {
 			continue
 		}
 
-		joined = joined.JoinIndex(theirIndex)
+		if theirIndex.isKnown {
+			log.Debug("Skipping replication of known delta %s", peerAddr)
+			continue
+		}
+
+		rn.observePeerIndex(theirIndex.Index)
+		joined = joined.JoinIndex(rn.withoutQuarantined(theirIndex.Index))
+		merged = append(merged, theirIndex.head)
 		updateHappened = true
 	}
 
@@ -315,6 +407,8 @@ func (rn *remoteNamespace) joinPeerIndex(links []crdt.Link) api.Response {
 		return failResponse
 	}
 
+	rn.peerHeads.add(merged...)
+
 	resp := api.RESPONSE_REPLICATE
 
 	if someFailed {
@@ -355,6 +449,10 @@ func (rn *remoteNamespace) Reflect(reflect api.ReflectionType, kvq api.Command)
 		runner = api.ResponderLambda(rn.getReflectHead)
 	case api.REFLECT_INDEX:
 		runner = api.ResponderLambda(rn.getReflectIndex)
+	case api.REFLECT_INDEX_LOG:
+		runner = api.ResponderLambda(func() api.Response {
+			return rn.getReflectIndexLog(kvq.Request.LogLimit)
+		})
 	case api.REFLECT_DUMP_NAMESPACE:
 		runner = api.ResponderLambda(rn.dumpReflectNamespaces)
 	default:
@@ -401,6 +499,56 @@ func (rn *remoteNamespace) getReflectIndex() api.Response {
 	return response
 }
 
+// getReflectIndexLog walks the index chain back from HEAD, following first
+// parents, for at most limit entries.  Each index is loaded once.
+func (rn *remoteNamespace) getReflectIndexLog(limit uint32) api.Response {
+	const failMsg = "remoteNamespace.getReflectIndexLog failed"
+	response := api.RESPONSE_REFLECT
+
+	if limit == 0 {
+		limit = api.DEFAULT_LOG_LIMIT
+	}
+
+	path, err := rn.getHead()
+
+	if err != nil {
+		response.Msg = api.RESPONSE_FAIL_MSG
+		response.Err = errors.Wrap(err, failMsg)
+		return response
+	}
+
+	index := crdt.EmptyIndex()
+
+	if !crdt.IsNilPath(path) {
+		index, err = rn.loadIndex(path)
+	}
+
+	for err == nil && !crdt.IsNilPath(path) && uint32(len(response.History)) < limit {
+		parent := crdt.EmptyIndex()
+		parentPath := crdt.NIL_PATH
+
+		if len(index.Parents) > 0 {
+			parentPath = index.Parents[0]
+			parent, err = rn.loadIndex(parentPath)
+		}
+
+		if err == nil {
+			entry := api.MakeIndexLogEntry(path, index, parent)
+			response.History = append(response.History, entry)
+			path = parentPath
+			index = parent
+		}
+	}
+
+	if err != nil {
+		response.Msg = api.RESPONSE_FAIL_MSG
+		response.Err = errors.Wrap(err, failMsg)
+		response.History = nil
+	}
+
+	return response
+}
+
 func (rn *remoteNamespace) dumpReflectNamespaces() api.Response {
 	const failMsg = "remoteNamespace.dumpReflectNamespace failed"
 	response := api.RESPONSE_REFLECT
@@ -471,17 +619,32 @@ func (rn *remoteNamespace) RunQuery(q *query.Query, kvq api.Command) {
 	var runner api.Responder
 
 	switch q.OpCode {
-	case query.JOIN:
-		log.Info("Running join...")
-		visitor := eval.MakeNamespaceTreeJoin(rn, rn.KeyStore)
-		q.Visit(visitor)
-		runner = visitor
+	case query.JOIN, query.DELETE:
+		runner = rn.writeRunner(q, rn, &rn.counterLock)
 	case query.SELECT:
 		log.Info("Running select...")
+
+		if q.Select.IndexTag != "" {
+			path, err := rn.resolveTag(q.Select.IndexTag)
+
+			if err != nil {
+				fail := api.RESPONSE_FAIL
+				fail.Type = api.API_QUERY
+				fail.Err = err
+				kvq.WriteResponse(fail)
+				return
+			}
+
+			resolved := *q
+			resolved.Select.IndexPath = path
+			q = &resolved
+		}
+
 		options := eval.SelectOptions{
 			Namespace: rn,
 			KeyStore:  rn.KeyStore,
 			Functions: rn.Functions,
+			Clock:     rn.Clock,
 		}
 		visitor := eval.MakeNamespaceTreeSelect(options)
 		q.Visit(visitor)
@@ -494,13 +657,54 @@ func (rn *remoteNamespace) RunQuery(q *query.Query, kvq api.Command) {
 	kvq.WriteResponse(response)
 }
 
+// writeRunner evaluates a join or delete query, writing tables to namespace.
+// The counterLock is optional.
+func (rn *remoteNamespace) writeRunner(q *query.Query, namespace api.RemoteNamespace, counterLock sync.Locker) api.Responder {
+	switch q.OpCode {
+	case query.JOIN:
+		log.Info("Running join...")
+		options := eval.JoinOptions{
+			Namespace:   namespace,
+			KeyStore:    rn.KeyStore,
+			Clock:       rn.Clock,
+			CounterLock: counterLock,
+			LWWTables:   rn.LWWTables,
+		}
+		visitor := eval.MakeNamespaceTreeJoin(options)
+		q.Visit(visitor)
+		return visitor
+	case query.DELETE:
+		log.Info("Running delete...")
+		visitor := eval.MakeNamespaceTreeDelete(namespace, rn.KeyStore)
+		q.Visit(visitor)
+		return visitor
+	default:
+		q.OpCodePanic()
+		return nil
+	}
+}
+
 // TODO there should be more clarity on who locks and when.
 func (rn *remoteNamespace) JoinTable(tableKey crdt.TableName, table crdt.Table) (crdt.IPFSPath, error) {
 	const failMsg = "remoteNamespace.JoinTable failed"
 
 	joined := crdt.EmptyNamespace().JoinTable(tableKey, table)
 
-	addr, nsErr := rn.insertNamespace(joined)
+	indexAddr, err := rn.joinNamespace(joined)
+
+	if err != nil {
+		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
+	}
+
+	return indexAddr, nil
+}
+
+// joinNamespace writes the namespace and adds every table in it with a single
+// index.
+func (rn *remoteNamespace) joinNamespace(namespace crdt.Namespace) (crdt.IPFSPath, error) {
+	const failMsg = "remoteNamespace.joinNamespace failed"
+
+	addr, nsErr := rn.insertNamespace(namespace)
 
 	if nsErr != nil {
 		return crdt.NIL_PATH, errors.Wrap(nsErr, failMsg)
@@ -512,7 +716,7 @@ func (rn *remoteNamespace) JoinTable(tableKey crdt.TableName, table crdt.Table)
 		return crdt.NIL_PATH, errors.Wrap(signErr, failMsg)
 	}
 
-	index := crdt.EmptyIndex().JoinTable(tableKey, signed)
+	index := crdt.EmptyIndex().JoinNamespace(signed, namespace)
 
 	indexAddr, indexErr := rn.insertIndex(index)
 
@@ -536,11 +740,36 @@ func (rn *remoteNamespace) LoadTraverse(searcher api.NamespaceSearcher) error {
 
 	tableAddrs := searcher.Search(index)
 
-	return rn.traverseTableNamespaces(tableAddrs, searcher)
+	return rn.traverseTableNamespaces(tableAddrs, searchRows(searcher), searcher)
 }
 
-func (rn *remoteNamespace) traverseTableNamespaces(tableAddrs []crdt.Link, f api.SearchResultTraverser) error {
-	resultch, cancelch := rn.namespaceLoader(tableAddrs)
+func (rn *remoteNamespace) LoadTraverseIndex(indexAddr crdt.IPFSPath, searcher api.NamespaceSearcher) error {
+	const failMsg = "remoteNamespace.LoadTraverseIndex failed"
+
+	index, indexerr := rn.loadIndex(indexAddr)
+
+	if indexerr != nil {
+		indexLoadFailure := api.SearchResult{IndexLoadFailure: true}
+		searcher.ReadSearchResult(indexLoadFailure)
+		return errors.Wrap(indexerr, failMsg)
+	}
+
+	tableAddrs := searcher.Search(index)
+
+	return rn.traverseTableNamespaces(tableAddrs, searchRows(searcher), searcher)
+}
+
+// searchRows are the rows needed by the searcher, or nil for every row.
+func searchRows(searcher api.NamespaceSearcher) []crdt.RowName {
+	if rowSearch, ok := searcher.(api.RowSearch); ok {
+		return rowSearch.SearchRows()
+	}
+
+	return nil
+}
+
+func (rn *remoteNamespace) traverseTableNamespaces(tableAddrs []crdt.Link, rows []crdt.RowName, f api.SearchResultTraverser) error {
+	resultch, cancelch := rn.namespaceLoader(tableAddrs, rows)
 	defer close(cancelch)
 	for result := range resultch {
 		update := f.ReadSearchResult(result)
@@ -560,23 +789,31 @@ func (rn *remoteNamespace) traverseTableNamespaces(tableAddrs []crdt.Link, f api
 }
 
 // Preload namespaces while the previous is analysed.
-func (rn *remoteNamespace) namespaceLoader(addrs []crdt.Link) (<-chan api.SearchResult, chan<- struct{}) {
+func (rn *remoteNamespace) namespaceLoader(addrs []crdt.Link, rows []crdt.RowName) (<-chan api.SearchResult, chan<- struct{}) {
 	resultch := make(chan api.SearchResult)
 	cancelch := make(chan struct{}, 1)
 
 	go func() {
 		defer close(resultch)
 		for _, a := range addrs {
-			namespace, err := rn.loadNamespace(a.Path())
+			namespace, source, err := rn.loadNamespaceRows(a.Path(), rows)
 
 			if err != nil {
 				log.Error("remoteNamespace.namespaceLoader: %s", err.Error())
-				resultch <- api.SearchResult{NamespaceLoadFailure: true}
+				resultch <- api.SearchResult{
+					NamespaceLoadFailure: true,
+					Path:                 a.Path(),
+					Source:               source,
+				}
 				continue
 			}
 
 			log.Info("Catted namespace from: %s", a.Path())
-			successResult := api.SearchResult{Namespace: namespace}
+			successResult := api.SearchResult{
+				Namespace: namespace,
+				Path:      a.Path(),
+				Source:    source,
+			}
 			select {
 			case <-rn.stopch:
 				return
@@ -591,23 +828,48 @@ func (rn *remoteNamespace) namespaceLoader(addrs []crdt.Link) (<-chan api.Search
 	return resultch, cancelch
 }
 
-func (rn *remoteNamespace) loadNamespace(namespaceAddr crdt.IPFSPath) (crdt.Namespace, error) {
+func (rn *remoteNamespace) loadNamespace(namespaceAddr crdt.IPFSPath) (crdt.Namespace, api.NamespaceSource, error) {
 	const failMsg = "remoteNamespace.loadNamespace failed"
 
 	ns, cacheErr := rn.Cache.GetNamespace(namespaceAddr)
 
 	if cacheErr == nil {
-		return ns, nil
+		return ns, api.SOURCE_CACHE, nil
 	}
 
 	log.Info("Cache miss for namespace at: %s", namespaceAddr)
 	ns, remoteErr := rn.Store.CatNamespace(namespaceAddr)
 
 	if remoteErr != nil {
-		return crdt.EmptyNamespace(), errors.Wrap(remoteErr, failMsg)
+		return crdt.EmptyNamespace(), api.SOURCE_IPFS, errors.Wrap(remoteErr, failMsg)
+	}
+
+	return ns, api.SOURCE_IPFS, nil
+}
+
+// loadNamespaceRows loads only the shards holding the rows, unless the whole
+// namespace is cached.  It loads every row when rows is nil.
+func (rn *remoteNamespace) loadNamespaceRows(namespaceAddr crdt.IPFSPath, rows []crdt.RowName) (crdt.Namespace, api.NamespaceSource, error) {
+	const failMsg = "remoteNamespace.loadNamespaceRows failed"
+
+	if rows == nil {
+		return rn.loadNamespace(namespaceAddr)
+	}
+
+	ns, cacheErr := rn.Cache.GetNamespace(namespaceAddr)
+
+	if cacheErr == nil {
+		return ns, api.SOURCE_CACHE, nil
+	}
+
+	log.Info("Cache miss for namespace at: %s", namespaceAddr)
+	ns, remoteErr := rn.Store.CatNamespaceRows(namespaceAddr, rows)
+
+	if remoteErr != nil {
+		return crdt.EmptyNamespace(), api.SOURCE_IPFS, errors.Wrap(remoteErr, failMsg)
 	}
 
-	return ns, nil
+	return ns, api.SOURCE_IPFS, nil
 }
 
 func (rn *remoteNamespace) loadCurrentIndex() (crdt.Index, error) {
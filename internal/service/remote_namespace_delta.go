@@ -0,0 +1,119 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/log"
+)
+
+// peerIndex is the index to merge for a link published by a peer.
+type peerIndex struct {
+	crdt.Index
+	// head is the peer HEAD that is merged with the index.
+	head crdt.IPFSPath
+	// isKnown is true when head was merged already.
+	isKnown bool
+}
+
+// loadPeerIndex loads the index published at peerAddr.  A delta only holds
+// the links added since its base, so it is merged alone when the base is
+// known.  When the base is unknown, the full index at the delta head is
+// merged instead.
+func (rn *remoteNamespace) loadPeerIndex(peerAddr, myAddr crdt.IPFSPath) (peerIndex, error) {
+	const failMsg = "remoteNamespace.loadPeerIndex failed"
+
+	index, err := rn.loadIndex(peerAddr)
+
+	if err != nil {
+		return peerIndex{}, errors.Wrap(err, failMsg)
+	}
+
+	if !index.IsDelta() {
+		return peerIndex{Index: index, head: peerAddr}, nil
+	}
+
+	peer := peerIndex{Index: index, head: index.Head}
+
+	if rn.isKnownHead(index.Head, myAddr) {
+		peer.isKnown = true
+		return peer, nil
+	}
+
+	if rn.isKnownHead(index.Base, myAddr) {
+		log.Info("Merging index delta from %s to %s", index.Base, index.Head)
+		return peer, nil
+	}
+
+	log.Info("Unknown base for index delta, merging full index at: %s", index.Head)
+
+	full, err := rn.loadIndex(index.Head)
+
+	if err != nil {
+		return peerIndex{}, errors.Wrap(err, failMsg)
+	}
+
+	peer.Index = full
+	return peer, nil
+}
+
+// observePeerIndex moves the clock past the writes in a peer index.  The peer
+// read Created from its clock after making them, so they are all earlier than
+// the nanosecond after.
+func (rn *remoteNamespace) observePeerIndex(index crdt.Index) {
+	if index.Created > 0 {
+		rn.Clock.Observe(crdt.Timestamp{Wall: index.Created + 1})
+	}
+}
+
+func (rn *remoteNamespace) isKnownHead(head, myAddr crdt.IPFSPath) bool {
+	if crdt.IsNilPath(head) {
+		return false
+	}
+
+	return head == myAddr || rn.peerHeads.has(head)
+}
+
+// knownHeads remembers the most recent peer HEADs that have been merged.
+type knownHeads struct {
+	sync.Mutex
+	heads []crdt.IPFSPath
+}
+
+func (known *knownHeads) add(heads ...crdt.IPFSPath) {
+	known.Lock()
+	defer known.Unlock()
+
+	for _, head := range heads {
+		if known.hasUnlocked(head) {
+			continue
+		}
+
+		known.heads = append(known.heads, head)
+	}
+
+	if overflow := len(known.heads) - __MAX_KNOWN_HEADS; overflow > 0 {
+		known.heads = known.heads[overflow:]
+	}
+}
+
+func (known *knownHeads) has(head crdt.IPFSPath) bool {
+	known.Lock()
+	defer known.Unlock()
+
+	return known.hasUnlocked(head)
+}
+
+func (known *knownHeads) hasUnlocked(head crdt.IPFSPath) bool {
+	for _, other := range known.heads {
+		if other == head {
+			return true
+		}
+	}
+
+	return false
+}
+
+const __MAX_KNOWN_HEADS = 1024
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/log"
+)
+
+// Revert makes a past index HEAD.  The revert is recorded as a new HEAD, whose
+// parents are the old HEAD and the target, so it shows in the index log.
+//
+// Namespace links dropped by the revert are quarantined: peer indices are
+// joined without them, so peers still publishing the reverted data do not
+// bring it straight back.  Local joins are not filtered.  The quarantine is
+// kept in the Cache beside HEAD, so it holds after the server restarts.
+func (rn *remoteNamespace) Revert(target crdt.IPFSPath, kvq api.Command) {
+	runner := api.ResponderLambda(func() api.Response { return rn.revertHead(target) })
+	response := runner.RunQuery()
+	kvq.WriteResponse(response)
+}
+
+func (rn *remoteNamespace) revertHead(target crdt.IPFSPath) api.Response {
+	const failMsg = "remoteNamespace.revertHead failed"
+
+	path, err := rn.checkoutIndex(target)
+
+	if err != nil {
+		fail := api.RESPONSE_REVERT
+		fail.Msg = api.RESPONSE_FAIL_MSG
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	response := api.RESPONSE_REVERT
+	response.Path = path
+	return response
+}
+
+// checkoutIndex makes the index at target HEAD, as described for Revert, and
+// returns the new HEAD.
+func (rn *remoteNamespace) checkoutIndex(target crdt.IPFSPath) (crdt.IPFSPath, error) {
+	index, err := rn.loadIndex(target)
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	rn.headLock.Lock()
+	path, dropped, err := rn.writeRevert(target, index)
+	rn.headLock.Unlock()
+
+	if err != nil {
+		return crdt.NIL_PATH, err
+	}
+
+	rn.watchers.notify()
+
+	log.Warn("Reverted HEAD to %s at: %s (%d tables quarantined)", target, path, len(dropped.Index))
+
+	return path, nil
+}
+
+// writeRevert must be called with headLock held for writing.
+func (rn *remoteNamespace) writeRevert(target crdt.IPFSPath, index crdt.Index) (crdt.IPFSPath, crdt.Index, error) {
+	head, err := rn.getHead()
+
+	if err != nil {
+		return crdt.NIL_PATH, crdt.EmptyIndex(), err
+	}
+
+	current, err := rn.MemoryImage.GetIndex()
+
+	if err != nil {
+		return crdt.NIL_PATH, crdt.EmptyIndex(), err
+	}
+
+	record := index.Copy()
+	record.Parents = []crdt.IPFSPath{target}
+
+	if !crdt.IsNilPath(head) {
+		record.Parents = []crdt.IPFSPath{head, target}
+	}
+
+	record.Created = rn.Clock.Now().Wall
+
+	path, err := rn.persistIndex(record)
+
+	if err != nil {
+		return crdt.NIL_PATH, crdt.EmptyIndex(), err
+	}
+
+	dropped := current.Difference(index)
+	err = rn.replaceQuarantinedHead(index, path, dropped)
+
+	if err != nil {
+		return crdt.NIL_PATH, crdt.EmptyIndex(), err
+	}
+
+	return path, dropped, nil
+}
+
+// replaceQuarantinedHead quarantines the links, and then replaces HEAD.  The
+// quarantine is restored if HEAD cannot be replaced.  It must be called with
+// headLock held for writing.
+func (rn *remoteNamespace) replaceQuarantinedHead(index crdt.Index, head crdt.IPFSPath, links crdt.Index) error {
+	previous := rn.quarantine
+	err := rn.setQuarantine(previous.JoinIndex(links))
+
+	if err != nil {
+		return err
+	}
+
+	err = rn.replaceHead(index, head)
+
+	if err != nil {
+		restoreErr := rn.setQuarantine(previous)
+
+		if restoreErr != nil {
+			log.Error("Failed to restore quarantine: %s", restoreErr.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// setQuarantine writes the quarantine to the Cache before it is used.  It
+// must be called with headLock held for writing.
+func (rn *remoteNamespace) setQuarantine(quarantine crdt.Index) error {
+	err := rn.Cache.SetQuarantine(quarantine)
+
+	if err != nil {
+		return err
+	}
+
+	rn.quarantine = quarantine
+	return nil
+}
+
+// loadQuarantine reads the quarantine kept by an earlier run.
+func loadQuarantine(cache api.HeadCache) crdt.Index {
+	quarantine, err := cache.GetQuarantine()
+
+	if err != nil {
+		log.Error("Failed to load quarantine: %s", err.Error())
+		return crdt.EmptyIndex()
+	}
+
+	if !quarantine.IsEmpty() {
+		log.Info("Loaded quarantine (%d tables)", len(quarantine.Index))
+	}
+
+	return quarantine
+}
+
+// replaceHead resets the MemoryImage and HEAD together.  The MemoryImage is
+// restored if HEAD cannot be written.  It must be called with headLock held
+// for writing.
+func (rn *remoteNamespace) replaceHead(index crdt.Index, head crdt.IPFSPath) error {
+	previous, err := rn.MemoryImage.GetIndex()
+
+	if err != nil {
+		return err
+	}
+
+	err = rn.MemoryImage.ResetIndex(index)
+
+	if err != nil {
+		return err
+	}
+
+	err = rn.setHead(head)
+
+	if err != nil {
+		restoreErr := rn.MemoryImage.ResetIndex(previous)
+
+		if restoreErr != nil {
+			log.Error("Failed to restore MemoryImage: %s", restoreErr.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (rn *remoteNamespace) withoutQuarantined(index crdt.Index) crdt.Index {
+	rn.headLock.RLock()
+	defer rn.headLock.RUnlock()
+
+	if rn.quarantine.IsEmpty() {
+		return index
+	}
+
+	return index.Difference(rn.quarantine)
+}
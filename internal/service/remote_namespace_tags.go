@@ -0,0 +1,131 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/log"
+)
+
+func (rn *remoteNamespace) Tag(tag api.TagRequest, kvq api.Command) {
+	var runner api.Responder
+	switch tag.Command {
+	case api.TAG_CREATE:
+		runner = api.ResponderLambda(func() api.Response { return rn.createTag(tag) })
+	case api.TAG_LIST:
+		runner = api.ResponderLambda(rn.listTags)
+	case api.TAG_CHECKOUT:
+		runner = api.ResponderLambda(func() api.Response { return rn.checkoutTag(tag.Name) })
+	default:
+		panic("Unknown tag command")
+	}
+
+	response := runner.RunQuery()
+	kvq.WriteResponse(response)
+}
+
+func (rn *remoteNamespace) createTag(request api.TagRequest) api.Response {
+	const failMsg = "remoteNamespace.createTag failed"
+
+	fail := api.RESPONSE_TAG
+	fail.Msg = api.RESPONSE_FAIL_MSG
+
+	path := request.Path
+
+	if crdt.IsNilPath(path) {
+		head, err := rn.getHead()
+
+		if err != nil {
+			fail.Err = errors.Wrap(err, failMsg)
+			return fail
+		}
+
+		if crdt.IsNilPath(head) {
+			fail.Err = errors.New("No index available to tag")
+			return fail
+		}
+
+		path = head
+	}
+
+	_, err := rn.loadIndex(path)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	tag := api.Tag{Name: request.Name, Path: path}
+	err = rn.Cache.SetTag(tag)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	log.Info("Tagged index '%s' at: %s", tag.Name, tag.Path)
+
+	response := api.RESPONSE_TAG
+	response.Path = path
+	return response
+}
+
+func (rn *remoteNamespace) listTags() api.Response {
+	const failMsg = "remoteNamespace.listTags failed"
+
+	tags, err := rn.Cache.GetTags()
+
+	if err != nil {
+		fail := api.RESPONSE_TAG
+		fail.Msg = api.RESPONSE_FAIL_MSG
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	response := api.RESPONSE_TAG
+	response.Tags = tags
+	return response
+}
+
+// checkoutTag makes the tagged index HEAD in the same way as a revert.  The
+// checkout is recorded as a new HEAD, and the links it drops are quarantined,
+// so that replication does not undo it.  Later joins build on the tagged
+// index.
+func (rn *remoteNamespace) checkoutTag(name string) api.Response {
+	const failMsg = "remoteNamespace.checkoutTag failed"
+
+	fail := api.RESPONSE_TAG
+	fail.Msg = api.RESPONSE_FAIL_MSG
+
+	tag, err := rn.Cache.GetTag(name)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	path, err := rn.checkoutIndex(tag.Path)
+
+	if err != nil {
+		fail.Err = errors.Wrap(err, failMsg)
+		return fail
+	}
+
+	log.Info("Checked out tag '%s' (%s) at: %s", tag.Name, tag.Path, path)
+
+	response := api.RESPONSE_TAG
+	response.Path = path
+	return response
+}
+
+// resolveTag points a select at the index named by its tag.
+func (rn *remoteNamespace) resolveTag(name string) (crdt.IPFSPath, error) {
+	tag, err := rn.Cache.GetTag(name)
+
+	if err != nil {
+		return crdt.NIL_PATH, errors.Wrap(err, "remoteNamespace.resolveTag failed")
+	}
+
+	return tag.Path, nil
+}
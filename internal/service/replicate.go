@@ -26,6 +26,13 @@ type replicator struct {
 	errch    chan<- error
 	stopch   <-chan struct{}
 	keyStore api.KeyStore
+	// published is the last HEAD published, and the link that was published for it.
+	published *publishedIndex
+}
+
+type publishedIndex struct {
+	head crdt.IPFSPath
+	link crdt.Link
 }
 
 // TODO support one-shot replication.
@@ -39,13 +46,14 @@ func Replicate(options ReplicateOptions) (api.Closer, <-chan error) {
 	}
 
 	p2p := replicator{
-		topics:   options.Topics,
-		api:      options.API,
-		store:    options.RemoteStore,
-		keyStore: options.KeyStore,
-		interval: interval,
-		errch:    errch,
-		stopch:   stopch,
+		topics:    options.Topics,
+		api:       options.API,
+		store:     options.RemoteStore,
+		keyStore:  options.KeyStore,
+		interval:  interval,
+		errch:     errch,
+		stopch:    stopch,
+		published: &publishedIndex{},
 	}
 
 	wg := &sync.WaitGroup{}
@@ -105,31 +113,92 @@ func (p2p replicator) publishIndex() {
 
 	head := crdt.IPFSPath(resp.Path)
 
-	// API should do this for its HEAD.
-	privKeys := p2p.keyStore.GetAllPrivateKeys()
+	if head == p2p.published.head {
+		p2p.publishLink(p2p.published.link)
+		log.Info("Republished index at %v", head)
+		return
+	}
 
-	var link crdt.Link
+	path := head
 
-	var err error
-	if len(privKeys) > 0 {
-		link, err = crdt.SignedLink(head, privKeys)
-		if err != nil {
-			log.Error("Failed to sign Index Link (%s): %s", head, err.Error())
-			return
+	if !crdt.IsNilPath(p2p.published.head) {
+		delta, err := p2p.addIndexDelta(head, p2p.published.head)
+
+		if err == nil {
+			path = delta
+		} else {
+			log.Warn("Publishing full index, delta failed: %s", err.Error())
 		}
-	} else {
+	}
+
+	link, err := p2p.signIndexLink(path)
+
+	if err != nil {
+		log.Error("Failed to sign Index Link (%s): %s", path, err.Error())
+		return
+	}
+
+	if !p2p.publishLink(link) {
+		return
+	}
+
+	p2p.published.head = head
+	p2p.published.link = link
+
+	log.Info("Published index at %v", path)
+}
+
+// addIndexDelta stores the links added to head since base, the last HEAD
+// published.  Peers that merged base need only merge the delta.
+func (p2p replicator) addIndexDelta(head, base crdt.IPFSPath) (crdt.IPFSPath, error) {
+	const failMsg = "replicator.addIndexDelta failed"
+
+	headIndex, err := p2p.store.CatIndex(head)
+
+	if err != nil {
+		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
+	}
+
+	baseIndex, err := p2p.store.CatIndex(base)
+
+	if err != nil {
+		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
+	}
+
+	delta := crdt.MakeIndexDelta(headIndex, head, baseIndex, base)
+
+	path, err := p2p.store.AddIndex(delta)
+
+	if err != nil {
+		return crdt.NIL_PATH, errors.Wrap(err, failMsg)
+	}
+
+	log.Info("Index delta from %s to %s has %d tables", base, head, len(delta.Index))
+
+	return path, nil
+}
+
+func (p2p replicator) signIndexLink(path crdt.IPFSPath) (crdt.Link, error) {
+	// API should do this for its HEAD.
+	privKeys := p2p.keyStore.GetAllPrivateKeys()
+
+	if len(privKeys) == 0 {
 		log.Warn("HEAD shared without signature (no keys available)")
-		link = crdt.UnsignedLink(head)
+		return crdt.UnsignedLink(path), nil
 	}
 
-	err = p2p.store.PublishAddr(link, p2p.topics)
+	return crdt.SignedLink(path, privKeys)
+}
+
+func (p2p replicator) publishLink(link crdt.Link) bool {
+	err := p2p.store.PublishAddr(link, p2p.topics)
 
 	if err != nil {
 		log.Error("Failed to publish index to all topics: %s", err.Error())
-		return
+		return false
 	}
 
-	log.Info("Published index at %v", head)
+	return true
 }
 
 func (p2p replicator) sendReflectRequest() (api.Response, error) {
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/query"
+)
+
+// statementCache holds prepared statements by handle.  When the cache is
+// full, the oldest statement is dropped, and clients must prepare it again.
+type statementCache struct {
+	sync.Mutex
+	limit      int
+	statements map[string]*preparedStatement
+	order      []string
+}
+
+func makeStatementCache(limit int) *statementCache {
+	if limit <= 0 {
+		limit = __DEFAULT_STATEMENT_LIMIT
+	}
+
+	return &statementCache{
+		limit:      limit,
+		statements: map[string]*preparedStatement{},
+	}
+}
+
+// statementHandle is the same for the same query text, so preparing a
+// statement twice gives one cache entry.
+func statementHandle(source string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(source)))
+}
+
+// add caches the prepared statement.  A statement already cached with the
+// same source is kept.
+func (cache *statementCache) add(prepared *preparedStatement) string {
+	cache.Lock()
+	defer cache.Unlock()
+
+	handle := statementHandle(prepared.statement.Source)
+
+	if _, present := cache.statements[handle]; present {
+		return handle
+	}
+
+	if len(cache.order) >= cache.limit {
+		oldest := cache.order[0]
+		cache.order = cache.order[1:]
+		delete(cache.statements, oldest)
+		log.Debug("Dropped prepared statement: %s", oldest)
+	}
+
+	cache.statements[handle] = prepared
+	cache.order = append(cache.order, handle)
+
+	return handle
+}
+
+func (cache *statementCache) get(handle string) (*preparedStatement, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	prepared, present := cache.statements[handle]
+	return prepared, present
+}
+
+// preparedStatement keeps the query compiled from the last bind values and
+// its validation result.  Running the statement again with the same values
+// skips both.  Each bind returns a copy of the compiled query, so callers
+// never share it.
+type preparedStatement struct {
+	sync.Mutex
+	statement *query.Statement
+	bound     bool
+	variables []interface{}
+	compiled  *query.Query
+	invalid   error
+}
+
+func (prepared *preparedStatement) bind(variables []interface{}, context query.ValidationContext) (*query.Query, error) {
+	prepared.Lock()
+	defer prepared.Unlock()
+
+	if prepared.bound && sameVariables(prepared.variables, variables) {
+		return prepared.result()
+	}
+
+	compiled, err := prepared.statement.Bind(variables...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prepared.bound = true
+	prepared.variables = variables
+	prepared.compiled = compiled
+	prepared.invalid = compiled.Validate(context)
+
+	return prepared.result()
+}
+
+func (prepared *preparedStatement) result() (*query.Query, error) {
+	if prepared.invalid != nil {
+		return nil, prepared.invalid
+	}
+
+	return prepared.compiled.Copy(), nil
+}
+
+func sameVariables(variables, other []interface{}) bool {
+	if len(variables) != len(other) {
+		return false
+	}
+
+	for i, variable := range variables {
+		if variable != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+const __DEFAULT_STATEMENT_LIMIT = 1024
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bufio"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -88,3 +90,63 @@ func DecodeText(message proto.Message, r io.Reader) error {
 	text := string(bs)
 	return proto.UnmarshalText(text, message)
 }
+
+// EncodeDelimited writes the message prefixed with its length, so that a
+// stream of messages can be read back with DecodeDelimited.
+func EncodeDelimited(message proto.Message, w io.Writer) error {
+	const failMsg = "encodeDelimited failed"
+
+	bs, err := proto.Marshal(message)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	prefix := make([]byte, binary.MaxVarintLen64)
+	prefixLen := binary.PutUvarint(prefix, uint64(len(bs)))
+
+	err = WriteBytes(prefix[:prefixLen], w)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	err = WriteBytes(bs, w)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	return nil
+}
+
+// DecodeDelimited reads one message written by EncodeDelimited.  It returns
+// io.EOF when the stream ends cleanly before the next message.
+func DecodeDelimited(message proto.Message, r *bufio.Reader) error {
+	const failMsg = "decodeDelimited failed"
+
+	size, err := binary.ReadUvarint(r)
+
+	if err == io.EOF {
+		return err
+	}
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	if size > __MAX_DELIMITED_SIZE {
+		return fmt.Errorf("%s: message too large (%d bytes)", failMsg, size)
+	}
+
+	bs := make([]byte, size)
+	_, err = io.ReadFull(r, bs)
+
+	if err != nil {
+		return errors.Wrap(err, failMsg)
+	}
+
+	return proto.Unmarshal(bs, message)
+}
+
+const __MAX_DELIMITED_SIZE = 1 << 30
@@ -55,6 +55,85 @@ func TestRemoteNamespaceCoreReplicate(t *testing.T) {
 	testReflectNamespace(t, core, namespace)
 }
 
+func TestRemoteNamespaceCoreReplicateDelta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	const addrFull = crdt.IPFSPath("Addr Full")
+	const addrDelta = crdt.IPFSPath("Addr Delta")
+	const addrDeltaHead = crdt.IPFSPath("Addr Delta Head")
+	const addrUnknownDelta = crdt.IPFSPath("Addr Unknown Delta")
+	const addrUnknownHead = crdt.IPFSPath("Addr Unknown Head")
+
+	full := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+
+	deltaHead := full.JoinTable("Table B", crdt.UnsignedLink("Addr B"))
+	delta := crdt.MakeIndexDelta(deltaHead, addrDeltaHead, full, addrFull)
+
+	unknownHead := deltaHead.JoinTable("Table C", crdt.UnsignedLink("Addr C"))
+	unknownDelta := crdt.MakeIndexDelta(unknownHead, addrUnknownHead, crdt.EmptyIndex(), "Addr Unknown Base")
+
+	mockStore.EXPECT().CatIndex(addrFull).Return(full, nil).MinTimes(1)
+	mockStore.EXPECT().CatIndex(addrDelta).Return(delta, nil).MinTimes(1)
+	mockStore.EXPECT().CatIndex(addrUnknownDelta).Return(unknownDelta, nil).MinTimes(1)
+	mockStore.EXPECT().CatIndex(addrUnknownHead).Return(unknownHead, nil).MinTimes(1)
+	gomock.InOrder(
+		mockStore.EXPECT().AddIndex(matchIndex(full)).Return(crdt.IPFSPath("Addr Joined 1"), nil),
+		mockStore.EXPECT().AddIndex(matchIndex(delta)).Return(crdt.IPFSPath("Addr Joined 2"), nil),
+		mockStore.EXPECT().AddIndex(matchIndex(unknownHead)).Return(crdt.IPFSPath("Addr Joined 3"), nil),
+	)
+
+	core := makeRemote(mockStore)
+	defer core.Close()
+
+	resp := makeReplicateRequest(core, addrFull)
+	testutil.AssertNil(t, resp.Err)
+
+	// The base is known, so only the delta is merged.
+	resp = makeReplicateRequest(core, addrDelta)
+	testutil.AssertNil(t, resp.Err)
+
+	resp = makeReplicateRequest(core, addrDelta)
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Expected no updates", "Ok with no updates", resp.Msg)
+
+	// The base is unknown, so the full index at the delta head is merged.
+	resp = makeReplicateRequest(core, addrUnknownDelta)
+	testutil.AssertNil(t, resp.Err)
+}
+
+func TestRemoteNamespaceCoreReplicateObservesClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	const peerAddr = crdt.IPFSPath("Peer addr")
+
+	// The peer clock runs an hour fast.
+	peerIndex := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"cars": crdt.UnsignedLink("Namespace addr"),
+	})
+	peerIndex.Created = time.Now().Add(time.Hour).UnixNano()
+
+	mockStore.EXPECT().CatIndex(peerAddr).Return(peerIndex, nil).MinTimes(1)
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(crdt.IPFSPath("Joined addr"), nil).AnyTimes()
+
+	clock := crdt.MakeHybridClock("Test Node")
+	options := remoteOptions(mockStore, makeTestCache())
+	options.Clock = clock
+	core := service.MakeRemoteNamespaceCore(options)
+	defer core.Close()
+
+	resp := makeReplicateRequest(core, peerAddr)
+	testutil.AssertNil(t, resp.Err)
+
+	now := clock.Now()
+	testutil.Assert(t, "Expected clock past peer index", peerIndex.Created < now.Wall)
+}
+
 func makeReplicateRequest(core api.Core, path crdt.IPFSPath) api.Response {
 	links := []crdt.Link{crdt.UnsignedLink(path)}
 	request := api.Request{Type: api.API_REPLICATE, Replicate: links}
@@ -90,7 +169,7 @@ func TestRemoteNamespaceCoreRunQuery(t *testing.T) {
 		"cars": crdt.UnsignedLink(namespaceAddr),
 	})
 
-	mockStore.EXPECT().AddNamespace(matchNamespace(namespace)).Return(namespaceAddr, nil)
+	mockStore.EXPECT().AddNamespace(matchJoinedNamespace(namespace)).Return(namespaceAddr, nil)
 	mockStore.EXPECT().AddIndex(matchIndex(index)).Return(indexAddr, nil).MinTimes(1)
 	mockStore.EXPECT().CatIndex(indexAddr).Return(index, nil).MinTimes(1)
 	mockStore.EXPECT().CatNamespace(namespaceAddr).Return(namespace, nil)
@@ -103,6 +182,7 @@ func TestRemoteNamespaceCoreRunQuery(t *testing.T) {
 	testutil.AssertNil(t, joinResponse.Err)
 	err = remote.WriteMemoryImage()
 	testutil.AssertNil(t, err)
+
 	log.Debug("running select")
 	selectResponse := makeQueryRequest(remote, selectQuery)
 	log.Debug("completed queries")
@@ -110,6 +190,41 @@ func TestRemoteNamespaceCoreRunQuery(t *testing.T) {
 	testutil.Assert(t, "Unexpected namespace", namespace.Equals(selectResponse.Namespace))
 }
 
+func TestRemoteNamespaceCoreSelectRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	selectQuery, err := query.Compile(`select cars where str_eq(@key, "car10")`)
+	testutil.AssertNil(t, err)
+
+	namespace := crdt.MakeNamespace(map[crdt.TableName]crdt.Table{
+		"cars": crdt.MakeTable(map[crdt.RowName]crdt.Row{
+			"car10": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+				"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mr Blogs")}),
+			}),
+		}),
+	})
+
+	const namespaceAddr = crdt.IPFSPath("Namespace addr")
+	const indexAddr = crdt.IPFSPath("Index addr")
+
+	index := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"cars": crdt.UnsignedLink(namespaceAddr),
+	})
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(indexAddr, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(indexAddr).Return(index, nil).MinTimes(1)
+	mockStore.EXPECT().CatNamespaceRows(namespaceAddr, []crdt.RowName{"car10"}).Return(namespace, nil)
+
+	remote := loadRemote(mockStore, indexAddr)
+	defer remote.Close()
+
+	selectResponse := makeQueryRequest(remote, selectQuery)
+	testutil.AssertNil(t, selectResponse.Err)
+	testutil.Assert(t, "Unexpected namespace", namespace.Equals(selectResponse.Namespace))
+}
+
 func makeQueryRequest(core api.Core, query *query.Query) api.Response {
 	request := api.Request{Type: api.API_QUERY, Query: query}
 	command, err := request.MakeCommand()
@@ -176,6 +291,457 @@ func TestRemoteNamespaceCoreReflect(t *testing.T) {
 	testReflectNamespace(t, remote, joinedNamespace)
 }
 
+func TestRemoteNamespaceCoreReflectIndexLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrFirst := crdt.IPFSPath("Addr First")
+	addrSecond := crdt.IPFSPath("Addr Second")
+	created := time.Unix(1500000000, 0)
+
+	first := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+	first.Created = created.UnixNano()
+
+	second := first.JoinTable("Table B", crdt.UnsignedLink("Addr B"))
+	second.Parents = []crdt.IPFSPath{addrFirst}
+	second.Created = created.Add(time.Minute).UnixNano()
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrSecond, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrSecond).Return(second, nil).MinTimes(1)
+	mockStore.EXPECT().CatIndex(addrFirst).Return(first, nil).MinTimes(1)
+
+	remote := loadRemote(mockStore, addrSecond)
+	defer remote.Close()
+
+	expected := []api.IndexLogEntry{
+		api.IndexLogEntry{
+			Path:          addrSecond,
+			Created:       created.Add(time.Minute),
+			Parents:       []crdt.IPFSPath{addrFirst},
+			ChangedTables: []crdt.TableName{"Table B"},
+		},
+		api.IndexLogEntry{
+			Path:          addrFirst,
+			Created:       created,
+			ChangedTables: []crdt.TableName{"Table A"},
+		},
+	}
+
+	resp := reflectOnRemote(remote, api.REFLECT_INDEX_LOG)
+
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected history length", len(expected), len(resp.History))
+
+	for i, entry := range expected {
+		testutil.Assert(t, "Unexpected history entry", entry.Equals(resp.History[i]))
+	}
+
+	command, err := api.MakeIndexLogRequest(1).MakeCommand()
+	testutil.AssertNil(t, err)
+	command.Run(remote)
+
+	resp = readApiResponse(command)
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected limited history length", 1, len(resp.History))
+	testutil.Assert(t, "Unexpected limited history entry", expected[0].Equals(resp.History[0]))
+}
+
+func TestRemoteNamespaceCoreTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrPast := crdt.IPFSPath("Addr Past")
+	addrCheckout := crdt.IPFSPath("Addr Checkout")
+	addrPeer := crdt.IPFSPath("Addr Peer")
+
+	headIndex := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+	pastIndex := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table B": crdt.UnsignedLink("Addr B"),
+	})
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrCheckout, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrHead).Return(headIndex, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPast).Return(pastIndex, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPeer).Return(headIndex, nil)
+
+	remote := loadRemote(mockStore, addrHead)
+	defer remote.Close()
+
+	resp := tagOnRemote(remote, api.TagRequest{Command: api.TAG_CREATE, Name: "current"})
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected tagged path", addrHead, resp.Path)
+
+	resp = tagOnRemote(remote, api.TagRequest{Command: api.TAG_CREATE, Name: "past", Path: addrPast})
+	testutil.AssertNil(t, resp.Err)
+
+	expectedTags := []api.Tag{
+		api.Tag{Name: "current", Path: addrHead},
+		api.Tag{Name: "past", Path: addrPast},
+	}
+
+	resp = tagOnRemote(remote, api.TagRequest{Command: api.TAG_LIST})
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected tags", expectedTags, resp.Tags)
+
+	resp = tagOnRemote(remote, api.TagRequest{Command: api.TAG_CHECKOUT, Name: "past"})
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected checkout path", addrCheckout, resp.Path)
+
+	testReflectHead(t, remote, addrCheckout)
+	testReflectIndex(t, remote, pastIndex)
+
+	// Peers do not undo the checkout.
+	resp = makeReplicateRequest(remote, addrPeer)
+	testutil.AssertNil(t, resp.Err)
+
+	testReflectIndex(t, remote, pastIndex)
+
+	resp = tagOnRemote(remote, api.TagRequest{Command: api.TAG_CHECKOUT, Name: "missing"})
+	testutil.AssertNonNil(t, resp.Err)
+}
+
+func TestRemoteNamespaceCoreRevert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrTarget := crdt.IPFSPath("Addr Target")
+	addrRevert := crdt.IPFSPath("Addr Revert")
+	addrPeer := crdt.IPFSPath("Addr Peer")
+
+	target := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+	head := target.JoinTable("Table B", crdt.UnsignedLink("Addr Garbage"))
+	peer := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table B": crdt.UnsignedLink("Addr Garbage"),
+		"Table C": crdt.UnsignedLink("Addr C"),
+	})
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrRevert, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrHead).Return(head, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrTarget).Return(target, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPeer).Return(peer, nil)
+
+	remote := loadRemote(mockStore, addrHead)
+	defer remote.Close()
+
+	command, err := api.MakeRevertRequest(addrTarget).MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+	resp := readApiResponse(command)
+
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected revert path", addrRevert, resp.Path)
+
+	testReflectHead(t, remote, addrRevert)
+	testReflectIndex(t, remote, target)
+
+	resp = makeReplicateRequest(remote, addrPeer)
+	testutil.AssertNil(t, resp.Err)
+
+	expected := target.JoinTable("Table C", crdt.UnsignedLink("Addr C"))
+	testReflectIndex(t, remote, expected)
+}
+
+func TestRemoteNamespaceCoreRevertRestart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrTarget := crdt.IPFSPath("Addr Target")
+	addrRevert := crdt.IPFSPath("Addr Revert")
+	addrPeer := crdt.IPFSPath("Addr Peer")
+
+	target := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table A": crdt.UnsignedLink("Addr A"),
+	})
+	head := target.JoinTable("Table B", crdt.UnsignedLink("Addr Garbage"))
+	peer := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		"Table B": crdt.UnsignedLink("Addr Garbage"),
+		"Table C": crdt.UnsignedLink("Addr C"),
+	})
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrRevert, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrHead).Return(head, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrTarget).Return(target, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrRevert).Return(target, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPeer).Return(peer, nil)
+
+	dataCache := makeTestCache()
+	err := dataCache.SetHead(addrHead)
+	panicOnBadInit(err)
+
+	options := remoteOptions(mockStore, dataCache)
+	remote := service.MakeRemoteNamespaceCore(options)
+
+	command, err := api.MakeRevertRequest(addrTarget).MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+	resp := readApiResponse(command)
+	testutil.AssertNil(t, resp.Err)
+
+	remote.Close()
+
+	// The rebuilt service reads the quarantine from the cache.
+	restarted := service.MakeRemoteNamespaceCore(options)
+	defer restarted.Close()
+
+	testReflectHead(t, restarted, addrRevert)
+
+	resp = makeReplicateRequest(restarted, addrPeer)
+	testutil.AssertNil(t, resp.Err)
+
+	expected := target.JoinTable("Table C", crdt.UnsignedLink("Addr C"))
+	testReflectIndex(t, restarted, expected)
+}
+
+func TestRemoteNamespaceCoreBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrBatch := crdt.IPFSPath("Addr Batch")
+	addrBatchIndex := crdt.IPFSPath("Addr Batch Index")
+
+	sources := []string{
+		`join cars rows (@key=car1, driver="Mr Fast")`,
+		`join drivers rows (@key=driver1, name="Mr Fast")`,
+		`join cars rows (@key=car2, driver="Mrs Faster")`,
+	}
+
+	queries := make([]*query.Query, len(sources))
+	for i, source := range sources {
+		q, err := query.Compile(source)
+		panicOnBadInit(err)
+		queries[i] = q
+	}
+
+	cars := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"car1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mr Fast")}),
+		}),
+		"car2": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mrs Faster")}),
+		}),
+	})
+	drivers := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"driver1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"name": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mr Fast")}),
+		}),
+	})
+
+	namespace := crdt.EmptyNamespace().JoinTable("cars", cars).JoinTable("drivers", drivers)
+	index := crdt.EmptyIndex().JoinNamespace(crdt.UnsignedLink(addrBatch), namespace)
+
+	mockStore.EXPECT().CatIndex(addrHead).Return(crdt.EmptyIndex(), nil).AnyTimes()
+	mockStore.EXPECT().AddNamespace(matchJoinedNamespace(namespace)).Return(addrBatch, nil)
+	mockStore.EXPECT().AddIndex(matchIndex(index)).Return(addrBatchIndex, nil)
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrHead, nil).AnyTimes()
+
+	remote := loadRemote(mockStore, addrHead)
+	defer remote.Close()
+
+	resp := batchOnRemote(remote, queries)
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected batch path", addrBatchIndex, resp.Path)
+
+	testReflectIndex(t, remote, index)
+
+	// No write when a query in the batch fails.
+	invalid, err := query.Compile(`join cars signed "UnknownKey" rows (@key=car3, driver="Mr Slow")`)
+	panicOnBadInit(err)
+
+	resp = batchOnRemote(remote, []*query.Query{queries[0], invalid})
+	testutil.AssertNonNil(t, resp.Err)
+
+	testReflectIndex(t, remote, index)
+}
+
+func TestRemoteNamespaceCoreCompact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := NewMockRemoteStore(ctrl)
+
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrPeer := crdt.IPFSPath("Addr Peer")
+	addrA := crdt.IPFSPath("Addr A")
+	addrB := crdt.IPFSPath("Addr B")
+	addrC := crdt.IPFSPath("Addr C")
+	addrCompact := crdt.IPFSPath("Addr Compact")
+	addrCompactIndex := crdt.IPFSPath("Addr Compact Index")
+
+	carOne := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+		"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mr Fast")}),
+	})
+	carOneMileage := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+		"mileage": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("10")}),
+	})
+	carTwo := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+		"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mrs Faster")}),
+	})
+	drivers := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"driver1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"name": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Mr Fast")}),
+		}),
+	})
+
+	namespaceA := crdt.EmptyNamespace().JoinTable("cars", crdt.MakeTable(map[crdt.RowName]crdt.Row{"car1": carOne}))
+	namespaceB := crdt.EmptyNamespace().JoinTable("cars", crdt.MakeTable(map[crdt.RowName]crdt.Row{"car2": carTwo})).JoinTable("drivers", drivers)
+	namespaceC := crdt.EmptyNamespace().JoinTable("cars", crdt.MakeTable(map[crdt.RowName]crdt.Row{"car1": carOneMileage}))
+
+	compactedCars := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"car1": carOne.JoinRow(carOneMileage),
+		"car2": carTwo,
+	})
+	compactedNamespace := crdt.EmptyNamespace().JoinTable("cars", compactedCars)
+
+	head := crdt.EmptyIndex().JoinTable("cars", crdt.UnsignedLink(addrA), crdt.UnsignedLink(addrB), crdt.UnsignedLink(addrC))
+	head = head.JoinTable("drivers", crdt.UnsignedLink(addrB))
+
+	expected := crdt.EmptyIndex().JoinTable("cars", crdt.UnsignedLink(addrCompact))
+	expected = expected.JoinTable("drivers", crdt.UnsignedLink(addrB))
+
+	mockStore.EXPECT().CatIndex(addrHead).Return(head, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPeer).Return(head, nil)
+	mockStore.EXPECT().CatNamespace(addrA).Return(namespaceA, nil)
+	mockStore.EXPECT().CatNamespace(addrB).Return(namespaceB, nil)
+	mockStore.EXPECT().CatNamespace(addrC).Return(namespaceC, nil)
+	mockStore.EXPECT().AddNamespace(matchNamespace(compactedNamespace)).Return(addrCompact, nil)
+	mockStore.EXPECT().AddIndex(matchIndex(expected)).Return(addrCompactIndex, nil)
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrHead, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrCompactIndex).Return(expected, nil).AnyTimes()
+
+	dataCache := makeTestCache()
+	err := dataCache.SetHead(addrHead)
+	panicOnBadInit(err)
+
+	options := remoteOptions(mockStore, dataCache)
+	remote := service.MakeRemoteNamespaceCore(options)
+
+	resp := compactOnRemote(remote)
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected compact path", addrCompactIndex, resp.Path)
+
+	testReflectHead(t, remote, addrCompactIndex)
+	testReflectIndex(t, remote, expected)
+
+	// Nothing left to compact.
+	resp = compactOnRemote(remote)
+	testutil.AssertNil(t, resp.Err)
+	testutil.Assert(t, "Unexpected compact path", crdt.IsNilPath(resp.Path))
+
+	// Peers do not bring back the compacted links, even after a restart.
+	remote.Close()
+	restarted := service.MakeRemoteNamespaceCore(options)
+	defer restarted.Close()
+
+	resp = makeReplicateRequest(restarted, addrPeer)
+	testutil.AssertNil(t, resp.Err)
+
+	testReflectIndex(t, restarted, expected)
+}
+
+func TestRemoteNamespaceCoreSelectAfterDelete(t *testing.T) {
+	remote := makeRemote(makeMemoryRemoteStore())
+	defer remote.Close()
+
+	const carCount = 6
+
+	for i := 0; i < carCount; i++ {
+		source := fmt.Sprintf(`join cars rows (@key=car%d, driver="Driver %d")`, i, i)
+		resp := queryOnRemote(remote, source)
+		testutil.AssertNil(t, resp.Err)
+	}
+
+	// Each tombstone is in a different namespace to the point it removes.
+	for i := 0; i < carCount; i++ {
+		source := fmt.Sprintf("delete cars rows (@key=car%d)", i)
+		resp := queryOnRemote(remote, source)
+		testutil.AssertNil(t, resp.Err)
+	}
+
+	sources := []string{
+		"select cars",
+		"select cars limit 2",
+		"select cars limit 2 offset 1",
+		`select cars where not(str_eq(driver, "Driver 1")) limit 2`,
+	}
+
+	for _, source := range sources {
+		resp := queryOnRemote(remote, source)
+		testutil.AssertNil(t, resp.Err)
+		testutil.AssertEquals(t, "Unexpected rows for: "+source, 0, len(resp.RowOrder))
+		testutil.Assert(t, "Unexpected namespace for: "+source, resp.Namespace.IsEmpty())
+	}
+}
+
+func TestRemoteNamespaceCoreDeleteOverwritten(t *testing.T) {
+	remote := makeRemote(makeMemoryRemoteStore())
+	defer remote.Close()
+
+	sources := []string{
+		`join cars lww rows (@key=car1, driver="old")`,
+		`join cars lww rows (@key=car1, driver="new")`,
+		"delete cars rows (@key=car1)",
+	}
+
+	for _, source := range sources {
+		resp := queryOnRemote(remote, source)
+		testutil.AssertNil(t, resp.Err)
+	}
+
+	resp := queryOnRemote(remote, "select cars")
+	testutil.AssertNil(t, resp.Err)
+	testutil.AssertEquals(t, "Unexpected rows", 0, len(resp.RowOrder))
+	testutil.Assert(t, "Unexpected namespace", resp.Namespace.IsEmpty())
+}
+
+func queryOnRemote(remote api.Core, source string) api.Response {
+	q, err := query.Compile(source)
+	panicOnBadInit(err)
+
+	command, err := api.MakeQueryRequest(q).MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+
+	return readApiResponse(command)
+}
+
+func compactOnRemote(remote api.Core) api.Response {
+	command, err := api.MakeCompactRequest().MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+
+	return readApiResponse(command)
+}
+
+func batchOnRemote(remote api.Core, queries []*query.Query) api.Response {
+	command, err := api.MakeBatchRequest(queries).MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+
+	return readApiResponse(command)
+}
+
+func tagOnRemote(remote api.Core, tag api.TagRequest) api.Response {
+	command, err := api.MakeTagRequest(tag).MakeCommand()
+	panicOnBadInit(err)
+	command.Run(remote)
+
+	return readApiResponse(command)
+}
+
 // FIXME test error path
 func testReflectHead(t *testing.T, remote api.Core, expected crdt.IPFSPath) {
 	resp := reflectOnRemote(remote, api.REFLECT_HEAD_PATH)
@@ -285,6 +851,47 @@ func TestRemoteNamespaceCoreLoadTraverseSuccess(t *testing.T) {
 	}
 }
 
+func TestRemoteNamespaceCoreLoadTraverseIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockRemoteStore(ctrl)
+	mockSearcher := NewMockNamespaceSearcher(ctrl)
+	addrA := crdt.IPFSPath("Addr A")
+	addrHead := crdt.IPFSPath("Addr Head")
+	addrPast := crdt.IPFSPath("Addr Past")
+
+	table := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point A")}),
+		}),
+	})
+
+	const tableName = "Table A"
+
+	namespace := crdt.EmptyNamespace().JoinTable(tableName, table)
+	pastIndex := crdt.MakeIndex(map[crdt.TableName]crdt.Link{
+		tableName: crdt.UnsignedLink(addrA),
+	})
+
+	keepReading := api.TraversalUpdate{More: true}
+
+	mockStore.EXPECT().AddIndex(gomock.Any()).Return(addrHead, nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrHead).Return(crdt.EmptyIndex(), nil).AnyTimes()
+	mockStore.EXPECT().CatIndex(addrPast).Return(pastIndex, nil)
+	mockStore.EXPECT().CatNamespace(addrA).Return(namespace, nil)
+
+	mockSearcher.EXPECT().Search(pastIndex).Return([]crdt.Link{crdt.UnsignedLink(addrA)})
+	mockSearcher.EXPECT().ReadSearchResult(matchNamespaceResult(namespace)).Return(keepReading)
+
+	remote := loadRemote(mockStore, addrHead)
+	defer remote.Close()
+
+	lterr := remote.LoadTraverseIndex(addrPast, mockSearcher)
+
+	testutil.AssertNil(t, lterr)
+}
+
 func TestRemoteNamespaceCoreLoadTraverseFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -505,6 +1112,51 @@ func (matcher nsmatcher) String() string {
 	return fmt.Sprintf("matches Namespace: %v", matcher.ns)
 }
 
+// joinedmatcher ignores the tags that queries put on joined points.
+type joinedmatcher struct {
+	ns crdt.Namespace
+}
+
+func matchJoinedNamespace(ns crdt.Namespace) gomock.Matcher {
+	return joinedmatcher{ns}
+}
+
+func (matcher joinedmatcher) Matches(v interface{}) bool {
+	other, ok := v.(crdt.Namespace)
+
+	if !ok {
+		return false
+	}
+
+	untagged, ok := untagNamespace(other)
+	return ok && matcher.ns.Equals(untagged)
+}
+
+func (matcher joinedmatcher) String() string {
+	return fmt.Sprintf("matches joined Namespace: %v", matcher.ns)
+}
+
+// untagNamespace removes the tags from points, and is false if any point was
+// not tagged.
+func untagNamespace(ns crdt.Namespace) (crdt.Namespace, bool) {
+	untagged := crdt.EmptyNamespace()
+	ok := true
+
+	ns.ForeachEntry(func(t crdt.TableName, r crdt.RowName, e crdt.EntryName, entry crdt.Entry) {
+		points := make([]crdt.Point, len(entry.Set))
+
+		for i, point := range entry.Set {
+			ok = ok && !point.Tag().IsZero()
+			points[i] = point.Tagged(crdt.Timestamp{})
+		}
+
+		row := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{e: crdt.MakeEntry(points)})
+		untagged = untagged.JoinTable(t, crdt.MakeTable(map[crdt.RowName]crdt.Row{r: row}))
+	})
+
+	return untagged, ok
+}
+
 type indexmatcher struct {
 	index crdt.Index
 }
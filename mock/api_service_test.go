@@ -1,6 +1,9 @@
 package mock_godless
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -78,6 +81,233 @@ func TestApiQuery(t *testing.T) {
 	validateResponseCh(t, respch)
 }
 
+func TestApiPreparedStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockCore(ctrl)
+
+	const source = "select things where str_eq(stuff, ?) limit ?"
+	first, err := query.Compile(source, "Hello", 1)
+	testutil.AssertNil(t, err)
+	second, err := query.Compile(source, "World", 2)
+	testutil.AssertNil(t, err)
+
+	gomock.InOrder(
+		mock.EXPECT().RunQuery(queryMatcher{first}, commandMatcher{}).Do(runQueryStub),
+		mock.EXPECT().RunQuery(queryMatcher{second}, commandMatcher{}).Do(runQueryStub),
+		mock.EXPECT().RunQuery(queryMatcher{second}, commandMatcher{}).Do(runQueryStub),
+	)
+	mock.EXPECT().Close()
+
+	service, errch := launchAPI(mock)
+	defer tidyApi(t, service, errch)
+
+	respch, err := service.Call(api.MakePrepareRequest(source))
+	testutil.AssertNil(t, err)
+	resp := validateResponseCh(t, respch)
+	testutil.Assert(t, "Expected statement handle", resp.Statement != "")
+
+	handle := resp.Statement
+
+	respch, err = service.Call(api.MakePrepareRequest(source))
+	testutil.AssertNil(t, err)
+	resp = validateResponseCh(t, respch)
+	testutil.AssertEquals(t, "Unexpected statement handle", handle, resp.Statement)
+
+	bindings := [][]interface{}{
+		[]interface{}{"Hello", 1},
+		[]interface{}{"World", 2},
+		[]interface{}{"World", 2},
+	}
+
+	for _, variables := range bindings {
+		respch, err = service.Call(api.MakeExecuteRequest(handle, variables...))
+		testutil.AssertNil(t, err)
+		validateResponseCh(t, respch)
+	}
+
+	_, err = service.Call(api.MakeExecuteRequest(handle, "Hello"))
+	testutil.AssertNonNil(t, err)
+
+	_, err = service.Call(api.MakeExecuteRequest("unknown", "Hello", 1))
+	testutil.AssertNonNil(t, err)
+
+	_, err = service.Call(api.MakePrepareRequest("select things where"))
+	testutil.AssertNonNil(t, err)
+
+	_, err = service.Call(api.MakePrepareRequest("select things where no_such_function(stuff)"))
+	testutil.AssertNonNil(t, err)
+}
+
+func TestApiPrepareInvalidNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockCore(ctrl)
+
+	const source = "select things where str_eq(stuff, \"Hello\")"
+	mock.EXPECT().RunQuery(gomock.Any(), commandMatcher{}).Do(runQueryStub)
+	mock.EXPECT().Close()
+
+	options := apiOptions(mock, 1)
+	options.StatementLimit = 1
+	service, errch := service.LaunchQueuedApiService(options)
+	defer tidyApi(t, service, errch)
+
+	respch, err := service.Call(api.MakePrepareRequest(source))
+	testutil.AssertNil(t, err)
+	handle := validateResponseCh(t, respch).Statement
+
+	_, err = service.Call(api.MakePrepareRequest("select things where no_such_function(stuff)"))
+	testutil.AssertNonNil(t, err)
+
+	respch, err = service.Call(api.MakeExecuteRequest(handle))
+	testutil.AssertNil(t, err)
+	validateResponseCh(t, respch)
+}
+
+func TestApiExecuteParallel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockCore(ctrl)
+
+	const source = "join things rows (@key=??, name=?)"
+	const executeCount = 50
+
+	checkQuery := func(q *query.Query, command api.Command) {
+		row := q.Join.Rows[0]
+		expected := strings.Replace(string(row.RowKey), "thing", "name", 1)
+		actual := string(row.Entries["name"])
+
+		if expected != actual {
+			t.Errorf("Expected name %s for row %s but received %s", expected, row.RowKey, actual)
+		}
+
+		runQueryStub(q, command)
+	}
+
+	mock.EXPECT().RunQuery(gomock.Any(), commandMatcher{}).Times(executeCount).Do(checkQuery)
+	mock.EXPECT().Close()
+
+	service, errch := launchConcurrentAPI(mock, executeCount)
+	defer tidyApi(t, service, errch)
+
+	respch, err := service.Call(api.MakePrepareRequest(source))
+	testutil.AssertNil(t, err)
+	handle := validateResponseCh(t, respch).Statement
+
+	wg := &sync.WaitGroup{}
+	wg.Add(executeCount)
+
+	for i := 0; i < executeCount; i++ {
+		rowKey := fmt.Sprintf("thing%d", i%10)
+		name := fmt.Sprintf("name%d", i%10)
+
+		go func() {
+			defer wg.Done()
+
+			respch, err := service.Call(api.MakeExecuteRequest(handle, rowKey, name))
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if resp := <-respch; resp.Err != nil {
+				t.Error(resp.Err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestApiWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockCore(ctrl)
+
+	query, err := query.Compile("select things where str_eq(stuff, \"Hello\")")
+	testutil.AssertNil(t, err)
+
+	rowA := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+		"stuff": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Hello")}),
+	})
+	rowB := crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+		"stuff": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Hello")}),
+		"other": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("World")}),
+	})
+
+	first := makeWatchResponse(map[crdt.RowName]crdt.Row{"a": rowA}, "a")
+	second := makeWatchResponse(map[crdt.RowName]crdt.Row{"a": rowA, "b": rowB}, "a", "b")
+	third := makeWatchResponse(map[crdt.RowName]crdt.Row{"b": rowB}, "b")
+
+	secondUpdate := makeWatchResponse(map[crdt.RowName]crdt.Row{"b": rowB}, "a", "b")
+	thirdUpdate := api.RESPONSE_QUERY
+	thirdUpdate.Namespace = crdt.EmptyNamespace()
+	thirdUpdate.RowOrder = third.RowOrder
+	thirdUpdate.RemovedRows = []crdt.RowName{"a"}
+
+	changes := make(chan struct{})
+	stop := make(chan struct{})
+
+	mock.EXPECT().WatchIndex(gomock.Any()).Return((<-chan struct{})(changes))
+	gomock.InOrder(
+		mock.EXPECT().RunQuery(query, commandMatcher{}).Do(writeResponseStub(first)),
+		mock.EXPECT().RunQuery(query, commandMatcher{}).Do(writeResponseStub(first)),
+		mock.EXPECT().RunQuery(query, commandMatcher{}).Do(writeResponseStub(second)),
+		mock.EXPECT().RunQuery(query, commandMatcher{}).Do(writeResponseStub(third)),
+	)
+	mock.EXPECT().Close()
+
+	service, errch := launchAPI(mock)
+	defer tidyApi(t, service, errch)
+
+	_, err = service.Call(api.MakeWatchRequest(query))
+	testutil.AssertNonNil(t, err)
+
+	respch, err := service.Watch(api.MakeWatchRequest(query), stop)
+	testutil.AssertNil(t, err)
+
+	resp := validateResponseCh(t, respch)
+	testutil.Assert(t, "Unexpected first response", first.Equals(resp))
+
+	// The unchanged result is not sent again.
+	changes <- struct{}{}
+	changes <- struct{}{}
+
+	// Only the new row is sent.
+	resp = validateResponseCh(t, respch)
+	testutil.Assert(t, "Unexpected second response", secondUpdate.Equals(resp))
+
+	changes <- struct{}{}
+
+	resp = validateResponseCh(t, respch)
+	testutil.Assert(t, "Unexpected third response", thirdUpdate.Equals(resp))
+
+	close(stop)
+
+	for range respch {
+		t.Error("Unexpected response after stop")
+	}
+}
+
+func makeWatchResponse(rows map[crdt.RowName]crdt.Row, rowOrder ...crdt.RowName) api.Response {
+	resp := api.RESPONSE_QUERY
+	resp.Namespace = crdt.EmptyNamespace().JoinTable("things", crdt.MakeTable(rows))
+	resp.RowOrder = rowOrder
+	return resp
+}
+
+func writeResponseStub(resp api.Response) func(*query.Query, api.Command) {
+	return func(q *query.Query, command api.Command) {
+		command.Response <- resp
+	}
+}
+
 func validateResponseCh(t *testing.T, respch <-chan api.Response) api.Response {
 	timeout := time.NewTimer(__TEST_TIMEOUT)
 
@@ -176,14 +406,17 @@ func launchAPI(core api.Core) (api.Service, <-chan error) {
 }
 
 func launchConcurrentAPI(core api.Core, queryLimit int) (api.Service, <-chan error) {
+	return service.LaunchQueuedApiService(apiOptions(core, queryLimit))
+}
+
+func apiOptions(core api.Core, queryLimit int) service.QueuedApiServiceOptions {
 	queue := cache.MakeResidentBufferQueue(__UNKNOWN_CACHE_SIZE)
-	options := service.QueuedApiServiceOptions{
+	return service.QueuedApiServiceOptions{
 		Core:       core,
 		Queue:      queue,
 		QueryLimit: queryLimit,
 		Validator:  api.StandardRequestValidator(),
 	}
-	return service.LaunchQueuedApiService(options)
 }
 
 type commandMatcher struct {
@@ -199,4 +432,18 @@ func (commandMatcher) Matches(v interface{}) bool {
 	return ok
 }
 
+type queryMatcher struct {
+	query *query.Query
+}
+
+func (matcher queryMatcher) String() string {
+	return "query equal to expected"
+}
+
+func (matcher queryMatcher) Matches(v interface{}) bool {
+	other, ok := v.(*query.Query)
+
+	return ok && matcher.query.Equals(other)
+}
+
 const __TEST_TIMEOUT = time.Second * 1
@@ -2,6 +2,8 @@ package mock_godless
 
 import (
 	"bytes"
+	"crypto"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"testing"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/johnny-morrice/godless/api"
 	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/datapeer"
 	"github.com/johnny-morrice/godless/internal/service"
 	"github.com/johnny-morrice/godless/internal/testutil"
 )
@@ -56,7 +59,7 @@ func TestContentAddressableRemoteStoreAddNamespaceSuccess(t *testing.T) {
 	ns := makeNamespaceForIPFS()
 
 	mock.EXPECT().IsUp().Return(true).AnyTimes()
-	mock.EXPECT().Add(gomock.Any()).Return(nsAddrText, nil)
+	mock.EXPECT().Add(gomock.Any()).Do(readAdded).Return(nsAddrText, nil)
 
 	addr, err := store.AddNamespace(ns)
 
@@ -95,7 +98,7 @@ func TestContentAddressableRemoteStoreAddIndexSuccess(t *testing.T) {
 	index := makeIndexForIPFS()
 
 	mock.EXPECT().IsUp().Return(true).AnyTimes()
-	mock.EXPECT().Add(gomock.Any()).Return(indexAddrText, nil)
+	mock.EXPECT().Add(gomock.Any()).Do(readAdded).Return(indexAddrText, nil)
 
 	addr, err := store.AddIndex(index)
 
@@ -145,6 +148,49 @@ func TestContentAddressableRemoteStoreCatNamespaceSuccess(t *testing.T) {
 	testutil.Assert(t, "Unexpected namespace", expected.Equals(actual))
 }
 
+// makeMemoryRemoteStore is a RemoteStore backed by a memory data peer.
+func makeMemoryRemoteStore() *service.ContentAddressableRemoteStore {
+	peerOptions := datapeer.ResidentMemoryStorageOptions{
+		Hash: crypto.SHA256,
+	}
+
+	return &service.ContentAddressableRemoteStore{
+		Shell: datapeer.MakeResidentMemoryDataPeer(peerOptions),
+	}
+}
+
+func TestContentAddressableRemoteStoreShardedNamespace(t *testing.T) {
+	store := makeMemoryRemoteStore()
+	store.ShardRows = 2
+
+	rows := map[crdt.RowName]crdt.Row{}
+	for i := 0; i < 64; i++ {
+		driver := crdt.PointText(fmt.Sprintf("Driver %d", i))
+		rows[crdt.RowName(fmt.Sprintf("car%d", i))] = crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"driver": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(driver)}),
+		})
+	}
+
+	expected := crdt.EmptyNamespace().JoinTable("cars", crdt.MakeTable(rows))
+
+	addr, err := store.AddNamespace(expected)
+	testutil.AssertNil(t, err)
+
+	actual, err := store.CatNamespace(addr)
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Unexpected namespace", expected.Equals(actual))
+
+	part, err := store.CatNamespaceRows(addr, []crdt.RowName{"car7"})
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Expected partial namespace", part.RowCount() < expected.RowCount())
+
+	table, err := part.GetTable("cars")
+	testutil.AssertNil(t, err)
+	row, err := table.GetRow("car7")
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Unexpected row", rows["car7"].Equals(row))
+}
+
 func TestContentAddressableRemoteStoreCatNamespaceFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -378,6 +424,12 @@ func TestContentAddressableRemoteStoreDisconnectFailure(t *testing.T) {
 	testutil.AssertNonNil(t, err)
 }
 
+// readAdded reads the added data, as a data peer does.
+func readAdded(r io.Reader) {
+	_, err := ioutil.ReadAll(r)
+	panicOnBadInit(err)
+}
+
 func makeNamespaceReaderForIPFS() io.ReadCloser {
 	ns := makeNamespaceForIPFS()
 	buff := &bytes.Buffer{}
@@ -32,6 +32,14 @@ func (_m *MockCore) EXPECT() *_MockCoreRecorder {
 	return _m.recorder
 }
 
+func (_m *MockCore) Batch(_param0 []*query.Query, _param1 api.Command) {
+	_m.ctrl.Call(_m, "Batch", _param0, _param1)
+}
+
+func (_mr *_MockCoreRecorder) Batch(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Batch", arg0, arg1)
+}
+
 func (_m *MockCore) Close() {
 	_m.ctrl.Call(_m, "Close")
 }
@@ -40,6 +48,14 @@ func (_mr *_MockCoreRecorder) Close() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Close")
 }
 
+func (_m *MockCore) Compact(_param0 api.Command) {
+	_m.ctrl.Call(_m, "Compact", _param0)
+}
+
+func (_mr *_MockCoreRecorder) Compact(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Compact", arg0)
+}
+
 func (_m *MockCore) Reflect(_param0 api.ReflectionType, _param1 api.Command) {
 	_m.ctrl.Call(_m, "Reflect", _param0, _param1)
 }
@@ -64,6 +80,32 @@ func (_mr *_MockCoreRecorder) RunQuery(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunQuery", arg0, arg1)
 }
 
+func (_m *MockCore) Revert(_param0 crdt.IPFSPath, _param1 api.Command) {
+	_m.ctrl.Call(_m, "Revert", _param0, _param1)
+}
+
+func (_mr *_MockCoreRecorder) Revert(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Revert", arg0, arg1)
+}
+
+func (_m *MockCore) Tag(_param0 api.TagRequest, _param1 api.Command) {
+	_m.ctrl.Call(_m, "Tag", _param0, _param1)
+}
+
+func (_mr *_MockCoreRecorder) Tag(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Tag", arg0, arg1)
+}
+
+func (_m *MockCore) WatchIndex(_param0 <-chan struct{}) <-chan struct{} {
+	ret := _m.ctrl.Call(_m, "WatchIndex", _param0)
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+func (_mr *_MockCoreRecorder) WatchIndex(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "WatchIndex", arg0)
+}
+
 func (_m *MockCore) WriteMemoryImage() error {
 	ret := _m.ctrl.Call(_m, "WriteMemoryImage")
 	ret0, _ := ret[0].(error)
@@ -139,6 +181,17 @@ func (_mr *_MockRemoteStoreRecorder) CatNamespace(arg0 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "CatNamespace", arg0)
 }
 
+func (_m *MockRemoteStore) CatNamespaceRows(_param0 crdt.IPFSPath, _param1 []crdt.RowName) (crdt.Namespace, error) {
+	ret := _m.ctrl.Call(_m, "CatNamespaceRows", _param0, _param1)
+	ret0, _ := ret[0].(crdt.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockRemoteStoreRecorder) CatNamespaceRows(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CatNamespaceRows", arg0, arg1)
+}
+
 func (_m *MockRemoteStore) Connect() error {
 	ret := _m.ctrl.Call(_m, "Connect")
 	ret0, _ := ret[0].(error)
@@ -222,6 +275,16 @@ func (_mr *_MockRemoteNamespaceRecorder) LoadTraverse(arg0 interface{}) *gomock.
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "LoadTraverse", arg0)
 }
 
+func (_m *MockRemoteNamespace) LoadTraverseIndex(_param0 crdt.IPFSPath, _param1 api.NamespaceSearcher) error {
+	ret := _m.ctrl.Call(_m, "LoadTraverseIndex", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockRemoteNamespaceRecorder) LoadTraverseIndex(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LoadTraverseIndex", arg0, arg1)
+}
+
 // Mock of NamespaceSearcher interface
 type MockNamespaceSearcher struct {
 	ctrl     *gomock.Controller
@@ -489,3 +552,14 @@ func (_m *MockService) CloseAPI() {
 func (_mr *_MockServiceRecorder) CloseAPI() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "CloseAPI")
 }
+
+func (_m *MockService) Watch(_param0 api.Request, _param1 <-chan struct{}) (<-chan api.Response, error) {
+	ret := _m.ctrl.Call(_m, "Watch", _param0, _param1)
+	ret0, _ := ret[0].(<-chan api.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockServiceRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Watch", arg0, arg1)
+}
@@ -31,6 +31,14 @@ func (_m *MockQueryVisitor) EXPECT() *_MockQueryVisitorRecorder {
 	return _m.recorder
 }
 
+func (_m *MockQueryVisitor) LeaveDelete(_param0 *query.QueryDelete) {
+	_m.ctrl.Call(_m, "LeaveDelete", _param0)
+}
+
+func (_mr *_MockQueryVisitorRecorder) LeaveDelete(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LeaveDelete", arg0)
+}
+
 func (_m *MockQueryVisitor) LeaveJoin(_param0 *query.QueryJoin) {
 	_m.ctrl.Call(_m, "LeaveJoin", _param0)
 }
@@ -63,6 +71,14 @@ func (_mr *_MockQueryVisitorRecorder) VisitAST(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VisitAST", arg0)
 }
 
+func (_m *MockQueryVisitor) VisitDelete(_param0 *query.QueryDelete) {
+	_m.ctrl.Call(_m, "VisitDelete", _param0)
+}
+
+func (_mr *_MockQueryVisitorRecorder) VisitDelete(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VisitDelete", arg0)
+}
+
 func (_m *MockQueryVisitor) VisitJoin(_param0 *query.QueryJoin) {
 	_m.ctrl.Call(_m, "VisitJoin", _param0)
 }
@@ -103,6 +119,14 @@ func (_mr *_MockQueryVisitorRecorder) VisitPublicKeyHash(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VisitPublicKeyHash", arg0)
 }
 
+func (_m *MockQueryVisitor) VisitRowDelete(_param0 int, _param1 *query.QueryRowDelete) {
+	_m.ctrl.Call(_m, "VisitRowDelete", _param0, _param1)
+}
+
+func (_mr *_MockQueryVisitorRecorder) VisitRowDelete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VisitRowDelete", arg0, arg1)
+}
+
 func (_m *MockQueryVisitor) VisitRowJoin(_param0 int, _param1 *query.QueryRowJoin) {
 	_m.ctrl.Call(_m, "VisitRowJoin", _param0, _param1)
 }
@@ -0,0 +1,351 @@
+package mock_godless
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/johnny-morrice/godless/api"
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/crypto"
+	"github.com/johnny-morrice/godless/internal/eval"
+	"github.com/johnny-morrice/godless/internal/testutil"
+	"github.com/johnny-morrice/godless/query"
+	"github.com/pkg/errors"
+)
+
+func TestRunQueryDeleteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	expectedResponse := api.RESPONSE_QUERY
+	expectedResponse.Path = indexAddr
+
+	query := &query.Query{
+		OpCode:   query.DELETE,
+		TableKey: MAIN_TABLE_KEY,
+		Delete: query.QueryDelete{
+			Rows: []query.QueryRowDelete{
+				query.QueryRowDelete{
+					RowKey: "Row A",
+				},
+				query.QueryRowDelete{
+					RowKey:  "Row B",
+					Entries: []crdt.EntryName{"Entry C"},
+				},
+				query.QueryRowDelete{
+					RowKey: "No such row",
+				},
+			},
+		},
+	}
+
+	table := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point A"), crdt.UnsignedPoint("Point D")}),
+			"Entry B": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point B")}),
+		}),
+		"Row B": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry C": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point C")}),
+		}),
+	})
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(feedDeleteNamespace)
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchTable(table)).Return(indexAddr, nil)
+
+	deleter := makeNamespaceTreeDelete(mock)
+	query.Visit(deleter)
+	resp := deleter.RunQuery()
+
+	if !expectedResponse.Equals(resp) {
+		t.Error("Expected", expectedResponse, "but was", resp)
+	}
+}
+
+func TestRunQueryDeleteNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	query := &query.Query{
+		OpCode:   query.DELETE,
+		TableKey: MAIN_TABLE_KEY,
+		Delete: query.QueryDelete{
+			Rows: []query.QueryRowDelete{
+				query.QueryRowDelete{
+					RowKey:  "Row B",
+					Entries: []crdt.EntryName{"No such entry"},
+				},
+			},
+		},
+	}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(feedDeleteNamespace)
+
+	deleter := makeNamespaceTreeDelete(mock)
+	query.Visit(deleter)
+	resp := deleter.RunQuery()
+
+	if !api.RESPONSE_QUERY.Equals(resp) {
+		t.Error("Expected", api.RESPONSE_QUERY, "but was", resp)
+	}
+}
+
+func TestRunQueryDeleteFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	failQuery := &query.Query{
+		OpCode:   query.DELETE,
+		TableKey: MAIN_TABLE_KEY,
+		Delete: query.QueryDelete{
+			Rows: []query.QueryRowDelete{
+				query.QueryRowDelete{
+					RowKey: "Row A",
+				},
+			},
+		},
+	}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(feedDeleteNamespace)
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, gomock.Any()).Return(crdt.NIL_PATH, errors.New("Expected error"))
+
+	deleter := makeNamespaceTreeDelete(mock)
+	failQuery.Visit(deleter)
+	resp := deleter.RunQuery()
+
+	if resp.Msg != api.RESPONSE_FAIL_MSG {
+		t.Error("Expected Msg error but received", resp.Msg)
+	}
+
+	if resp.Err == nil {
+		t.Error("Expected response Err")
+	}
+
+	if resp.Type != api.API_QUERY {
+		t.Error("Unexpected response Type")
+	}
+}
+
+func TestRunQueryDeleteInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	invalidQueries := []*query.Query{
+		// Basically wrong.
+		&query.Query{},
+		&query.Query{OpCode: query.JOIN},
+	}
+
+	for _, q := range invalidQueries {
+		deleter := makeNamespaceTreeDelete(mock)
+		q.Visit(deleter)
+		resp := deleter.RunQuery()
+
+		if resp.Msg != "error" {
+			t.Error("Expected Msg error but received", resp.Msg)
+		}
+
+		if resp.Err == nil {
+			t.Error("Expected response Err")
+		}
+	}
+}
+
+func TestRunQueryDeleteThenRejoin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	joinQuery := &query.Query{
+		OpCode:   query.JOIN,
+		TableKey: MAIN_TABLE_KEY,
+		Join: query.QueryJoin{
+			Rows: []query.QueryRowJoin{
+				query.QueryRowJoin{
+					RowKey:  "Row A",
+					Entries: map[crdt.EntryName]crdt.PointText{"Entry A": "Point A"},
+				},
+			},
+		},
+	}
+
+	deleteQuery := &query.Query{
+		OpCode:   query.DELETE,
+		TableKey: MAIN_TABLE_KEY,
+		Delete: query.QueryDelete{
+			Rows: []query.QueryRowDelete{
+				query.QueryRowDelete{RowKey: "Row A"},
+			},
+		},
+	}
+
+	namespace := crdt.EmptyNamespace()
+	recordTable := func(tableKey crdt.TableName, table crdt.Table) {
+		namespace = namespace.JoinTable(tableKey, table)
+	}
+
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, gomock.Any()).Return(indexAddr, nil).Do(recordTable).Times(3)
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(reader api.SearchResultTraverser) {
+		reader.ReadSearchResult(api.SearchResult{Namespace: namespace})
+	})
+
+	clock := crdt.MakeHybridClock("Test Node")
+
+	joiner := makeClockedNamespaceTreeJoin(mock, clock)
+	joinQuery.Visit(joiner)
+	testutil.AssertNil(t, joiner.RunQuery().Err)
+
+	deleter := makeNamespaceTreeDelete(mock)
+	deleteQuery.Visit(deleter)
+	testutil.AssertNil(t, deleter.RunQuery().Err)
+
+	testutil.Assert(t, "Expected removal", namespace.ApplyTombstones().IsEmpty())
+
+	rejoiner := makeClockedNamespaceTreeJoin(mock, clock)
+	joinQuery.Visit(rejoiner)
+	testutil.AssertNil(t, rejoiner.RunQuery().Err)
+
+	var values []crdt.Point
+	namespace.ApplyTombstones().ForeachEntry(func(t crdt.TableName, r crdt.RowName, e crdt.EntryName, entry crdt.Entry) {
+		values = append(values, entry.GetValues()...)
+	})
+
+	testutil.AssertLenEquals(t, 1, values)
+	testutil.Assert(t, "Unexpected value after rejoin", values[0].HasText("Point A"))
+}
+
+func TestRunQueryDeleteCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	shards := []crdt.CounterShard{
+		crdt.MakeCounterShard("Node A", 3),
+		crdt.MakeCounterShard("Node B", -1),
+	}
+
+	stored := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeCounterEntry(shards),
+		}),
+	}))
+
+	tombstones := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeCounterTombstoneEntry(shards),
+		}),
+	})
+
+	query := &query.Query{
+		OpCode:   query.DELETE,
+		TableKey: MAIN_TABLE_KEY,
+		Delete: query.QueryDelete{
+			Rows: []query.QueryRowDelete{
+				query.QueryRowDelete{RowKey: "Row A"},
+			},
+		},
+	}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(reader api.SearchResultTraverser) {
+		reader.ReadSearchResult(api.SearchResult{Namespace: stored})
+	})
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchTable(tombstones)).Return(indexAddr, nil)
+
+	deleter := makeNamespaceTreeDelete(mock)
+	query.Visit(deleter)
+	resp := deleter.RunQuery()
+
+	testutil.AssertNil(t, resp.Err)
+	testutil.Assert(t, "Expected counter removal", stored.JoinTable(MAIN_TABLE_KEY, tombstones).ApplyTombstones().IsEmpty())
+}
+
+func TestRunQuerySelectTombstones(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	tombstones := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point A"), crdt.UnsignedPoint("Point D")}),
+			"Entry B": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point B")}),
+		}),
+		"Row B": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry C": crdt.MakeTombstoneEntry([]crdt.Point{crdt.UnsignedPoint("Point C")}),
+		}),
+	}))
+
+	// Tombstones arrive in a separate namespace to the points they remove.
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(reader api.SearchResultTraverser) {
+		feedDeleteNamespace(reader)
+		reader.ReadSearchResult(api.SearchResult{Namespace: tombstones})
+	})
+
+	selectQuery := &query.Query{
+		OpCode:   query.SELECT,
+		TableKey: MAIN_TABLE_KEY,
+	}
+
+	expectedResponse := api.RESPONSE_QUERY
+	expectedResponse.Namespace = crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row B": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry E": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point E")}),
+		}),
+	}))
+	expectedResponse.RowOrder = []crdt.RowName{"Row B"}
+
+	selector := makeNamespaceTreeSelect(mock)
+	selectQuery.Visit(selector)
+	resp := selector.RunQuery()
+
+	if !expectedResponse.Equals(resp) {
+		t.Error("Expected", expectedResponse, "but was", resp)
+	}
+}
+
+func feedDeleteNamespace(reader api.SearchResultTraverser) {
+	table := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point A"), crdt.UnsignedPoint("Point D")}),
+			"Entry B": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point B")}),
+		}),
+		"Row B": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry C": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point C")}),
+			"Entry E": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point E")}),
+		}),
+	})
+
+	result := api.SearchResult{
+		Namespace: crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, table),
+	}
+	reader.ReadSearchResult(result)
+}
+
+func makeClockedNamespaceTreeJoin(namespace api.RemoteNamespace, clock *crdt.HybridClock) *eval.NamespaceTreeJoin {
+	options := eval.JoinOptions{
+		Namespace: namespace,
+		KeyStore:  &crypto.KeyStore{},
+		Clock:     clock,
+	}
+	return eval.MakeNamespaceTreeJoin(options)
+}
+
+func makeNamespaceTreeDelete(namespace api.RemoteNamespace) *eval.NamespaceTreeDelete {
+	keyStore := &crypto.KeyStore{}
+	return eval.MakeNamespaceTreeDelete(namespace, keyStore)
+}
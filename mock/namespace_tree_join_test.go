@@ -1,7 +1,9 @@
 package mock_godless
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/johnny-morrice/godless/api"
@@ -63,7 +65,7 @@ func TestRunQueryJoinSuccess(t *testing.T) {
 			"Entry C": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point C")}),
 		}),
 	})
-	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchTable(table)).Return(indexAddr, nil)
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchJoinedTable(table)).Return(indexAddr, nil)
 
 	joiner := makeNamespaceTreeJoin(mock)
 	query.Visit(joiner)
@@ -139,7 +141,11 @@ func TestRunQueryJoinSigned(t *testing.T) {
 	})
 	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchSignedTable(table)).Return(indexAddr, nil)
 
-	joiner := eval.MakeNamespaceTreeJoin(mock, keyStore)
+	options := eval.JoinOptions{
+		Namespace: mock,
+		KeyStore:  keyStore,
+	}
+	joiner := eval.MakeNamespaceTreeJoin(options)
 	query.Visit(joiner)
 	resp := joiner.RunQuery()
 
@@ -148,6 +154,249 @@ func TestRunQueryJoinSigned(t *testing.T) {
 	}
 }
 
+func TestRunQueryJoinLastWriterWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	queries := []*query.Query{
+		&query.Query{
+			OpCode:   query.JOIN,
+			TableKey: MAIN_TABLE_KEY,
+			Join: query.QueryJoin{
+				LWW: true,
+				Rows: []query.QueryRowJoin{
+					query.QueryRowJoin{
+						RowKey: "Row A",
+						Entries: map[crdt.EntryName]crdt.PointText{
+							"Entry A": "Point A",
+						},
+					},
+				},
+			},
+		},
+		&query.Query{
+			OpCode:   query.JOIN,
+			TableKey: ALT_TABLE_KEY,
+			Join: query.QueryJoin{
+				Rows: []query.QueryRowJoin{
+					query.QueryRowJoin{
+						RowKey: "Row A",
+						Entries: map[crdt.EntryName]crdt.PointText{
+							"Entry A": "Point A",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var stamps []crdt.Timestamp
+	recordStamp := func(tableKey crdt.TableName, table crdt.Table) {
+		table.ForeachEntry(func(rowName crdt.RowName, entryName crdt.EntryName, entry crdt.Entry) {
+			for _, point := range entry.GetValues() {
+				stamps = append(stamps, point.Timestamp())
+			}
+		})
+	}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Times(2)
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, gomock.Any()).Return(indexAddr, nil).Do(recordStamp)
+	mock.EXPECT().JoinTable(ALT_TABLE_KEY, gomock.Any()).Return(indexAddr, nil).Do(recordStamp)
+
+	options := eval.JoinOptions{
+		Namespace: mock,
+		KeyStore:  &crypto.KeyStore{},
+		Clock:     crdt.MakeHybridClock("Test Node"),
+		LWWTables: []crdt.TableName{ALT_TABLE_KEY},
+	}
+
+	for _, q := range queries {
+		joiner := eval.MakeNamespaceTreeJoin(options)
+		q.Visit(joiner)
+		resp := joiner.RunQuery()
+
+		testutil.AssertNil(t, resp.Err)
+	}
+
+	testutil.AssertLenEquals(t, 2, stamps)
+
+	for _, stamp := range stamps {
+		testutil.AssertEquals(t, "Unexpected node", "Test Node", stamp.Node)
+	}
+
+	testutil.Assert(t, "Expected increasing timestamps", stamps[0].Less(stamps[1]))
+}
+
+func TestRunQueryJoinObservesRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	query := &query.Query{
+		OpCode:   query.JOIN,
+		TableKey: MAIN_TABLE_KEY,
+		Join: query.QueryJoin{
+			LWW: true,
+			Rows: []query.QueryRowJoin{
+				query.QueryRowJoin{
+					RowKey: "Row A",
+					Entries: map[crdt.EntryName]crdt.PointText{
+						"Entry A": "Point B",
+					},
+				},
+			},
+		},
+	}
+
+	// A peer with a fast clock wrote the point we overwrite.
+	peerStamp := crdt.Timestamp{Wall: time.Now().Add(time.Hour).UnixNano(), Node: "Peer Node"}
+	stored := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Point A").Stamp(peerStamp)}),
+		}),
+	}))
+
+	var written crdt.Table
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(searcher api.NamespaceSearcher) {
+		rowSearch, ok := searcher.(api.RowSearch)
+		testutil.Assert(t, "Expected row search", ok)
+		testutil.AssertEquals(t, "Unexpected rows", []crdt.RowName{"Row A"}, rowSearch.SearchRows())
+		searcher.ReadSearchResult(api.SearchResult{Namespace: stored})
+	})
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, gomock.Any()).Return(indexAddr, nil).Do(func(tableKey crdt.TableName, table crdt.Table) {
+		written = table
+	})
+
+	joiner := makeNamespaceTreeJoin(mock)
+	query.Visit(joiner)
+	resp := joiner.RunQuery()
+	testutil.AssertNil(t, resp.Err)
+
+	joined := stored.JoinTable(MAIN_TABLE_KEY, written).ApplyTombstones()
+	table, err := joined.GetTable(MAIN_TABLE_KEY)
+	testutil.AssertNil(t, err)
+	row, err := table.GetRow("Row A")
+	testutil.AssertNil(t, err)
+	entry, err := row.GetEntry("Entry A")
+	testutil.AssertNil(t, err)
+
+	values := entry.GetValues()
+	testutil.AssertLenEquals(t, 1, values)
+	testutil.Assert(t, "Expected overwrite", values[0].HasText("Point B"))
+}
+
+func TestRunQueryJoinCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const indexAddr = crdt.IPFSPath("Index Addr")
+
+	query := &query.Query{
+		OpCode:   query.JOIN,
+		TableKey: MAIN_TABLE_KEY,
+		Join: query.QueryJoin{
+			Rows: []query.QueryRowJoin{
+				query.QueryRowJoin{
+					RowKey:   "Row A",
+					Counters: map[crdt.EntryName]int64{"Entry A": 3},
+				},
+				query.QueryRowJoin{
+					RowKey:   "Row A",
+					Counters: map[crdt.EntryName]int64{"Entry A": -1},
+				},
+			},
+		},
+	}
+
+	// This node and a peer have already changed the counter.
+	stored := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row A": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry A": crdt.MakeCounterEntry([]crdt.CounterShard{
+				crdt.MakeCounterShard("Test Node", 5),
+				crdt.MakeCounterShard("Peer Node", 1),
+			}),
+		}),
+	}))
+
+	var joined crdt.Table
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(searcher api.NamespaceSearcher) {
+		searcher.ReadSearchResult(api.SearchResult{Namespace: stored})
+	})
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, gomock.Any()).Return(indexAddr, nil).Do(func(tableKey crdt.TableName, table crdt.Table) {
+		joined = table
+	})
+
+	joiner := makeNamespaceTreeJoin(mock)
+	query.Visit(joiner)
+	resp := joiner.RunQuery()
+
+	testutil.AssertNil(t, resp.Err)
+
+	row, err := joined.GetRow("Row A")
+	testutil.AssertNil(t, err)
+	entry, err := row.GetEntry("Entry A")
+	testutil.AssertNil(t, err)
+
+	expectedShard := crdt.CounterShard{Replica: "Test Node", Increments: 8, Decrements: 1}
+	testutil.AssertEquals(t, "Unexpected shards", []crdt.CounterShard{expectedShard}, entry.Counter.Shards)
+
+	table, err := stored.JoinTable(MAIN_TABLE_KEY, joined).GetTable(MAIN_TABLE_KEY)
+	testutil.AssertNil(t, err)
+	row, err = table.GetRow("Row A")
+	testutil.AssertNil(t, err)
+	entry, err = row.GetEntry("Entry A")
+	testutil.AssertNil(t, err)
+
+	value, err := entry.Counter.Value()
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected counter value", int64(8), value)
+}
+
+func TestRunQueryJoinCounterOverflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	query := &query.Query{
+		OpCode:   query.JOIN,
+		TableKey: MAIN_TABLE_KEY,
+		Join: query.QueryJoin{
+			Rows: []query.QueryRowJoin{
+				query.QueryRowJoin{
+					RowKey:   "Row A",
+					Counters: map[crdt.EntryName]int64{"Entry A": math.MaxInt64},
+				},
+				query.QueryRowJoin{
+					RowKey:   "Row A",
+					Counters: map[crdt.EntryName]int64{"Entry A": math.MaxInt64},
+				},
+				query.QueryRowJoin{
+					RowKey:   "Row A",
+					Counters: map[crdt.EntryName]int64{"Entry A": math.MaxInt64},
+				},
+			},
+		},
+	}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil)
+
+	joiner := makeNamespaceTreeJoin(mock)
+	query.Visit(joiner)
+	resp := joiner.RunQuery()
+
+	testutil.AssertNonNil(t, resp.Err)
+}
+
 func TestRunQueryJoinFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -177,7 +426,7 @@ func TestRunQueryJoinFailure(t *testing.T) {
 		}),
 	})
 
-	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchTable(table)).Return(crdt.NIL_PATH, errors.New("Expected error"))
+	mock.EXPECT().JoinTable(MAIN_TABLE_KEY, matchJoinedTable(table)).Return(crdt.NIL_PATH, errors.New("Expected error"))
 
 	joiner := makeNamespaceTreeJoin(mock)
 	failQuery.Visit(joiner)
@@ -276,6 +525,35 @@ func (tm signedTableMatcher) Matches(v interface{}) bool {
 	return !missing
 }
 
+type joinedTableMatcher struct {
+	t crdt.Table
+}
+
+func matchJoinedTable(t crdt.Table) gomock.Matcher {
+	return joinedTableMatcher{t: t}
+}
+
+func (tm joinedTableMatcher) String() string {
+	return "is matching joined Table"
+}
+
+func (tm joinedTableMatcher) Matches(v interface{}) bool {
+	other, ok := v.(crdt.Table)
+
+	if !ok {
+		return false
+	}
+
+	untagged, ok := untagNamespace(crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, other))
+
+	if !ok {
+		return false
+	}
+
+	expected := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, tm.t)
+	return expected.Equals(untagged)
+}
+
 type tableMatcher struct {
 	t crdt.Table
 }
@@ -297,5 +575,10 @@ func (tm tableMatcher) Matches(v interface{}) bool {
 func makeNamespaceTreeJoin(namespace api.RemoteNamespace) *eval.NamespaceTreeJoin {
 	// TODO use fake key store
 	keyStore := &crypto.KeyStore{}
-	return eval.MakeNamespaceTreeJoin(namespace, keyStore)
+	options := eval.JoinOptions{
+		Namespace: namespace,
+		KeyStore:  keyStore,
+		Clock:     crdt.MakeHybridClock("Test Node"),
+	}
+	return eval.MakeNamespaceTreeJoin(options)
 }
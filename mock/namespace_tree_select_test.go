@@ -190,29 +190,36 @@ func TestRunQuerySelectSuccess(t *testing.T) {
 
 	responseA := api.RESPONSE_QUERY
 	responseA.Namespace = namespaceA()
+	responseA.RowOrder = []crdt.RowName{"Row A0"}
 
 	responseB := api.RESPONSE_QUERY
 	responseB.Namespace = namespaceB().JoinNamespace(namespaceC())
+	responseB.RowOrder = []crdt.RowName{"Row B0", "Row C0"}
 
 	responseC := api.RESPONSE_QUERY
 	responseC.Namespace = namespaceC()
 
 	responseD := api.RESPONSE_QUERY
 	responseD.Namespace = namespaceD()
+	responseD.RowOrder = []crdt.RowName{"Row D0"}
 
 	responseE := api.RESPONSE_QUERY
 	responseE.Namespace = namespaceE()
+	responseE.RowOrder = []crdt.RowName{"Row E0", "Row E1"}
 
 	responseF := api.RESPONSE_QUERY
 
 	responseG := api.RESPONSE_QUERY
 	responseG.Namespace = namespaceF()
+	responseG.RowOrder = []crdt.RowName{"Row F0"}
 
 	responseH := api.RESPONSE_QUERY
 	responseH.Namespace = namespaceG().JoinNamespace(namespaceH())
+	responseH.RowOrder = []crdt.RowName{"Row G0", "Row G1", "Row G2"}
 
 	responseI := api.RESPONSE_QUERY
 	responseI.Namespace = namespaceH()
+	responseI.RowOrder = []crdt.RowName{"Row G0", "Row G1", "Row G2"}
 
 	expect := []api.Response{
 		responseA,
@@ -260,6 +267,239 @@ func TestRunQuerySelectSuccess(t *testing.T) {
 	}
 }
 
+func TestRunQuerySelectFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	whereD := query.QueryWhere{
+		OpCode: query.PREDICATE,
+		Predicate: query.QueryPredicate{
+			FunctionName: "str_eq",
+			Values:       []query.PredicateValue{query.PredicateLiteral("Apple"), query.PredicateKey("Entry C")},
+		},
+	}
+
+	queries := []*query.Query{
+		&query.Query{
+			OpCode:   query.SELECT,
+			TableKey: MAIN_TABLE_KEY,
+			Select: query.QuerySelect{
+				Where:  whereD,
+				Fields: []crdt.EntryName{"Entry D", "No such entry"},
+			},
+		},
+	}
+
+	projected := crdt.EmptyNamespace().JoinTable(MAIN_TABLE_KEY, crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"Row D0": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"Entry D": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Orange")}),
+		}),
+	}))
+
+	expected := api.RESPONSE_QUERY
+	expected.Namespace = projected
+	expected.RowOrder = []crdt.RowName{"Row D0"}
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(feedNamespace).Times(len(queries))
+
+	for i, q := range queries {
+		selector := makeNamespaceTreeSelect(mock)
+		q.Visit(selector)
+		actual := selector.RunQuery()
+
+		if !expected.Equals(actual) {
+			t.Error("Case", i, "Expected", expected, "but received", actual)
+		}
+	}
+}
+
+func TestRunQuerySelectTableJoin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	mkentry := func(text crdt.PointText) crdt.Entry {
+		return crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint(text)})
+	}
+
+	books := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"b1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title":    mkentry("Animal Farm"),
+			"authorId": mkentry("a1"),
+		}),
+		"b2": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title":    mkentry("Emma"),
+			"authorId": mkentry("a2"),
+		}),
+		"b3": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title": mkentry("Anonymous"),
+		}),
+	})
+
+	authors := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"a1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"name": mkentry("Orwell"),
+		}),
+		"a2": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"name": mkentry("Austen"),
+		}),
+	})
+
+	namespace := crdt.EmptyNamespace().JoinTable("books", books).JoinTable("authors", authors)
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(reader api.SearchResultTraverser) {
+		reader.ReadSearchResult(api.SearchResult{Namespace: namespace})
+	})
+
+	q := &query.Query{
+		OpCode:   query.SELECT,
+		TableKey: "books",
+		Select: query.QuerySelect{
+			TableJoin: query.QueryTableJoin{
+				TableKey: "authors",
+				Left:     query.QueryColumn{TableKey: "books", Entry: "authorId"},
+				Right:    query.QueryColumn{TableKey: "authors", RowKey: true},
+			},
+			Where: query.QueryWhere{
+				OpCode: query.PREDICATE,
+				Predicate: query.QueryPredicate{
+					FunctionName: "str_eq",
+					Values:       []query.PredicateValue{query.PredicateLiteral("Orwell"), query.PredicateKey("authors.name")},
+				},
+			},
+		},
+	}
+
+	expected := api.RESPONSE_QUERY
+	expected.Namespace = crdt.EmptyNamespace().JoinTable("books", crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"b1/a1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"books.title":    mkentry("Animal Farm"),
+			"books.authorId": mkentry("a1"),
+			"books.@key":     mkentry("b1"),
+			"authors.name":   mkentry("Orwell"),
+			"authors.@key":   mkentry("a1"),
+		}),
+	}))
+	expected.RowOrder = []crdt.RowName{"b1/a1"}
+
+	selector := makeNamespaceTreeSelect(mock)
+	q.Visit(selector)
+	actual := selector.RunQuery()
+
+	if !expected.Equals(actual) {
+		t.Error("Expected", expected, "but received", actual)
+	}
+}
+
+func TestRunQuerySelectExplain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	index := crdt.EmptyIndex().JoinTable("books", crdt.UnsignedLink("Qm1"), crdt.UnsignedLink("Qm2"))
+	index = index.JoinTable("authors", crdt.UnsignedLink("Qm3"))
+
+	books := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"b1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Emma")}),
+		}),
+		"b2": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Persuasion")}),
+		}),
+	})
+
+	mock.EXPECT().LoadTraverse(gomock.Any()).Return(nil).Do(func(searcher api.NamespaceSearcher) {
+		links := searcher.Search(index)
+
+		if len(links) != 2 {
+			t.Error("Expected 2 links but received", links)
+		}
+
+		searcher.ReadSearchResult(api.SearchResult{
+			Namespace: crdt.EmptyNamespace().JoinTable("books", books),
+			Path:      "Qm1",
+			Source:    api.SOURCE_CACHE,
+		})
+
+		searcher.ReadSearchResult(api.SearchResult{
+			NamespaceLoadFailure: true,
+			Path:                 "Qm2",
+			Source:               api.SOURCE_IPFS,
+		})
+	})
+
+	q := &query.Query{
+		OpCode:   query.SELECT,
+		TableKey: "books",
+		Select:   query.QuerySelect{Explain: true},
+	}
+
+	selector := makeNamespaceTreeSelect(mock)
+	q.Visit(selector)
+	actual := selector.RunQuery()
+
+	expectedLinks := []crdt.IPFSPath{"Qm1", "Qm2"}
+	expectedLoads := []api.NamespaceLoad{
+		api.NamespaceLoad{Path: "Qm1", Source: api.SOURCE_CACHE},
+		api.NamespaceLoad{Path: "Qm2", Source: api.SOURCE_IPFS, Failed: true},
+	}
+	expectedPhases := []string{"search", "merge", "evaluate"}
+
+	plan := actual.Plan
+
+	testutil.AssertEquals(t, "Unexpected index links", expectedLinks, plan.IndexLinks)
+	testutil.AssertEquals(t, "Unexpected loads", expectedLoads, plan.Loads)
+	testutil.AssertEquals(t, "Unexpected rows evaluated", uint32(2), plan.RowsEvaluated)
+	testutil.Assert(t, "Unexpected namespace", actual.Namespace.IsEmpty())
+
+	phases := []string{}
+	for _, phase := range plan.Phases {
+		phases = append(phases, phase.Name)
+	}
+
+	testutil.AssertEquals(t, "Unexpected phases", expectedPhases, phases)
+}
+
+func TestRunQuerySelectAtIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockRemoteNamespace(ctrl)
+
+	const pastIndex = crdt.IPFSPath("QmPast")
+
+	books := crdt.MakeTable(map[crdt.RowName]crdt.Row{
+		"b1": crdt.MakeRow(map[crdt.EntryName]crdt.Entry{
+			"title": crdt.MakeEntry([]crdt.Point{crdt.UnsignedPoint("Emma")}),
+		}),
+	})
+	namespace := crdt.EmptyNamespace().JoinTable("books", books)
+
+	mock.EXPECT().LoadTraverseIndex(pastIndex, gomock.Any()).Return(nil).Do(func(path crdt.IPFSPath, searcher api.NamespaceSearcher) {
+		searcher.ReadSearchResult(api.SearchResult{Namespace: namespace})
+	})
+
+	q := &query.Query{
+		OpCode:   query.SELECT,
+		TableKey: "books",
+		Select: query.QuerySelect{
+			Limit:     1,
+			IndexPath: pastIndex,
+		},
+	}
+
+	selector := makeNamespaceTreeSelect(mock)
+	q.Visit(selector)
+	actual := selector.RunQuery()
+
+	testutil.AssertNil(t, actual.Err)
+	testutil.Assert(t, "Unexpected namespace", namespace.Equals(actual.Namespace))
+}
+
 func TestRunQuerySelectFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
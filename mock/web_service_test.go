@@ -13,6 +13,7 @@ import (
 	"github.com/johnny-morrice/godless/http"
 	"github.com/johnny-morrice/godless/internal/testutil"
 	"github.com/johnny-morrice/godless/log"
+	"github.com/johnny-morrice/godless/query"
 )
 
 func TestWebServiceGetApiRequestHandler(t *testing.T) {
@@ -55,6 +56,57 @@ func TestWebServiceGetApiRequestHandler(t *testing.T) {
 	testutil.AssertNonNil(t, err)
 }
 
+func TestWebServiceWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockService(ctrl)
+
+	q, err := query.Compile("select books")
+	panicOnBadInit(err)
+	request := api.MakeWatchRequest(q)
+
+	const SIZE = 50
+	expected := []api.Response{
+		api.GenResponse(testutil.Rand(), SIZE),
+		api.GenResponse(testutil.Rand(), SIZE),
+	}
+
+	respch := make(chan api.Response, len(expected))
+	for _, resp := range expected {
+		respch <- resp
+	}
+	close(respch)
+
+	mock.EXPECT().Watch(matchRequest(request), gomock.Any()).Return((<-chan api.Response)(respch), nil)
+
+	webService := http.MakeWebService(http.WebServiceOptions{Api: mock, Watch: mock})
+	defer webService.Close()
+
+	server := httptest.NewServer(webService.GetApiRequestHandler())
+	defer server.Close()
+
+	client, err := http.MakeClient(http.ClientOptions{ServerAddr: server.URL})
+	panicOnBadInit(err)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	stream, err := client.Watch(request, stop)
+	testutil.AssertNil(t, err)
+
+	actual := []api.Response{}
+	for resp := range stream {
+		actual = append(actual, resp)
+	}
+
+	testutil.AssertLenEquals(t, len(expected), actual)
+
+	for i, resp := range actual {
+		testutil.Assert(t, "Unexpected api.Response", expected[i].Equals(resp))
+	}
+}
+
 func webApiCall(handler gohttp.Handler, request api.Request) (api.Response, error) {
 	buff := &bytes.Buffer{}
 	err := api.EncodeRequest(request, buff)
@@ -9,19 +9,39 @@ It is generated from these files:
 
 It has these top-level messages:
 	NamespaceMessage
+	ShardMessage
 	NamespaceEntryMessage
+	CounterShardMessage
 	PointMessage
+	TimestampMessage
 	IndexMessage
 	IndexEntryMessage
 	LinkMessage
 	APIRequestMessage
+	ExecuteMessage
+	VariableMessage
+	TagRequestMessage
+	TagMessage
 	ReplicateMessage
 	APIResponseMessage
+	IndexLogEntryMessage
+	QueryPlanMessage
+	NamespaceLoadMessage
+	PlanPhaseMessage
+	ResultTableMessage
+	ResultRowMessage
 	QueryMessage
 	QueryJoinMessage
 	QueryRowJoinMessage
+	QueryRowJoinCounterMessage
 	QueryRowJoinEntryMessage
+	QueryDeleteMessage
+	QueryRowDeleteMessage
 	QuerySelectMessage
+	QueryTableJoinMessage
+	QueryColumnMessage
+	QueryAggregateMessage
+	QueryOrderByMessage
 	QueryWhereMessage
 	QueryPredicateMessage
 	PredicateValue
@@ -45,6 +65,7 @@ const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type NamespaceMessage struct {
 	Entries []*NamespaceEntryMessage `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Shards  []*ShardMessage          `protobuf:"bytes,2,rep,name=shards" json:"shards,omitempty"`
 }
 
 func (m *NamespaceMessage) Reset()                    { *m = NamespaceMessage{} }
@@ -59,17 +80,50 @@ func (m *NamespaceMessage) GetEntries() []*NamespaceEntryMessage {
 	return nil
 }
 
+func (m *NamespaceMessage) GetShards() []*ShardMessage {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type ShardMessage struct {
+	Slot uint32 `protobuf:"varint,1,opt,name=slot" json:"slot,omitempty"`
+	Link string `protobuf:"bytes,2,opt,name=link" json:"link,omitempty"`
+}
+
+func (m *ShardMessage) Reset()                    { *m = ShardMessage{} }
+func (m *ShardMessage) String() string            { return proto1.CompactTextString(m) }
+func (*ShardMessage) ProtoMessage()               {}
+func (*ShardMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ShardMessage) GetSlot() uint32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *ShardMessage) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
 type NamespaceEntryMessage struct {
-	Table string        `protobuf:"bytes,1,opt,name=table" json:"table,omitempty"`
-	Row   string        `protobuf:"bytes,2,opt,name=row" json:"row,omitempty"`
-	Entry string        `protobuf:"bytes,3,opt,name=entry" json:"entry,omitempty"`
-	Point *PointMessage `protobuf:"bytes,4,opt,name=point" json:"point,omitempty"`
+	Table     string               `protobuf:"bytes,1,opt,name=table" json:"table,omitempty"`
+	Row       string               `protobuf:"bytes,2,opt,name=row" json:"row,omitempty"`
+	Entry     string               `protobuf:"bytes,3,opt,name=entry" json:"entry,omitempty"`
+	Point     *PointMessage        `protobuf:"bytes,4,opt,name=point" json:"point,omitempty"`
+	Tombstone bool                 `protobuf:"varint,5,opt,name=tombstone" json:"tombstone,omitempty"`
+	Counter   *CounterShardMessage `protobuf:"bytes,6,opt,name=counter" json:"counter,omitempty"`
 }
 
 func (m *NamespaceEntryMessage) Reset()                    { *m = NamespaceEntryMessage{} }
 func (m *NamespaceEntryMessage) String() string            { return proto1.CompactTextString(m) }
 func (*NamespaceEntryMessage) ProtoMessage()               {}
-func (*NamespaceEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*NamespaceEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *NamespaceEntryMessage) GetTable() string {
 	if m != nil {
@@ -99,15 +153,63 @@ func (m *NamespaceEntryMessage) GetPoint() *PointMessage {
 	return nil
 }
 
+func (m *NamespaceEntryMessage) GetTombstone() bool {
+	if m != nil {
+		return m.Tombstone
+	}
+	return false
+}
+
+func (m *NamespaceEntryMessage) GetCounter() *CounterShardMessage {
+	if m != nil {
+		return m.Counter
+	}
+	return nil
+}
+
+type CounterShardMessage struct {
+	Replica    string `protobuf:"bytes,1,opt,name=replica" json:"replica,omitempty"`
+	Increments uint64 `protobuf:"varint,2,opt,name=increments" json:"increments,omitempty"`
+	Decrements uint64 `protobuf:"varint,3,opt,name=decrements" json:"decrements,omitempty"`
+}
+
+func (m *CounterShardMessage) Reset()                    { *m = CounterShardMessage{} }
+func (m *CounterShardMessage) String() string            { return proto1.CompactTextString(m) }
+func (*CounterShardMessage) ProtoMessage()               {}
+func (*CounterShardMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *CounterShardMessage) GetReplica() string {
+	if m != nil {
+		return m.Replica
+	}
+	return ""
+}
+
+func (m *CounterShardMessage) GetIncrements() uint64 {
+	if m != nil {
+		return m.Increments
+	}
+	return 0
+}
+
+func (m *CounterShardMessage) GetDecrements() uint64 {
+	if m != nil {
+		return m.Decrements
+	}
+	return 0
+}
+
 type PointMessage struct {
-	Text      string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
-	Signature string `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
+	Text      string            `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
+	Signature string            `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
+	Timestamp *TimestampMessage `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Tag       *TimestampMessage `protobuf:"bytes,4,opt,name=tag" json:"tag,omitempty"`
 }
 
 func (m *PointMessage) Reset()                    { *m = PointMessage{} }
 func (m *PointMessage) String() string            { return proto1.CompactTextString(m) }
 func (*PointMessage) ProtoMessage()               {}
-func (*PointMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*PointMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *PointMessage) GetText() string {
 	if m != nil {
@@ -123,14 +225,64 @@ func (m *PointMessage) GetSignature() string {
 	return ""
 }
 
+func (m *PointMessage) GetTimestamp() *TimestampMessage {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *PointMessage) GetTag() *TimestampMessage {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+type TimestampMessage struct {
+	Wall    int64  `protobuf:"varint,1,opt,name=wall" json:"wall,omitempty"`
+	Logical uint32 `protobuf:"varint,2,opt,name=logical" json:"logical,omitempty"`
+	Node    string `protobuf:"bytes,3,opt,name=node" json:"node,omitempty"`
+}
+
+func (m *TimestampMessage) Reset()                    { *m = TimestampMessage{} }
+func (m *TimestampMessage) String() string            { return proto1.CompactTextString(m) }
+func (*TimestampMessage) ProtoMessage()               {}
+func (*TimestampMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *TimestampMessage) GetWall() int64 {
+	if m != nil {
+		return m.Wall
+	}
+	return 0
+}
+
+func (m *TimestampMessage) GetLogical() uint32 {
+	if m != nil {
+		return m.Logical
+	}
+	return 0
+}
+
+func (m *TimestampMessage) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type IndexMessage struct {
 	Entries []*IndexEntryMessage `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Parents []string             `protobuf:"bytes,2,rep,name=parents" json:"parents,omitempty"`
+	Created int64                `protobuf:"varint,3,opt,name=created" json:"created,omitempty"`
+	Base    string               `protobuf:"bytes,4,opt,name=base" json:"base,omitempty"`
+	Head    string               `protobuf:"bytes,5,opt,name=head" json:"head,omitempty"`
 }
 
 func (m *IndexMessage) Reset()                    { *m = IndexMessage{} }
 func (m *IndexMessage) String() string            { return proto1.CompactTextString(m) }
 func (*IndexMessage) ProtoMessage()               {}
-func (*IndexMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*IndexMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *IndexMessage) GetEntries() []*IndexEntryMessage {
 	if m != nil {
@@ -139,6 +291,34 @@ func (m *IndexMessage) GetEntries() []*IndexEntryMessage {
 	return nil
 }
 
+func (m *IndexMessage) GetParents() []string {
+	if m != nil {
+		return m.Parents
+	}
+	return nil
+}
+
+func (m *IndexMessage) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *IndexMessage) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *IndexMessage) GetHead() string {
+	if m != nil {
+		return m.Head
+	}
+	return ""
+}
+
 type IndexEntryMessage struct {
 	Table     string `protobuf:"bytes,1,opt,name=table" json:"table,omitempty"`
 	Link      string `protobuf:"bytes,2,opt,name=link" json:"link,omitempty"`
@@ -148,7 +328,7 @@ type IndexEntryMessage struct {
 func (m *IndexEntryMessage) Reset()                    { *m = IndexEntryMessage{} }
 func (m *IndexEntryMessage) String() string            { return proto1.CompactTextString(m) }
 func (*IndexEntryMessage) ProtoMessage()               {}
-func (*IndexEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*IndexEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *IndexEntryMessage) GetTable() string {
 	if m != nil {
@@ -179,7 +359,7 @@ type LinkMessage struct {
 func (m *LinkMessage) Reset()                    { *m = LinkMessage{} }
 func (m *LinkMessage) String() string            { return proto1.CompactTextString(m) }
 func (*LinkMessage) ProtoMessage()               {}
-func (*LinkMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*LinkMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *LinkMessage) GetLink() string {
 	if m != nil {
@@ -196,16 +376,22 @@ func (m *LinkMessage) GetSignatures() []string {
 }
 
 type APIRequestMessage struct {
-	Type       uint32            `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
-	Reflection uint32            `protobuf:"varint,2,opt,name=reflection" json:"reflection,omitempty"`
-	Query      *QueryMessage     `protobuf:"bytes,3,opt,name=query" json:"query,omitempty"`
-	Replicate  *ReplicateMessage `protobuf:"bytes,4,opt,name=replicate" json:"replicate,omitempty"`
+	Type       uint32             `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+	Reflection uint32             `protobuf:"varint,2,opt,name=reflection" json:"reflection,omitempty"`
+	Query      *QueryMessage      `protobuf:"bytes,3,opt,name=query" json:"query,omitempty"`
+	Replicate  *ReplicateMessage  `protobuf:"bytes,4,opt,name=replicate" json:"replicate,omitempty"`
+	Tag        *TagRequestMessage `protobuf:"bytes,5,opt,name=tag" json:"tag,omitempty"`
+	Revert     string             `protobuf:"bytes,6,opt,name=revert" json:"revert,omitempty"`
+	Prepare    string             `protobuf:"bytes,7,opt,name=prepare" json:"prepare,omitempty"`
+	Execute    *ExecuteMessage    `protobuf:"bytes,8,opt,name=execute" json:"execute,omitempty"`
+	Batch      []*QueryMessage    `protobuf:"bytes,9,rep,name=batch" json:"batch,omitempty"`
+	LogLimit   uint32             `protobuf:"varint,10,opt,name=logLimit" json:"logLimit,omitempty"`
 }
 
 func (m *APIRequestMessage) Reset()                    { *m = APIRequestMessage{} }
 func (m *APIRequestMessage) String() string            { return proto1.CompactTextString(m) }
 func (*APIRequestMessage) ProtoMessage()               {}
-func (*APIRequestMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*APIRequestMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *APIRequestMessage) GetType() uint32 {
 	if m != nil {
@@ -235,6 +421,160 @@ func (m *APIRequestMessage) GetReplicate() *ReplicateMessage {
 	return nil
 }
 
+func (m *APIRequestMessage) GetTag() *TagRequestMessage {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *APIRequestMessage) GetRevert() string {
+	if m != nil {
+		return m.Revert
+	}
+	return ""
+}
+
+func (m *APIRequestMessage) GetPrepare() string {
+	if m != nil {
+		return m.Prepare
+	}
+	return ""
+}
+
+func (m *APIRequestMessage) GetExecute() *ExecuteMessage {
+	if m != nil {
+		return m.Execute
+	}
+	return nil
+}
+
+func (m *APIRequestMessage) GetBatch() []*QueryMessage {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *APIRequestMessage) GetLogLimit() uint32 {
+	if m != nil {
+		return m.LogLimit
+	}
+	return 0
+}
+
+type ExecuteMessage struct {
+	Statement string             `protobuf:"bytes,1,opt,name=statement" json:"statement,omitempty"`
+	Variables []*VariableMessage `protobuf:"bytes,2,rep,name=variables" json:"variables,omitempty"`
+}
+
+func (m *ExecuteMessage) Reset()                    { *m = ExecuteMessage{} }
+func (m *ExecuteMessage) String() string            { return proto1.CompactTextString(m) }
+func (*ExecuteMessage) ProtoMessage()               {}
+func (*ExecuteMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ExecuteMessage) GetStatement() string {
+	if m != nil {
+		return m.Statement
+	}
+	return ""
+}
+
+func (m *ExecuteMessage) GetVariables() []*VariableMessage {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+type VariableMessage struct {
+	Type   uint32 `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+	Text   string `protobuf:"bytes,2,opt,name=text" json:"text,omitempty"`
+	Number int64  `protobuf:"varint,3,opt,name=number" json:"number,omitempty"`
+}
+
+func (m *VariableMessage) Reset()                    { *m = VariableMessage{} }
+func (m *VariableMessage) String() string            { return proto1.CompactTextString(m) }
+func (*VariableMessage) ProtoMessage()               {}
+func (*VariableMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *VariableMessage) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *VariableMessage) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *VariableMessage) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type TagRequestMessage struct {
+	Command uint32 `protobuf:"varint,1,opt,name=command" json:"command,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Path    string `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *TagRequestMessage) Reset()                    { *m = TagRequestMessage{} }
+func (m *TagRequestMessage) String() string            { return proto1.CompactTextString(m) }
+func (*TagRequestMessage) ProtoMessage()               {}
+func (*TagRequestMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *TagRequestMessage) GetCommand() uint32 {
+	if m != nil {
+		return m.Command
+	}
+	return 0
+}
+
+func (m *TagRequestMessage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TagRequestMessage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type TagMessage struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *TagMessage) Reset()                    { *m = TagMessage{} }
+func (m *TagMessage) String() string            { return proto1.CompactTextString(m) }
+func (*TagMessage) ProtoMessage()               {}
+func (*TagMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TagMessage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TagMessage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type ReplicateMessage struct {
 	Links []*LinkMessage `protobuf:"bytes,1,rep,name=links" json:"links,omitempty"`
 }
@@ -242,7 +582,7 @@ type ReplicateMessage struct {
 func (m *ReplicateMessage) Reset()                    { *m = ReplicateMessage{} }
 func (m *ReplicateMessage) String() string            { return proto1.CompactTextString(m) }
 func (*ReplicateMessage) ProtoMessage()               {}
-func (*ReplicateMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ReplicateMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ReplicateMessage) GetLinks() []*LinkMessage {
 	if m != nil {
@@ -252,18 +592,25 @@ func (m *ReplicateMessage) GetLinks() []*LinkMessage {
 }
 
 type APIResponseMessage struct {
-	Message   string            `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
-	Error     string            `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	Type      uint32            `protobuf:"varint,3,opt,name=type" json:"type,omitempty"`
-	Path      string            `protobuf:"bytes,4,opt,name=path" json:"path,omitempty"`
-	Namespace *NamespaceMessage `protobuf:"bytes,5,opt,name=namespace" json:"namespace,omitempty"`
-	Index     *IndexMessage     `protobuf:"bytes,6,opt,name=index" json:"index,omitempty"`
+	Message     string                  `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Error       string                  `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	Type        uint32                  `protobuf:"varint,3,opt,name=type" json:"type,omitempty"`
+	Path        string                  `protobuf:"bytes,4,opt,name=path" json:"path,omitempty"`
+	Namespace   *NamespaceMessage       `protobuf:"bytes,5,opt,name=namespace" json:"namespace,omitempty"`
+	Index       *IndexMessage           `protobuf:"bytes,6,opt,name=index" json:"index,omitempty"`
+	RowOrder    []string                `protobuf:"bytes,7,rep,name=rowOrder" json:"rowOrder,omitempty"`
+	Table       *ResultTableMessage     `protobuf:"bytes,8,opt,name=table" json:"table,omitempty"`
+	Plan        *QueryPlanMessage       `protobuf:"bytes,9,opt,name=plan" json:"plan,omitempty"`
+	History     []*IndexLogEntryMessage `protobuf:"bytes,10,rep,name=history" json:"history,omitempty"`
+	Tags        []*TagMessage           `protobuf:"bytes,11,rep,name=tags" json:"tags,omitempty"`
+	Statement   string                  `protobuf:"bytes,12,opt,name=statement" json:"statement,omitempty"`
+	RemovedRows []string                `protobuf:"bytes,13,rep,name=removedRows" json:"removedRows,omitempty"`
 }
 
 func (m *APIResponseMessage) Reset()                    { *m = APIResponseMessage{} }
 func (m *APIResponseMessage) String() string            { return proto1.CompactTextString(m) }
 func (*APIResponseMessage) ProtoMessage()               {}
-func (*APIResponseMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*APIResponseMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *APIResponseMessage) GetMessage() string {
 	if m != nil {
@@ -307,18 +654,244 @@ func (m *APIResponseMessage) GetIndex() *IndexMessage {
 	return nil
 }
 
+func (m *APIResponseMessage) GetRowOrder() []string {
+	if m != nil {
+		return m.RowOrder
+	}
+	return nil
+}
+
+func (m *APIResponseMessage) GetTable() *ResultTableMessage {
+	if m != nil {
+		return m.Table
+	}
+	return nil
+}
+
+func (m *APIResponseMessage) GetPlan() *QueryPlanMessage {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *APIResponseMessage) GetHistory() []*IndexLogEntryMessage {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *APIResponseMessage) GetTags() []*TagMessage {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *APIResponseMessage) GetStatement() string {
+	if m != nil {
+		return m.Statement
+	}
+	return ""
+}
+
+func (m *APIResponseMessage) GetRemovedRows() []string {
+	if m != nil {
+		return m.RemovedRows
+	}
+	return nil
+}
+
+type IndexLogEntryMessage struct {
+	Path          string   `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Created       int64    `protobuf:"varint,2,opt,name=created" json:"created,omitempty"`
+	Parents       []string `protobuf:"bytes,3,rep,name=parents" json:"parents,omitempty"`
+	ChangedTables []string `protobuf:"bytes,4,rep,name=changedTables" json:"changedTables,omitempty"`
+}
+
+func (m *IndexLogEntryMessage) Reset()                    { *m = IndexLogEntryMessage{} }
+func (m *IndexLogEntryMessage) String() string            { return proto1.CompactTextString(m) }
+func (*IndexLogEntryMessage) ProtoMessage()               {}
+func (*IndexLogEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *IndexLogEntryMessage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *IndexLogEntryMessage) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *IndexLogEntryMessage) GetParents() []string {
+	if m != nil {
+		return m.Parents
+	}
+	return nil
+}
+
+func (m *IndexLogEntryMessage) GetChangedTables() []string {
+	if m != nil {
+		return m.ChangedTables
+	}
+	return nil
+}
+
+type QueryPlanMessage struct {
+	IndexLinks    []string                `protobuf:"bytes,1,rep,name=indexLinks" json:"indexLinks,omitempty"`
+	Loads         []*NamespaceLoadMessage `protobuf:"bytes,2,rep,name=loads" json:"loads,omitempty"`
+	RowsEvaluated uint32                  `protobuf:"varint,3,opt,name=rowsEvaluated" json:"rowsEvaluated,omitempty"`
+	Phases        []*PlanPhaseMessage     `protobuf:"bytes,4,rep,name=phases" json:"phases,omitempty"`
+}
+
+func (m *QueryPlanMessage) Reset()                    { *m = QueryPlanMessage{} }
+func (m *QueryPlanMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryPlanMessage) ProtoMessage()               {}
+func (*QueryPlanMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *QueryPlanMessage) GetIndexLinks() []string {
+	if m != nil {
+		return m.IndexLinks
+	}
+	return nil
+}
+
+func (m *QueryPlanMessage) GetLoads() []*NamespaceLoadMessage {
+	if m != nil {
+		return m.Loads
+	}
+	return nil
+}
+
+func (m *QueryPlanMessage) GetRowsEvaluated() uint32 {
+	if m != nil {
+		return m.RowsEvaluated
+	}
+	return 0
+}
+
+func (m *QueryPlanMessage) GetPhases() []*PlanPhaseMessage {
+	if m != nil {
+		return m.Phases
+	}
+	return nil
+}
+
+type NamespaceLoadMessage struct {
+	Path   string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Source uint32 `protobuf:"varint,2,opt,name=source" json:"source,omitempty"`
+	Failed bool   `protobuf:"varint,3,opt,name=failed" json:"failed,omitempty"`
+}
+
+func (m *NamespaceLoadMessage) Reset()                    { *m = NamespaceLoadMessage{} }
+func (m *NamespaceLoadMessage) String() string            { return proto1.CompactTextString(m) }
+func (*NamespaceLoadMessage) ProtoMessage()               {}
+func (*NamespaceLoadMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *NamespaceLoadMessage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *NamespaceLoadMessage) GetSource() uint32 {
+	if m != nil {
+		return m.Source
+	}
+	return 0
+}
+
+func (m *NamespaceLoadMessage) GetFailed() bool {
+	if m != nil {
+		return m.Failed
+	}
+	return false
+}
+
+type PlanPhaseMessage struct {
+	Name        string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Nanoseconds int64  `protobuf:"varint,2,opt,name=nanoseconds" json:"nanoseconds,omitempty"`
+}
+
+func (m *PlanPhaseMessage) Reset()                    { *m = PlanPhaseMessage{} }
+func (m *PlanPhaseMessage) String() string            { return proto1.CompactTextString(m) }
+func (*PlanPhaseMessage) ProtoMessage()               {}
+func (*PlanPhaseMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *PlanPhaseMessage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlanPhaseMessage) GetNanoseconds() int64 {
+	if m != nil {
+		return m.Nanoseconds
+	}
+	return 0
+}
+
+type ResultTableMessage struct {
+	Columns []string            `protobuf:"bytes,1,rep,name=columns" json:"columns,omitempty"`
+	Rows    []*ResultRowMessage `protobuf:"bytes,2,rep,name=rows" json:"rows,omitempty"`
+}
+
+func (m *ResultTableMessage) Reset()                    { *m = ResultTableMessage{} }
+func (m *ResultTableMessage) String() string            { return proto1.CompactTextString(m) }
+func (*ResultTableMessage) ProtoMessage()               {}
+func (*ResultTableMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ResultTableMessage) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *ResultTableMessage) GetRows() []*ResultRowMessage {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type ResultRowMessage struct {
+	Values []string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *ResultRowMessage) Reset()                    { *m = ResultRowMessage{} }
+func (m *ResultRowMessage) String() string            { return proto1.CompactTextString(m) }
+func (*ResultRowMessage) ProtoMessage()               {}
+func (*ResultRowMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ResultRowMessage) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type QueryMessage struct {
 	OpCode    uint32              `protobuf:"varint,1,opt,name=opCode" json:"opCode,omitempty"`
 	Table     string              `protobuf:"bytes,2,opt,name=table" json:"table,omitempty"`
 	Join      *QueryJoinMessage   `protobuf:"bytes,3,opt,name=join" json:"join,omitempty"`
 	Select    *QuerySelectMessage `protobuf:"bytes,4,opt,name=select" json:"select,omitempty"`
 	KeyHashes []string            `protobuf:"bytes,5,rep,name=keyHashes" json:"keyHashes,omitempty"`
+	Delete    *QueryDeleteMessage `protobuf:"bytes,6,opt,name=delete" json:"delete,omitempty"`
 }
 
 func (m *QueryMessage) Reset()                    { *m = QueryMessage{} }
 func (m *QueryMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryMessage) ProtoMessage()               {}
-func (*QueryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*QueryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *QueryMessage) GetOpCode() uint32 {
 	if m != nil {
@@ -355,14 +928,22 @@ func (m *QueryMessage) GetKeyHashes() []string {
 	return nil
 }
 
+func (m *QueryMessage) GetDelete() *QueryDeleteMessage {
+	if m != nil {
+		return m.Delete
+	}
+	return nil
+}
+
 type QueryJoinMessage struct {
 	Rows []*QueryRowJoinMessage `protobuf:"bytes,1,rep,name=rows" json:"rows,omitempty"`
+	Lww  bool                   `protobuf:"varint,2,opt,name=lww" json:"lww,omitempty"`
 }
 
 func (m *QueryJoinMessage) Reset()                    { *m = QueryJoinMessage{} }
 func (m *QueryJoinMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryJoinMessage) ProtoMessage()               {}
-func (*QueryJoinMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*QueryJoinMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *QueryJoinMessage) GetRows() []*QueryRowJoinMessage {
 	if m != nil {
@@ -371,15 +952,23 @@ func (m *QueryJoinMessage) GetRows() []*QueryRowJoinMessage {
 	return nil
 }
 
+func (m *QueryJoinMessage) GetLww() bool {
+	if m != nil {
+		return m.Lww
+	}
+	return false
+}
+
 type QueryRowJoinMessage struct {
-	Row     string                      `protobuf:"bytes,1,opt,name=row" json:"row,omitempty"`
-	Entries []*QueryRowJoinEntryMessage `protobuf:"bytes,2,rep,name=entries" json:"entries,omitempty"`
+	Row      string                        `protobuf:"bytes,1,opt,name=row" json:"row,omitempty"`
+	Entries  []*QueryRowJoinEntryMessage   `protobuf:"bytes,2,rep,name=entries" json:"entries,omitempty"`
+	Counters []*QueryRowJoinCounterMessage `protobuf:"bytes,3,rep,name=counters" json:"counters,omitempty"`
 }
 
 func (m *QueryRowJoinMessage) Reset()                    { *m = QueryRowJoinMessage{} }
 func (m *QueryRowJoinMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryRowJoinMessage) ProtoMessage()               {}
-func (*QueryRowJoinMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*QueryRowJoinMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *QueryRowJoinMessage) GetRow() string {
 	if m != nil {
@@ -395,6 +984,37 @@ func (m *QueryRowJoinMessage) GetEntries() []*QueryRowJoinEntryMessage {
 	return nil
 }
 
+func (m *QueryRowJoinMessage) GetCounters() []*QueryRowJoinCounterMessage {
+	if m != nil {
+		return m.Counters
+	}
+	return nil
+}
+
+type QueryRowJoinCounterMessage struct {
+	Entry string `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	Delta int64  `protobuf:"zigzag64,2,opt,name=delta" json:"delta,omitempty"`
+}
+
+func (m *QueryRowJoinCounterMessage) Reset()                    { *m = QueryRowJoinCounterMessage{} }
+func (m *QueryRowJoinCounterMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryRowJoinCounterMessage) ProtoMessage()               {}
+func (*QueryRowJoinCounterMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *QueryRowJoinCounterMessage) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *QueryRowJoinCounterMessage) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
 type QueryRowJoinEntryMessage struct {
 	Entry string `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
 	Point string `protobuf:"bytes,2,opt,name=point" json:"point,omitempty"`
@@ -403,7 +1023,7 @@ type QueryRowJoinEntryMessage struct {
 func (m *QueryRowJoinEntryMessage) Reset()                    { *m = QueryRowJoinEntryMessage{} }
 func (m *QueryRowJoinEntryMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryRowJoinEntryMessage) ProtoMessage()               {}
-func (*QueryRowJoinEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*QueryRowJoinEntryMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *QueryRowJoinEntryMessage) GetEntry() string {
 	if m != nil {
@@ -419,15 +1039,64 @@ func (m *QueryRowJoinEntryMessage) GetPoint() string {
 	return ""
 }
 
+type QueryDeleteMessage struct {
+	Rows []*QueryRowDeleteMessage `protobuf:"bytes,1,rep,name=rows" json:"rows,omitempty"`
+}
+
+func (m *QueryDeleteMessage) Reset()                    { *m = QueryDeleteMessage{} }
+func (m *QueryDeleteMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryDeleteMessage) ProtoMessage()               {}
+func (*QueryDeleteMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *QueryDeleteMessage) GetRows() []*QueryRowDeleteMessage {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type QueryRowDeleteMessage struct {
+	Row     string   `protobuf:"bytes,1,opt,name=row" json:"row,omitempty"`
+	Entries []string `protobuf:"bytes,2,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *QueryRowDeleteMessage) Reset()                    { *m = QueryRowDeleteMessage{} }
+func (m *QueryRowDeleteMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryRowDeleteMessage) ProtoMessage()               {}
+func (*QueryRowDeleteMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *QueryRowDeleteMessage) GetRow() string {
+	if m != nil {
+		return m.Row
+	}
+	return ""
+}
+
+func (m *QueryRowDeleteMessage) GetEntries() []string {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
 type QuerySelectMessage struct {
-	Limit uint32             `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
-	Where *QueryWhereMessage `protobuf:"bytes,2,opt,name=where" json:"where,omitempty"`
+	Limit      uint32                   `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	Where      *QueryWhereMessage       `protobuf:"bytes,2,opt,name=where" json:"where,omitempty"`
+	Fields     []string                 `protobuf:"bytes,3,rep,name=fields" json:"fields,omitempty"`
+	OrderBy    *QueryOrderByMessage     `protobuf:"bytes,4,opt,name=orderBy" json:"orderBy,omitempty"`
+	Offset     uint32                   `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+	Aggregates []*QueryAggregateMessage `protobuf:"bytes,6,rep,name=aggregates" json:"aggregates,omitempty"`
+	GroupBy    string                   `protobuf:"bytes,7,opt,name=groupBy" json:"groupBy,omitempty"`
+	TableJoin  *QueryTableJoinMessage   `protobuf:"bytes,8,opt,name=tableJoin" json:"tableJoin,omitempty"`
+	Explain    bool                     `protobuf:"varint,9,opt,name=explain" json:"explain,omitempty"`
+	Index      string                   `protobuf:"bytes,10,opt,name=index" json:"index,omitempty"`
+	Tag        string                   `protobuf:"bytes,11,opt,name=tag" json:"tag,omitempty"`
 }
 
 func (m *QuerySelectMessage) Reset()                    { *m = QuerySelectMessage{} }
 func (m *QuerySelectMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QuerySelectMessage) ProtoMessage()               {}
-func (*QuerySelectMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*QuerySelectMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *QuerySelectMessage) GetLimit() uint32 {
 	if m != nil {
@@ -443,6 +1112,189 @@ func (m *QuerySelectMessage) GetWhere() *QueryWhereMessage {
 	return nil
 }
 
+func (m *QuerySelectMessage) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *QuerySelectMessage) GetOrderBy() *QueryOrderByMessage {
+	if m != nil {
+		return m.OrderBy
+	}
+	return nil
+}
+
+func (m *QuerySelectMessage) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *QuerySelectMessage) GetAggregates() []*QueryAggregateMessage {
+	if m != nil {
+		return m.Aggregates
+	}
+	return nil
+}
+
+func (m *QuerySelectMessage) GetGroupBy() string {
+	if m != nil {
+		return m.GroupBy
+	}
+	return ""
+}
+
+func (m *QuerySelectMessage) GetTableJoin() *QueryTableJoinMessage {
+	if m != nil {
+		return m.TableJoin
+	}
+	return nil
+}
+
+func (m *QuerySelectMessage) GetExplain() bool {
+	if m != nil {
+		return m.Explain
+	}
+	return false
+}
+
+func (m *QuerySelectMessage) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *QuerySelectMessage) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type QueryTableJoinMessage struct {
+	Table string              `protobuf:"bytes,1,opt,name=table" json:"table,omitempty"`
+	Left  *QueryColumnMessage `protobuf:"bytes,2,opt,name=left" json:"left,omitempty"`
+	Right *QueryColumnMessage `protobuf:"bytes,3,opt,name=right" json:"right,omitempty"`
+}
+
+func (m *QueryTableJoinMessage) Reset()                    { *m = QueryTableJoinMessage{} }
+func (m *QueryTableJoinMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryTableJoinMessage) ProtoMessage()               {}
+func (*QueryTableJoinMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *QueryTableJoinMessage) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *QueryTableJoinMessage) GetLeft() *QueryColumnMessage {
+	if m != nil {
+		return m.Left
+	}
+	return nil
+}
+
+func (m *QueryTableJoinMessage) GetRight() *QueryColumnMessage {
+	if m != nil {
+		return m.Right
+	}
+	return nil
+}
+
+type QueryColumnMessage struct {
+	Table  string `protobuf:"bytes,1,opt,name=table" json:"table,omitempty"`
+	Entry  string `protobuf:"bytes,2,opt,name=entry" json:"entry,omitempty"`
+	RowKey bool   `protobuf:"varint,3,opt,name=rowKey" json:"rowKey,omitempty"`
+}
+
+func (m *QueryColumnMessage) Reset()                    { *m = QueryColumnMessage{} }
+func (m *QueryColumnMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryColumnMessage) ProtoMessage()               {}
+func (*QueryColumnMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *QueryColumnMessage) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *QueryColumnMessage) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *QueryColumnMessage) GetRowKey() bool {
+	if m != nil {
+		return m.RowKey
+	}
+	return false
+}
+
+type QueryAggregateMessage struct {
+	Function uint32 `protobuf:"varint,1,opt,name=function" json:"function,omitempty"`
+	Key      string `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+}
+
+func (m *QueryAggregateMessage) Reset()                    { *m = QueryAggregateMessage{} }
+func (m *QueryAggregateMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryAggregateMessage) ProtoMessage()               {}
+func (*QueryAggregateMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *QueryAggregateMessage) GetFunction() uint32 {
+	if m != nil {
+		return m.Function
+	}
+	return 0
+}
+
+func (m *QueryAggregateMessage) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type QueryOrderByMessage struct {
+	Key        string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	RowKey     bool   `protobuf:"varint,2,opt,name=rowKey" json:"rowKey,omitempty"`
+	Descending bool   `protobuf:"varint,3,opt,name=descending" json:"descending,omitempty"`
+}
+
+func (m *QueryOrderByMessage) Reset()                    { *m = QueryOrderByMessage{} }
+func (m *QueryOrderByMessage) String() string            { return proto1.CompactTextString(m) }
+func (*QueryOrderByMessage) ProtoMessage()               {}
+func (*QueryOrderByMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *QueryOrderByMessage) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueryOrderByMessage) GetRowKey() bool {
+	if m != nil {
+		return m.RowKey
+	}
+	return false
+}
+
+func (m *QueryOrderByMessage) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
 type QueryWhereMessage struct {
 	OpCode    uint32                 `protobuf:"varint,1,opt,name=opCode" json:"opCode,omitempty"`
 	Predicate *QueryPredicateMessage `protobuf:"bytes,2,opt,name=predicate" json:"predicate,omitempty"`
@@ -452,7 +1304,7 @@ type QueryWhereMessage struct {
 func (m *QueryWhereMessage) Reset()                    { *m = QueryWhereMessage{} }
 func (m *QueryWhereMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryWhereMessage) ProtoMessage()               {}
-func (*QueryWhereMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*QueryWhereMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *QueryWhereMessage) GetOpCode() uint32 {
 	if m != nil {
@@ -484,7 +1336,7 @@ type QueryPredicateMessage struct {
 func (m *QueryPredicateMessage) Reset()                    { *m = QueryPredicateMessage{} }
 func (m *QueryPredicateMessage) String() string            { return proto1.CompactTextString(m) }
 func (*QueryPredicateMessage) ProtoMessage()               {}
-func (*QueryPredicateMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*QueryPredicateMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *QueryPredicateMessage) GetFunctionName() string {
 	if m != nil {
@@ -515,7 +1367,7 @@ type PredicateValue struct {
 func (m *PredicateValue) Reset()                    { *m = PredicateValue{} }
 func (m *PredicateValue) String() string            { return proto1.CompactTextString(m) }
 func (*PredicateValue) ProtoMessage()               {}
-func (*PredicateValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*PredicateValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *PredicateValue) GetIsKey() bool {
 	if m != nil {
@@ -533,19 +1385,39 @@ func (m *PredicateValue) GetText() string {
 
 func init() {
 	proto1.RegisterType((*NamespaceMessage)(nil), "proto.NamespaceMessage")
+	proto1.RegisterType((*ShardMessage)(nil), "proto.ShardMessage")
 	proto1.RegisterType((*NamespaceEntryMessage)(nil), "proto.NamespaceEntryMessage")
+	proto1.RegisterType((*CounterShardMessage)(nil), "proto.CounterShardMessage")
 	proto1.RegisterType((*PointMessage)(nil), "proto.PointMessage")
+	proto1.RegisterType((*TimestampMessage)(nil), "proto.TimestampMessage")
 	proto1.RegisterType((*IndexMessage)(nil), "proto.IndexMessage")
 	proto1.RegisterType((*IndexEntryMessage)(nil), "proto.IndexEntryMessage")
 	proto1.RegisterType((*LinkMessage)(nil), "proto.LinkMessage")
 	proto1.RegisterType((*APIRequestMessage)(nil), "proto.APIRequestMessage")
+	proto1.RegisterType((*ExecuteMessage)(nil), "proto.ExecuteMessage")
+	proto1.RegisterType((*VariableMessage)(nil), "proto.VariableMessage")
+	proto1.RegisterType((*TagRequestMessage)(nil), "proto.TagRequestMessage")
+	proto1.RegisterType((*TagMessage)(nil), "proto.TagMessage")
 	proto1.RegisterType((*ReplicateMessage)(nil), "proto.ReplicateMessage")
 	proto1.RegisterType((*APIResponseMessage)(nil), "proto.APIResponseMessage")
+	proto1.RegisterType((*IndexLogEntryMessage)(nil), "proto.IndexLogEntryMessage")
+	proto1.RegisterType((*QueryPlanMessage)(nil), "proto.QueryPlanMessage")
+	proto1.RegisterType((*NamespaceLoadMessage)(nil), "proto.NamespaceLoadMessage")
+	proto1.RegisterType((*PlanPhaseMessage)(nil), "proto.PlanPhaseMessage")
+	proto1.RegisterType((*ResultTableMessage)(nil), "proto.ResultTableMessage")
+	proto1.RegisterType((*ResultRowMessage)(nil), "proto.ResultRowMessage")
 	proto1.RegisterType((*QueryMessage)(nil), "proto.QueryMessage")
 	proto1.RegisterType((*QueryJoinMessage)(nil), "proto.QueryJoinMessage")
 	proto1.RegisterType((*QueryRowJoinMessage)(nil), "proto.QueryRowJoinMessage")
+	proto1.RegisterType((*QueryRowJoinCounterMessage)(nil), "proto.QueryRowJoinCounterMessage")
 	proto1.RegisterType((*QueryRowJoinEntryMessage)(nil), "proto.QueryRowJoinEntryMessage")
+	proto1.RegisterType((*QueryDeleteMessage)(nil), "proto.QueryDeleteMessage")
+	proto1.RegisterType((*QueryRowDeleteMessage)(nil), "proto.QueryRowDeleteMessage")
 	proto1.RegisterType((*QuerySelectMessage)(nil), "proto.QuerySelectMessage")
+	proto1.RegisterType((*QueryTableJoinMessage)(nil), "proto.QueryTableJoinMessage")
+	proto1.RegisterType((*QueryColumnMessage)(nil), "proto.QueryColumnMessage")
+	proto1.RegisterType((*QueryAggregateMessage)(nil), "proto.QueryAggregateMessage")
+	proto1.RegisterType((*QueryOrderByMessage)(nil), "proto.QueryOrderByMessage")
 	proto1.RegisterType((*QueryWhereMessage)(nil), "proto.QueryWhereMessage")
 	proto1.RegisterType((*QueryPredicateMessage)(nil), "proto.QueryPredicateMessage")
 	proto1.RegisterType((*PredicateValue)(nil), "proto.PredicateValue")
@@ -554,52 +1426,117 @@ func init() {
 func init() { proto1.RegisterFile("godless.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 739 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0x96, 0xe3, 0x38, 0x6d, 0xa6, 0xe9, 0x51, 0xba, 0x6d, 0x4f, 0x7d, 0x8e, 0xaa, 0x73, 0xa2,
-	0xbd, 0x0a, 0x42, 0x44, 0xa2, 0x08, 0x24, 0x2a, 0x2e, 0x68, 0x11, 0x88, 0x96, 0x1f, 0x95, 0x45,
-	0x02, 0x09, 0xae, 0xdc, 0x64, 0xda, 0x9a, 0xba, 0x5e, 0xd7, 0x6b, 0x93, 0xe6, 0x0e, 0xf1, 0x1a,
-	0x3c, 0x01, 0xaf, 0xc0, 0x43, 0xf0, 0x4c, 0x68, 0xff, 0xec, 0x75, 0x92, 0x5e, 0x65, 0x67, 0xe6,
-	0xf3, 0xcc, 0xec, 0xf7, 0xcd, 0x4e, 0x60, 0xfd, 0x9c, 0x4f, 0x12, 0x14, 0x62, 0x94, 0xe5, 0xbc,
-	0xe0, 0x24, 0x50, 0x3f, 0xf4, 0x18, 0xfa, 0x6f, 0xa3, 0x2b, 0x14, 0x59, 0x34, 0xc6, 0x37, 0x28,
-	0x44, 0x74, 0x8e, 0xe4, 0x11, 0xac, 0x60, 0x5a, 0xe4, 0x31, 0x8a, 0xd0, 0x1b, 0xf8, 0xc3, 0xb5,
-	0xbd, 0x5d, 0xfd, 0xcd, 0xa8, 0x42, 0x3e, 0x4f, 0x8b, 0x7c, 0x66, 0xe0, 0xcc, 0x82, 0xe9, 0x37,
-	0x0f, 0xb6, 0x97, 0x42, 0xc8, 0x16, 0x04, 0x45, 0x74, 0x9a, 0x60, 0xe8, 0x0d, 0xbc, 0x61, 0x97,
-	0x69, 0x83, 0xf4, 0xc1, 0xcf, 0xf9, 0x34, 0x6c, 0x29, 0x9f, 0x3c, 0x4a, 0x9c, 0x4c, 0x36, 0x0b,
-	0x7d, 0x8d, 0x53, 0x06, 0xb9, 0x03, 0x41, 0xc6, 0xe3, 0xb4, 0x08, 0xdb, 0x03, 0x6f, 0xb8, 0xb6,
-	0xb7, 0x69, 0xba, 0x39, 0x91, 0x3e, 0xdb, 0x84, 0x46, 0xd0, 0xa7, 0xd0, 0x73, 0xdd, 0x84, 0x40,
-	0xbb, 0xc0, 0x9b, 0xc2, 0xd4, 0x55, 0x67, 0xb2, 0x0b, 0x5d, 0x11, 0x9f, 0xa7, 0x51, 0x51, 0xe6,
-	0x68, 0x8a, 0xd7, 0x0e, 0x7a, 0x08, 0xbd, 0xa3, 0x74, 0x82, 0x37, 0x36, 0xc3, 0xde, 0x3c, 0x19,
-	0xa1, 0x29, 0xaf, 0x50, 0xcb, 0x89, 0xf8, 0x0c, 0x1b, 0x0b, 0xd1, 0x5b, 0x38, 0x20, 0xd0, 0x4e,
-	0xe2, 0xf4, 0xd2, 0xf4, 0xa1, 0xce, 0xcd, 0x06, 0xfd, 0xf9, 0x06, 0x0f, 0x60, 0xed, 0x75, 0x9c,
-	0x5e, 0x3a, 0x37, 0x54, 0x09, 0x3c, 0x27, 0xc1, 0x7f, 0x00, 0x15, 0x5e, 0x84, 0xad, 0x81, 0x3f,
-	0xec, 0x32, 0xc7, 0x43, 0x7f, 0x7a, 0xb0, 0x71, 0x70, 0x72, 0xc4, 0xf0, 0xba, 0x44, 0xd1, 0xe0,
-	0x6a, 0x96, 0xe9, 0xfe, 0xd6, 0x99, 0x3a, 0xcb, 0x4c, 0x39, 0x9e, 0x25, 0x38, 0x2e, 0x62, 0x9e,
-	0xaa, 0x26, 0xd7, 0x99, 0xe3, 0x91, 0xd2, 0x5c, 0x97, 0x68, 0x04, 0xab, 0xa5, 0x79, 0x27, 0x7d,
-	0x95, 0x34, 0x0a, 0x41, 0x1e, 0x42, 0x37, 0xc7, 0x2c, 0x89, 0xc7, 0x51, 0x81, 0x46, 0xc9, 0x1d,
-	0x03, 0x67, 0xd6, 0x6f, 0x3f, 0xa9, 0x91, 0xf4, 0x09, 0xf4, 0xe7, 0xc3, 0x64, 0x08, 0x81, 0xbc,
-	0xa7, 0x55, 0x84, 0x98, 0x34, 0x0e, 0x2d, 0x4c, 0x03, 0xe8, 0x6f, 0x0f, 0x88, 0xba, 0xa9, 0xc8,
-	0x78, 0x2a, 0xaa, 0x04, 0x21, 0xac, 0x5c, 0xe9, 0xa3, 0xe1, 0xcd, 0x9a, 0x6a, 0x02, 0xf3, 0x9c,
-	0xe7, 0x46, 0x10, 0x6d, 0x54, 0xd4, 0xf8, 0x0e, 0x35, 0x04, 0xda, 0x59, 0x54, 0x5c, 0xa8, 0xab,
-	0x74, 0x99, 0x3a, 0xcb, 0x3b, 0xa6, 0xf6, 0x01, 0x84, 0x41, 0xe3, 0x8e, 0xf3, 0xaf, 0x8c, 0xd5,
-	0x48, 0xc9, 0x62, 0x2c, 0xe7, 0x25, 0xec, 0x34, 0x58, 0x74, 0xe7, 0x90, 0x69, 0x04, 0xfd, 0xe5,
-	0x41, 0xcf, 0x65, 0x97, 0xfc, 0x0d, 0x1d, 0x9e, 0x3d, 0xe3, 0x13, 0xab, 0x9b, 0xb1, 0xea, 0x71,
-	0x6b, 0xb9, 0xe3, 0x76, 0x17, 0xda, 0x5f, 0x78, 0x9c, 0x1a, 0xb9, 0x76, 0x5c, 0xb9, 0x8e, 0x79,
-	0x9c, 0xda, 0x62, 0x0a, 0x44, 0xee, 0x43, 0x47, 0xa0, 0x54, 0xda, 0xc8, 0xf5, 0x8f, 0x0b, 0x7f,
-	0xaf, 0x22, 0xf6, 0x03, 0x03, 0x94, 0xa3, 0x7b, 0x89, 0xb3, 0x97, 0x91, 0xb8, 0x40, 0x11, 0x06,
-	0x6a, 0xf0, 0x6a, 0x07, 0x3d, 0x84, 0xfe, 0x7c, 0x29, 0x32, 0x82, 0x76, 0xce, 0xa7, 0x56, 0xca,
-	0x7f, 0xdd, 0x12, 0x8c, 0x4f, 0x1b, 0x4d, 0x49, 0x1c, 0x3d, 0x85, 0xcd, 0x25, 0x41, 0xbb, 0x4b,
-	0xbc, 0x7a, 0x97, 0x3c, 0xae, 0x1f, 0x6e, 0x4b, 0xe5, 0xfe, 0x7f, 0x49, 0xee, 0xe5, 0xef, 0xf7,
-	0x05, 0x84, 0xb7, 0x81, 0xea, 0x15, 0xe5, 0xb9, 0x2b, 0x6a, 0xcb, 0xae, 0x28, 0xc3, 0xb6, 0xde,
-	0x46, 0x9f, 0x80, 0x2c, 0x72, 0x25, 0xb1, 0x49, 0x7c, 0x15, 0x17, 0x46, 0x30, 0x6d, 0x90, 0x11,
-	0x04, 0xd3, 0x0b, 0x34, 0x1b, 0xa9, 0xde, 0x32, 0xea, 0xfb, 0x8f, 0x32, 0x50, 0x0d, 0x82, 0x82,
-	0xd1, 0x1f, 0x1e, 0x6c, 0x2c, 0x04, 0x6f, 0x9d, 0x86, 0x7d, 0xe8, 0x66, 0x39, 0x4e, 0xf4, 0xe3,
-	0xd3, 0x15, 0x76, 0xdd, 0x0a, 0x27, 0x36, 0x58, 0x4d, 0x67, 0x05, 0x97, 0x1b, 0x70, 0x9c, 0x44,
-	0xa5, 0x40, 0x11, 0xfa, 0x8d, 0x0d, 0xb8, 0xd8, 0x9b, 0x05, 0xd2, 0xef, 0x1e, 0x6c, 0x2f, 0x4d,
-	0x4c, 0x28, 0xf4, 0xce, 0xca, 0x54, 0x6d, 0x0f, 0xf9, 0x24, 0x0c, 0x8d, 0x0d, 0x1f, 0xb9, 0x07,
-	0x9d, 0xaf, 0x51, 0x52, 0x56, 0xca, 0x6d, 0xdb, 0x8d, 0x6f, 0x93, 0x7d, 0x90, 0x51, 0x66, 0x40,
-	0xf2, 0xd2, 0xa5, 0x40, 0x29, 0xbf, 0x1c, 0xeb, 0x55, 0x66, 0x2c, 0xba, 0x0f, 0x7f, 0x35, 0xbf,
-	0x90, 0xd4, 0xc7, 0xe2, 0x15, 0x6a, 0xf1, 0x56, 0x99, 0x36, 0xaa, 0x3f, 0x89, 0x56, 0xfd, 0x27,
-	0x71, 0xda, 0x51, 0x15, 0x1f, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x87, 0x83, 0x87, 0x36,
-	0x07, 0x00, 0x00,
+	// 1779 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x57, 0x5f, 0x8f, 0x1c, 0x47,
+	0x11, 0xd7, 0xec, 0xbf, 0xdb, 0xad, 0xbb, 0x0b, 0xeb, 0x3e, 0x9f, 0x19, 0x1c, 0x2b, 0x2c, 0x2d,
+	0x90, 0x2e, 0xb1, 0x62, 0x13, 0xe3, 0x44, 0xc2, 0x0a, 0x0f, 0x8e, 0x71, 0xe4, 0x24, 0x26, 0xb9,
+	0x4c, 0x4e, 0x01, 0x91, 0x07, 0xd4, 0xb7, 0x53, 0x37, 0x3b, 0x78, 0x76, 0x7a, 0x3d, 0xdd, 0xeb,
+	0xbd, 0x7b, 0x45, 0xbc, 0xf2, 0xca, 0x0b, 0x12, 0x12, 0x7c, 0x0e, 0x3e, 0x06, 0x9f, 0x81, 0x37,
+	0x3e, 0x03, 0xa8, 0xfa, 0xdf, 0xcc, 0xec, 0xce, 0xc2, 0xd3, 0x74, 0x55, 0xfd, 0xa6, 0xba, 0xba,
+	0xfe, 0x75, 0x35, 0x1c, 0x67, 0x32, 0x2d, 0x50, 0xa9, 0x07, 0xab, 0x4a, 0x6a, 0xc9, 0x86, 0xe6,
+	0xc3, 0x37, 0x30, 0xfd, 0x52, 0x2c, 0x51, 0xad, 0xc4, 0x1c, 0x7f, 0x85, 0x4a, 0x89, 0x0c, 0xd9,
+	0x47, 0x70, 0x80, 0xa5, 0xae, 0x72, 0x54, 0x71, 0x34, 0xeb, 0x9f, 0x1d, 0x3e, 0xba, 0x67, 0xff,
+	0x79, 0x10, 0x90, 0xcf, 0x4b, 0x5d, 0xdd, 0x38, 0x78, 0xe2, 0xc1, 0xec, 0x3e, 0x8c, 0xd4, 0x42,
+	0x54, 0xa9, 0x8a, 0x7b, 0xe6, 0xb7, 0x13, 0xf7, 0xdb, 0x37, 0xc4, 0xf4, 0x68, 0x07, 0xe1, 0x1f,
+	0xc1, 0x51, 0x93, 0xcf, 0x18, 0x0c, 0x54, 0x21, 0x75, 0x1c, 0xcd, 0xa2, 0xb3, 0xe3, 0xc4, 0xac,
+	0x89, 0x57, 0xe4, 0xe5, 0xab, 0xb8, 0x37, 0x8b, 0xce, 0x26, 0x89, 0x59, 0xf3, 0x7f, 0x46, 0x70,
+	0xda, 0x69, 0x07, 0xbb, 0x0d, 0x43, 0x2d, 0x2e, 0x0b, 0x34, 0x2a, 0x26, 0x89, 0x25, 0xd8, 0x14,
+	0xfa, 0x95, 0xdc, 0x38, 0x15, 0xb4, 0x24, 0x1c, 0x59, 0x7c, 0x13, 0xf7, 0x2d, 0xce, 0x10, 0xec,
+	0x5d, 0x18, 0xae, 0x64, 0x5e, 0xea, 0x78, 0x30, 0x8b, 0x1a, 0xb6, 0x9f, 0x13, 0xcf, 0xdb, 0x6e,
+	0x11, 0xec, 0x1e, 0x4c, 0xb4, 0x5c, 0x5e, 0x2a, 0x2d, 0x4b, 0x8c, 0x87, 0xb3, 0xe8, 0x6c, 0x9c,
+	0xd4, 0x0c, 0xf6, 0x18, 0x0e, 0xe6, 0x72, 0x5d, 0x6a, 0xac, 0xe2, 0x91, 0x51, 0x75, 0xd7, 0xa9,
+	0x7a, 0x66, 0xb9, 0x2d, 0x6f, 0x78, 0x28, 0x97, 0x70, 0xd2, 0x21, 0x67, 0x31, 0x1c, 0x54, 0xb8,
+	0x2a, 0xf2, 0xb9, 0x70, 0xa7, 0xf2, 0x24, 0x7b, 0x07, 0x20, 0x2f, 0xe7, 0x15, 0x2e, 0xb1, 0xd4,
+	0xca, 0x1c, 0x6f, 0x90, 0x34, 0x38, 0x24, 0x4f, 0x31, 0xc8, 0xfb, 0x56, 0x5e, 0x73, 0xf8, 0xdf,
+	0x23, 0x38, 0x6a, 0x1e, 0x8e, 0x9c, 0xad, 0xf1, 0x5a, 0xbb, 0x7d, 0xcc, 0x9a, 0x4e, 0xaa, 0xf2,
+	0xac, 0x14, 0x7a, 0x5d, 0xa1, 0x73, 0x61, 0xcd, 0x60, 0x1f, 0xc2, 0x44, 0xe7, 0x4b, 0x54, 0x5a,
+	0x2c, 0x57, 0x66, 0x87, 0xc3, 0x47, 0xdf, 0x77, 0x67, 0xbd, 0xf0, 0x7c, 0x7f, 0xd0, 0x1a, 0xc9,
+	0xde, 0x85, 0xbe, 0x16, 0x59, 0x3c, 0xf8, 0xdf, 0x3f, 0x10, 0x86, 0x5f, 0xc0, 0x74, 0x5b, 0x40,
+	0x76, 0x6e, 0x44, 0x51, 0x18, 0x3b, 0xfb, 0x89, 0x59, 0x93, 0x9b, 0x0a, 0x99, 0xe5, 0x73, 0x51,
+	0x18, 0x2b, 0x8f, 0x13, 0x4f, 0x12, 0xba, 0x94, 0x29, 0xba, 0x58, 0x9b, 0x35, 0xff, 0x6b, 0x04,
+	0x47, 0x9f, 0x95, 0x29, 0x5e, 0x7b, 0x95, 0x8f, 0xb6, 0x13, 0x3e, 0x76, 0x56, 0x19, 0x54, 0x77,
+	0xb2, 0xc7, 0x70, 0xb0, 0x12, 0x95, 0x73, 0x7e, 0x9f, 0x22, 0xe3, 0x48, 0x92, 0xcc, 0x2b, 0x14,
+	0x1a, 0x53, 0xb3, 0x6b, 0x3f, 0xf1, 0x24, 0x19, 0x73, 0x29, 0x14, 0x9a, 0xa3, 0x4f, 0x12, 0xb3,
+	0x26, 0xde, 0x02, 0x45, 0x6a, 0xf2, 0x68, 0x92, 0x98, 0x35, 0xff, 0x0e, 0x6e, 0xed, 0xec, 0xbc,
+	0x27, 0xbd, 0x3b, 0x4a, 0xa4, 0x1d, 0xb5, 0xfe, 0x56, 0xd4, 0xf8, 0x53, 0x38, 0x7c, 0x99, 0x97,
+	0xaf, 0x1a, 0xee, 0x34, 0x0a, 0xa2, 0x86, 0x82, 0x77, 0x00, 0x02, 0xde, 0x1f, 0xaf, 0xc1, 0xe1,
+	0xff, 0xe9, 0xc1, 0xad, 0xa7, 0xe7, 0x9f, 0x25, 0xf8, 0x7a, 0x8d, 0xaa, 0x95, 0x40, 0x37, 0x2b,
+	0xf4, 0x15, 0x4c, 0x6b, 0xd2, 0x54, 0xe1, 0x55, 0x81, 0x73, 0x9d, 0xcb, 0xd2, 0xc5, 0xa6, 0xc1,
+	0xa1, 0xaa, 0x7b, 0xbd, 0x46, 0x57, 0x8b, 0x75, 0xd5, 0x7d, 0x4d, 0xbc, 0x50, 0x75, 0x06, 0x41,
+	0xd9, 0xe6, 0x72, 0x5f, 0xe3, 0x56, 0xf2, 0x24, 0x9e, 0x1f, 0xb2, 0x2d, 0x20, 0xd9, 0x7b, 0x36,
+	0xdb, 0x86, 0xb3, 0xa8, 0x11, 0xd7, 0x0b, 0x91, 0xb5, 0x8d, 0x37, 0xe9, 0xc6, 0xee, 0xc0, 0xa8,
+	0xc2, 0x37, 0x58, 0x69, 0x53, 0xb9, 0x93, 0xc4, 0x51, 0x26, 0xd6, 0x15, 0x52, 0x7c, 0xe3, 0x03,
+	0x5b, 0x85, 0x8e, 0x64, 0x0f, 0xe1, 0x00, 0xaf, 0x71, 0xbe, 0xd6, 0x18, 0x8f, 0xcd, 0x0e, 0xa7,
+	0x6e, 0x87, 0xe7, 0x96, 0x5b, 0xa7, 0x8d, 0xa5, 0xe9, 0xc0, 0x97, 0x42, 0xcf, 0x17, 0xf1, 0x64,
+	0xd6, 0xdf, 0x7b, 0x60, 0x83, 0x60, 0x77, 0x61, 0x5c, 0xc8, 0xec, 0x65, 0xbe, 0xcc, 0x75, 0x0c,
+	0xc6, 0x73, 0x81, 0xe6, 0x29, 0xbc, 0xd5, 0xde, 0xc1, 0x04, 0x5d, 0x0b, 0x6d, 0xaa, 0xdb, 0x05,
+	0xb3, 0x66, 0xb0, 0xc7, 0x30, 0x79, 0x23, 0xaa, 0x9c, 0x52, 0xc6, 0x77, 0xe7, 0x3b, 0x6e, 0xeb,
+	0x6f, 0x1d, 0x3f, 0xf8, 0x2e, 0x00, 0xf9, 0xd7, 0xf0, 0xbd, 0x2d, 0x69, 0x67, 0x90, 0x7d, 0xe7,
+	0xe8, 0x35, 0x3a, 0xc7, 0x1d, 0x18, 0x95, 0xeb, 0xe5, 0x25, 0x56, 0xae, 0x06, 0x1c, 0x45, 0xa9,
+	0xbd, 0xe3, 0x7c, 0x53, 0x31, 0x72, 0xb9, 0x14, 0x65, 0xea, 0xf4, 0x7a, 0xd2, 0x94, 0xaf, 0x58,
+	0xfa, 0xde, 0x63, 0xd6, 0xc4, 0x5b, 0x09, 0xbd, 0xf0, 0x25, 0x4d, 0xeb, 0xcf, 0x07, 0xe3, 0xc1,
+	0x74, 0xc8, 0x1f, 0x03, 0x5c, 0x88, 0xac, 0x61, 0xaa, 0xf9, 0x37, 0xea, 0xf8, 0xb7, 0x57, 0xff,
+	0xcb, 0x3f, 0x86, 0xe9, 0x76, 0x02, 0xb1, 0x33, 0x18, 0x52, 0x25, 0xf8, 0x7e, 0xc0, 0x9c, 0xaf,
+	0x1a, 0x85, 0x93, 0x58, 0x00, 0xff, 0x57, 0x1f, 0x98, 0xa9, 0x05, 0xb5, 0x92, 0xa5, 0xc2, 0xc6,
+	0x91, 0x96, 0x76, 0xe9, 0x1b, 0xf7, 0xb2, 0xae, 0x63, 0xac, 0x2a, 0x59, 0x39, 0x1b, 0x2c, 0x11,
+	0xfc, 0xda, 0x6f, 0xfb, 0xd5, 0x18, 0x3b, 0xa8, 0x8d, 0xa5, 0x2a, 0x28, 0xfd, 0xed, 0x17, 0x0f,
+	0x5b, 0x55, 0xb0, 0x7d, 0x8f, 0x27, 0x35, 0x92, 0xd2, 0x2e, 0xa7, 0x8e, 0xe2, 0xae, 0xa4, 0x93,
+	0x66, 0x7f, 0x0b, 0x07, 0x32, 0x08, 0x4a, 0xbb, 0x4a, 0x6e, 0xbe, 0xaa, 0x52, 0xac, 0xe2, 0x03,
+	0x53, 0xfa, 0x81, 0x66, 0x0f, 0x7d, 0x0f, 0xb2, 0xc9, 0xfe, 0x83, 0x50, 0x7f, 0x6a, 0x5d, 0xe8,
+	0x8b, 0x66, 0x16, 0x59, 0x1c, 0xbb, 0x0f, 0x83, 0x55, 0x21, 0xca, 0x78, 0xd2, 0xb2, 0xd4, 0x64,
+	0xfb, 0x79, 0x21, 0x4a, 0x8f, 0x36, 0x20, 0xf6, 0x21, 0x1c, 0x2c, 0x72, 0xa5, 0x65, 0x75, 0x13,
+	0x83, 0x71, 0xfb, 0xdb, 0x4d, 0x33, 0x5f, 0xca, 0xac, 0xdd, 0x89, 0x1d, 0x96, 0xfd, 0x04, 0x06,
+	0x5a, 0x64, 0x2a, 0x3e, 0x34, 0xff, 0xdc, 0xaa, 0x4b, 0x3c, 0x68, 0x27, 0x71, 0xbb, 0x40, 0x8e,
+	0xb6, 0x0b, 0x64, 0x06, 0x87, 0x15, 0x2e, 0xe5, 0x1b, 0x4c, 0x13, 0xb9, 0x51, 0xf1, 0xb1, 0x39,
+	0x78, 0x93, 0xc5, 0xff, 0x18, 0xc1, 0xed, 0x2e, 0x43, 0x42, 0x98, 0xa2, 0x46, 0x98, 0x1a, 0x77,
+	0x40, 0xaf, 0x7d, 0x07, 0x34, 0xee, 0x8d, 0x7e, 0xfb, 0xde, 0xf8, 0x31, 0x1c, 0xcf, 0x17, 0xa2,
+	0xcc, 0x30, 0xbd, 0xb0, 0x75, 0x3a, 0x30, 0xf2, 0x36, 0x93, 0xff, 0x23, 0x82, 0xe9, 0xb6, 0xff,
+	0xec, 0x30, 0x40, 0xa6, 0x85, 0x9c, 0x9d, 0x24, 0x0d, 0x0e, 0xfb, 0x00, 0x86, 0x85, 0x14, 0x61,
+	0x30, 0x7b, 0x7b, 0x3b, 0x63, 0x5e, 0x4a, 0x91, 0xd6, 0x79, 0x4d, 0x48, 0xb2, 0xa6, 0x92, 0x1b,
+	0xf5, 0xfc, 0x8d, 0x28, 0xd6, 0xe1, 0x2e, 0x3b, 0x4e, 0xda, 0x4c, 0xf6, 0x10, 0x46, 0xab, 0x85,
+	0x50, 0xce, 0xd8, 0x3a, 0xc2, 0x64, 0xdc, 0x39, 0x09, 0xc2, 0xd8, 0x67, 0x61, 0xfc, 0xb7, 0x70,
+	0xbb, 0x6b, 0xd7, 0x4e, 0x27, 0xde, 0x81, 0x91, 0x92, 0xeb, 0x6a, 0x8e, 0xee, 0xe2, 0x70, 0x14,
+	0xf1, 0xaf, 0x44, 0x5e, 0x38, 0x9b, 0xc6, 0x89, 0xa3, 0xf8, 0x0b, 0x98, 0x6e, 0xef, 0xdb, 0xd9,
+	0x04, 0x66, 0x70, 0x58, 0x8a, 0x52, 0x2a, 0x9c, 0xcb, 0x32, 0x55, 0x2e, 0x40, 0x4d, 0x16, 0xff,
+	0x0e, 0xd8, 0x6e, 0x4e, 0xdb, 0x36, 0x55, 0xac, 0x97, 0xa5, 0x77, 0xb1, 0x27, 0x29, 0xcd, 0xc9,
+	0x2f, 0x71, 0xaf, 0xe5, 0x04, 0xab, 0x22, 0x91, 0x9b, 0x90, 0x88, 0x04, 0xe2, 0xef, 0xc1, 0x74,
+	0x5b, 0x42, 0x47, 0x22, 0x9f, 0xa2, 0xd7, 0xec, 0x28, 0xfe, 0xef, 0x08, 0x8e, 0x9a, 0x77, 0x03,
+	0x01, 0xe5, 0xea, 0x99, 0x4c, 0xed, 0x89, 0x8e, 0x13, 0x47, 0xd5, 0xd3, 0x41, 0xaf, 0x39, 0x1d,
+	0xdc, 0x87, 0xc1, 0xef, 0x65, 0x5e, 0x6e, 0x0d, 0x67, 0x46, 0xe1, 0xe7, 0x32, 0xaf, 0xcb, 0x8f,
+	0x40, 0xec, 0x03, 0x18, 0x29, 0xa4, 0x8b, 0x39, 0x1e, 0xb4, 0xaa, 0xdb, 0xc0, 0xbf, 0x31, 0x92,
+	0x7a, 0x88, 0x37, 0x24, 0xd5, 0xd4, 0x2b, 0xbc, 0x79, 0x21, 0xd4, 0x02, 0x55, 0x3c, 0x34, 0x96,
+	0xd7, 0x0c, 0x52, 0x98, 0x62, 0x81, 0x1a, 0xe3, 0xd1, 0xae, 0xc2, 0x5f, 0x1a, 0x49, 0x50, 0x68,
+	0x81, 0x34, 0xf0, 0x6d, 0x5b, 0xc7, 0x1e, 0x38, 0xe7, 0xda, 0x56, 0x7c, 0xb7, 0xa9, 0x24, 0x91,
+	0x9b, 0xd6, 0x39, 0x08, 0x47, 0x13, 0x7f, 0xb1, 0xb1, 0x13, 0xff, 0x38, 0xa1, 0x25, 0xff, 0x5b,
+	0x04, 0x27, 0x1d, 0x78, 0xff, 0x36, 0x88, 0xea, 0xb7, 0xc1, 0xcf, 0xeb, 0x49, 0xd0, 0xc6, 0xf2,
+	0x87, 0x1d, 0xdb, 0x75, 0x0f, 0x84, 0xbf, 0x80, 0xb1, 0x1b, 0xe6, 0x6d, 0x65, 0x1f, 0x3e, 0xfa,
+	0x51, 0xc7, 0xbf, 0x6e, 0xc8, 0xf7, 0x7f, 0x87, 0x5f, 0xf8, 0x0b, 0xb8, 0xbb, 0x1f, 0x57, 0xbf,
+	0x59, 0xa2, 0xe6, 0x9b, 0xe5, 0x36, 0x0c, 0x53, 0x2c, 0xb4, 0x30, 0x67, 0x65, 0x89, 0x25, 0xf8,
+	0xa7, 0x10, 0xef, 0xb3, 0x76, 0xbf, 0x1e, 0xfb, 0xf6, 0x71, 0xc9, 0x63, 0x08, 0xfe, 0x29, 0xb0,
+	0xdd, 0x48, 0xb1, 0x9f, 0xb6, 0xa2, 0x71, 0x6f, 0xeb, 0x88, 0xed, 0xa8, 0xda, 0x7c, 0x7f, 0x06,
+	0xa7, 0x9d, 0xe2, 0x0e, 0xf7, 0xc7, 0x6d, 0xf7, 0x4f, 0x82, 0x77, 0xf9, 0x9f, 0xfb, 0xc0, 0x76,
+	0x13, 0x91, 0x2c, 0x2f, 0xcc, 0x80, 0x64, 0xab, 0xc1, 0x12, 0xec, 0x01, 0x0c, 0x37, 0x0b, 0x74,
+	0x4f, 0x96, 0x7a, 0xea, 0x33, 0xff, 0xff, 0x9a, 0x04, 0xa1, 0xd7, 0x19, 0x98, 0x69, 0x28, 0x39,
+	0x16, 0xa9, 0x6f, 0xc9, 0x8e, 0xa2, 0xa7, 0x9c, 0xa4, 0x7b, 0xef, 0x93, 0x1b, 0x57, 0x12, 0xad,
+	0xe4, 0xfb, 0xca, 0x8a, 0x42, 0x22, 0x38, 0xa8, 0x29, 0xd1, 0xab, 0x2b, 0x85, 0x3a, 0x1e, 0xba,
+	0x12, 0x35, 0x14, 0xfb, 0x18, 0x40, 0x64, 0x59, 0x85, 0x99, 0xd0, 0xa8, 0xe2, 0xd1, 0xae, 0xff,
+	0x9e, 0x7a, 0xa9, 0x57, 0xd9, 0xc0, 0x93, 0x6b, 0xb2, 0x4a, 0xae, 0x57, 0x9f, 0xdc, 0xf8, 0x19,
+	0xd4, 0x91, 0xec, 0x09, 0x4c, 0x4c, 0xb5, 0x53, 0xb0, 0xdd, 0xc5, 0xdc, 0x52, 0x7b, 0xe1, 0x85,
+	0xf5, 0x5b, 0xcc, 0x73, 0x8c, 0xc3, 0xaf, 0x57, 0x85, 0xc8, 0xed, 0x15, 0x3d, 0x4e, 0x3c, 0x49,
+	0x9e, 0xb5, 0x13, 0x03, 0xd8, 0x9c, 0x30, 0x04, 0x9b, 0xda, 0x69, 0xfa, 0xd0, 0x86, 0x8c, 0x9e,
+	0x68, 0x7f, 0x8a, 0xe0, 0xb4, 0x73, 0x9b, 0x3d, 0x0f, 0x96, 0xf7, 0x61, 0x50, 0xe0, 0x95, 0x8e,
+	0x7b, 0xbb, 0x2d, 0xe1, 0x99, 0xe9, 0xa6, 0x21, 0x79, 0x08, 0x46, 0x13, 0x47, 0x95, 0x67, 0x0b,
+	0x1d, 0xf7, 0xff, 0x1f, 0xde, 0xe2, 0xf8, 0x6f, 0x80, 0xed, 0x0a, 0xf7, 0xd8, 0x12, 0xaa, 0xa1,
+	0xd7, 0xac, 0x06, 0x7a, 0x05, 0xc8, 0xcd, 0x17, 0x78, 0xe3, 0xaf, 0x17, 0x4b, 0xf1, 0xe7, 0x70,
+	0xda, 0x19, 0x26, 0x9a, 0x98, 0xae, 0xd6, 0xa5, 0x7d, 0xe2, 0xd8, 0x3c, 0x0c, 0x34, 0x39, 0xec,
+	0x15, 0xfa, 0x0d, 0x68, 0xc9, 0x7f, 0x07, 0x27, 0x1d, 0xe9, 0xe3, 0x81, 0x51, 0x00, 0x36, 0xec,
+	0xe8, 0x35, 0xed, 0xb0, 0x2f, 0x7b, 0x35, 0xc7, 0x32, 0xcd, 0xcb, 0xcc, 0xd9, 0xd8, 0xe0, 0xf0,
+	0xbf, 0x44, 0x70, 0x6b, 0x27, 0xd5, 0xf7, 0x5e, 0x1c, 0x4f, 0x60, 0xb2, 0xaa, 0x30, 0xb5, 0xcf,
+	0xaa, 0xde, 0x6e, 0xf6, 0x9c, 0x7b, 0x61, 0xc8, 0x9e, 0x00, 0xa7, 0x77, 0xf3, 0xbc, 0x10, 0x6b,
+	0x85, 0xbe, 0xe3, 0xed, 0xaf, 0x34, 0x0f, 0xe4, 0x7f, 0xf0, 0xf9, 0xb2, 0xad, 0x98, 0x71, 0x38,
+	0xf2, 0x6e, 0xfb, 0xb2, 0xbe, 0xb2, 0x5b, 0x3c, 0xf6, 0x7e, 0xb8, 0x27, 0x6d, 0x7b, 0xf6, 0xcf,
+	0xad, 0xa0, 0xec, 0x5b, 0x92, 0xfa, 0xeb, 0x93, 0x0e, 0xbd, 0x56, 0x48, 0x4d, 0xc6, 0x85, 0xd2,
+	0x52, 0xfc, 0x09, 0xbc, 0xd5, 0xfe, 0xc3, 0xa4, 0xbb, 0xfa, 0xc2, 0x05, 0x60, 0x9c, 0x58, 0xa2,
+	0xeb, 0x65, 0x73, 0x39, 0x32, 0x3b, 0xfe, 0xec, 0xbf, 0x03, 0x00, 0x3f, 0x22, 0x1d, 0xf2, 0x50,
+	0x13, 0x00, 0x00,
 }
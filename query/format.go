@@ -0,0 +1,117 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FormatOptions controls the layout of a formatted query.
+type FormatOptions struct {
+	// Indent is written once for each level of nesting.  A tab is used if
+	// Indent is empty.
+	Indent string
+	// Compact writes the query on a single line.
+	Compact bool
+}
+
+// Format writes the query in canonical layout.  Equal queries are always
+// formatted to the same text.
+func (query *Query) Format(w io.Writer, options FormatOptions) error {
+	// Aggregates come before the table name, so the printer needs them early.
+	printer := &queryPrinter{
+		output:     w,
+		aggregates: query.Select.Aggregates,
+		explain:    query.Select.Explain,
+		indentText: options.Indent,
+		compact:    options.Compact,
+	}
+
+	query.Visit(printer)
+
+	return printer.Error()
+}
+
+// FormatText formats the query, and checks that the text compiles back to an
+// equal query.
+func (query *Query) FormatText(options FormatOptions) (string, error) {
+	const failMsg = "FormatText failed"
+
+	buff := &bytes.Buffer{}
+	err := query.Format(buff, options)
+
+	if err != nil {
+		return "", errors.Wrap(err, failMsg)
+	}
+
+	text := buff.String()
+	roundTrip, err := Compile(text)
+
+	if err != nil {
+		return "", errors.Wrap(err, "BUG formatted query did not compile")
+	}
+
+	if !query.Equals(roundTrip) {
+		return "", fmt.Errorf("BUG formatted query was not equal to original: %s", text)
+	}
+
+	return text, nil
+}
+
+// FormatSource compiles the query text and formats it.  The source may be a
+// script of many statements, but must not contain placeholders.  Comments are
+// kept, on their own lines above the statement they were found in.
+func FormatSource(source string, options FormatOptions) (string, error) {
+	const failMsg = "FormatSource failed"
+
+	parser, err := parseSource(source)
+
+	if err != nil {
+		return "", errors.Wrap(err, failMsg)
+	}
+
+	buff := &bytes.Buffer{}
+	isScript := len(parser.Statements) > 1
+
+	for i, ast := range parser.Statements {
+		query, err := compileStatement(parser, ast, CompileContext{})
+
+		if err != nil {
+			return "", errors.Wrapf(err, "%s at statement %d", failMsg, i+1)
+		}
+
+		text, err := query.FormatText(options)
+
+		if err != nil {
+			return "", errors.Wrapf(err, "%s at statement %d", failMsg, i+1)
+		}
+
+		if i > 0 {
+			buff.WriteString("\n\n")
+		}
+
+		for _, comment := range ast.Comments {
+			buff.WriteString(comment)
+			buff.WriteString("\n")
+		}
+
+		buff.WriteString(text)
+
+		if isScript {
+			buff.WriteString(";")
+		}
+	}
+
+	if len(parser.Comments) > 0 {
+		if len(parser.Statements) > 0 {
+			buff.WriteString("\n")
+		}
+
+		buff.WriteString(strings.Join(parser.Comments, "\n"))
+	}
+
+	return buff.String(), nil
+}
@@ -0,0 +1,101 @@
+package query
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+		return
+	}
+
+	config := &quick.Config{
+		MaxCount: PARSE_REPEAT_COUNT,
+	}
+
+	err := quick.Check(queryFormatOk, config)
+
+	if err != nil {
+		t.Error("Unexpected error:", testutil.Trim(err))
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	const source = `select cars
+		where and(str_eq(driver, "Mr Fast"), not(str_eq(@key, "car1")))
+		order by driver desc
+		limit 5`
+	const expected = `select cars where and(str_eq(driver, "Mr Fast"), not(str_eq(@key, "car1"))) order by driver desc limit 5`
+
+	actual, err := FormatSource(source, FormatOptions{Compact: true})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected format", expected, actual)
+}
+
+func TestFormatIndent(t *testing.T) {
+	const source = `join cars rows (@key=car1, driver="Mr Fast")`
+	const expected = "join cars rows\n  (\n    @key=@\"car1\",\n    driver=\"Mr Fast\"\n  )"
+
+	actual, err := FormatSource(source, FormatOptions{Indent: "  "})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected format", expected, actual)
+}
+
+func queryFormatOk(expected *Query) bool {
+	layouts := []FormatOptions{
+		FormatOptions{},
+		FormatOptions{Compact: true},
+		FormatOptions{Indent: "  "},
+	}
+
+	for _, options := range layouts {
+		text, err := expected.FormatText(options)
+
+		if err != nil {
+			testutil.LogDiff(prettyQuery(expected), err.Error())
+			return false
+		}
+
+		again, err := FormatSource(text, options)
+
+		if err != nil || again != text {
+			testutil.LogDiff(text, again)
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestFormatEntryPredicates(t *testing.T) {
+	const source = "select books where exists(a)"
+	const expected = "select books where\n\texists(\n\t\ta\n\t)"
+
+	actual, err := FormatSource(source, FormatOptions{})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected format", expected, actual)
+
+	sources := []string{
+		"select books where missing(@key, a)",
+		"select books where not(exists(a))",
+		`select books where and(missing(a), str_eq(b, "c"))`,
+	}
+
+	for _, source := range sources {
+		actual, err := FormatSource(source, FormatOptions{Compact: true})
+
+		if err != nil {
+			t.Error("Error formatting", source, ":", err)
+			continue
+		}
+
+		testutil.AssertEquals(t, "Unexpected format", source, actual)
+	}
+}
@@ -13,12 +13,20 @@ func GenQuery(rand *rand.Rand, size int) *Query {
 	gen := &Query{}
 	gen.TableKey = crdt.TableName(testutil.RandStr(rand, __ALPHABET, 1, TABLE_NAME_MAX))
 
-	if rand.Float32() > 0.5 {
+	branch := rand.Float32()
+	if branch < 0.4 {
 		gen.OpCode = SELECT
 		gen.Select = genQuerySelect(rand, size)
-	} else {
+
+		if rand.Float32() < 0.2 {
+			gen.Select.TableJoin = genQueryTableJoin(rand, gen.TableKey)
+		}
+	} else if branch < 0.8 {
 		gen.OpCode = JOIN
 		gen.Join = genQueryJoin(rand, size)
+	} else {
+		gen.OpCode = DELETE
+		gen.Delete = genQueryDelete(rand, size)
 	}
 
 	return gen
@@ -30,6 +38,131 @@ func genQuerySelect(rand *rand.Rand, size int) QuerySelect {
 	gen.Limit = uint32(limit)
 	gen.Where = genQueryWhere(rand, size, 1)
 
+	if rand.Float32() < 0.3 {
+		genQueryAggregates(rand, &gen)
+	} else {
+		genQueryRowOptions(rand, size, &gen)
+	}
+
+	if rand.Float32() < 0.3 {
+		offset := rand.Intn(__GEN_QUERY_LIMIT)
+		gen.Offset = uint32(offset)
+	}
+
+	gen.Explain = rand.Float32() < 0.1
+
+	branch := rand.Float32()
+	if branch < 0.1 {
+		gen.IndexPath = crdt.IPFSPath(testutil.RandLettersRange(rand, 1, __GEN_FIELD_LEN))
+	} else if branch < 0.2 {
+		gen.IndexTag = testutil.RandLettersRange(rand, 1, __GEN_FIELD_LEN)
+	}
+
+	return gen
+}
+
+func genQueryTableJoin(rand *rand.Rand, tableKey crdt.TableName) QueryTableJoin {
+	const TABLE_NAME_MAX = 20
+
+	gen := QueryTableJoin{}
+	gen.TableKey = crdt.TableName(testutil.RandStr(rand, __ALPHABET, 1, TABLE_NAME_MAX))
+
+	if gen.TableKey == tableKey {
+		gen.TableKey += "x"
+	}
+
+	gen.Left = genQueryColumn(rand, tableKey)
+	gen.Right = genQueryColumn(rand, gen.TableKey)
+
+	if rand.Float32() < 0.5 {
+		gen.Left, gen.Right = gen.Right, gen.Left
+	}
+
+	return gen
+}
+
+func genQueryColumn(rand *rand.Rand, tableKey crdt.TableName) QueryColumn {
+	gen := QueryColumn{TableKey: tableKey}
+
+	if rand.Float32() < 0.3 {
+		gen.RowKey = true
+	} else {
+		gen.Entry = genEntryKey(rand)
+	}
+
+	return gen
+}
+
+func genQueryRowOptions(rand *rand.Rand, size int, gen *QuerySelect) {
+	if rand.Float32() < 0.3 {
+		fieldCount := testutil.GenCountRange(rand, 1, size)
+		for i := 0; i < fieldCount; i++ {
+			field := testutil.RandKey(rand, __GEN_FIELD_LEN)
+			gen.Fields = append(gen.Fields, crdt.EntryName(field))
+		}
+	}
+
+	if rand.Float32() < 0.3 {
+		gen.OrderBy = genQueryOrderBy(rand)
+	}
+}
+
+// genQueryAggregates makes a valid aggregate select, with at most one
+// distinct entry, which is also the group by entry.
+func genQueryAggregates(rand *rand.Rand, gen *QuerySelect) {
+	if rand.Float32() < 0.3 {
+		gen.GroupBy = genEntryKey(rand)
+	}
+
+	if rand.Float32() < 0.3 {
+		distinct := QueryAggregate{Function: DISTINCT, Key: gen.GroupBy}
+
+		if distinct.Key == "" {
+			distinct.Key = genEntryKey(rand)
+		}
+
+		gen.Aggregates = append(gen.Aggregates, distinct)
+	}
+
+	aggregateCount := testutil.GenCountRange(rand, 1, 3)
+	for i := 0; i < aggregateCount; i++ {
+		gen.Aggregates = append(gen.Aggregates, genQueryAggregate(rand))
+	}
+}
+
+func genQueryAggregate(rand *rand.Rand) QueryAggregate {
+	gen := QueryAggregate{}
+
+	branch := rand.Float32()
+	if branch < 0.333 {
+		gen.Function = COUNT
+		return gen
+	} else if branch < 0.666 {
+		gen.Function = MIN
+	} else {
+		gen.Function = MAX
+	}
+
+	gen.Key = genEntryKey(rand)
+
+	return gen
+}
+
+func genEntryKey(rand *rand.Rand) crdt.EntryName {
+	return crdt.EntryName(testutil.RandLettersRange(rand, 1, __GEN_FIELD_LEN))
+}
+
+func genQueryOrderBy(rand *rand.Rand) QueryOrderBy {
+	gen := QueryOrderBy{}
+
+	if rand.Float32() < 0.5 {
+		gen.RowKey = true
+	} else {
+		gen.Key = crdt.EntryName(testutil.RandLettersRange(rand, 1, __GEN_FIELD_LEN))
+	}
+
+	gen.Descending = rand.Float32() < 0.5
+
 	return gen
 }
 
@@ -41,13 +174,21 @@ func genQueryWhere(rand *rand.Rand, size int, depth int) QueryWhere {
 	}
 
 	if rand.Float32()/float32(depth) > 0.4 {
-		if rand.Float32() > 0.5 {
+		branch := rand.Float32()
+		clauseCount := 1
+
+		if branch < 0.4 {
 			gen.OpCode = AND
-		} else {
+		} else if branch < 0.8 {
 			gen.OpCode = OR
+		} else {
+			gen.OpCode = NOT
+		}
+
+		if gen.OpCode != NOT {
+			clauseCount = testutil.GenCountRange(rand, 1, size)
 		}
 
-		clauseCount := testutil.GenCountRange(rand, 1, size)
 		gen.Clauses = make([]QueryWhere, clauseCount)
 
 		nextDepth := depth + 1
@@ -70,11 +211,21 @@ func genQueryPredicate(rand *rand.Rand, size int) QueryPredicate {
 		gen.IncludeRowKey = true
 	}
 
-	gen.FunctionName = "str_eq"
-
 	keyCount := testutil.GenCountRange(rand, 1, size)
 	litCount := testutil.GenCountRange(rand, 1, size)
 
+	// exists and missing test entries, so they take no literals.
+	branch := rand.Float32()
+	if branch < 0.15 {
+		gen.FunctionName = "exists"
+		litCount = 0
+	} else if branch < 0.3 {
+		gen.FunctionName = "missing"
+		litCount = 0
+	} else {
+		gen.FunctionName = "str_eq"
+	}
+
 	for i := 0; i < keyCount; i++ {
 		entryText := testutil.RandKey(rand, MAX_POINT)
 		entry := crdt.EntryName(entryText)
@@ -99,7 +250,10 @@ func genQueryJoin(rand *rand.Rand, size int) QueryJoin {
 
 	rowCount := testutil.GenCountRange(rand, 1, size)
 
-	gen := QueryJoin{Rows: make([]QueryRowJoin, rowCount)}
+	gen := QueryJoin{
+		Rows: make([]QueryRowJoin, rowCount),
+		LWW:  rand.Float32() < 0.5,
+	}
 
 	for i := 0; i < rowCount; i++ {
 		gen.Rows[i] = QueryRowJoin{Entries: map[crdt.EntryName]crdt.PointText{}}
@@ -112,6 +266,41 @@ func genQueryJoin(rand *rand.Rand, size int) QueryJoin {
 			point := testutil.RandPoint(rand, MAX_STR_LEN)
 			row.Entries[crdt.EntryName(entry)] = crdt.PointText(point)
 		}
+
+		counterCount := testutil.GenCount(rand, size)
+		for i := 0; i < counterCount; i++ {
+			if row.Counters == nil {
+				row.Counters = map[crdt.EntryName]int64{}
+			}
+
+			entry := testutil.RandKey(rand, MAX_STR_LEN)
+			row.Counters[crdt.EntryName(entry)] = rand.Int63() - rand.Int63()
+		}
+	}
+
+	return gen
+}
+
+func genQueryDelete(rand *rand.Rand, size int) QueryDelete {
+	const MAX_STR_LEN = 10
+
+	if size > 10 {
+		size = 10
+	}
+
+	rowCount := testutil.GenCountRange(rand, 1, size)
+
+	gen := QueryDelete{Rows: make([]QueryRowDelete, rowCount)}
+
+	for i := 0; i < rowCount; i++ {
+		row := &gen.Rows[i]
+		row.RowKey = crdt.RowName(testutil.RandKey(rand, MAX_STR_LEN))
+
+		entryCount := testutil.GenCount(rand, size)
+		for j := 0; j < entryCount; j++ {
+			entry := testutil.RandKey(rand, MAX_STR_LEN)
+			row.Entries = append(row.Entries, crdt.EntryName(entry))
+		}
 	}
 
 	return gen
@@ -122,3 +311,4 @@ const __DIGITS = "0123456789"
 
 const __KEY_SYMS = __ALPHABET + __DIGITS
 const __GEN_QUERY_LIMIT = 1000
+const __GEN_FIELD_LEN = 10
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/crypto"
 	"github.com/johnny-morrice/godless/internal/testutil"
 )
 
@@ -68,6 +69,46 @@ func TestPlaceholders(t *testing.T) {
 				},
 			},
 		},
+		placeholderTest{
+			source: "join cars lww rows (@key=??, mileage += ?, ?? -= 2)",
+			values: []interface{}{string(rowName), 10, string(driverEntry)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   JOIN,
+				Join: QueryJoin{
+					LWW: true,
+					Rows: []QueryRowJoin{
+						QueryRowJoin{
+							RowKey:  rowName,
+							Entries: map[crdt.EntryName]crdt.PointText{},
+							Counters: map[crdt.EntryName]int64{
+								"mileage":   10,
+								driverEntry: -2,
+							},
+						},
+					},
+				},
+			},
+		},
+		placeholderTest{
+			source: "delete cars rows (@key=??), (@key=test, ??)",
+			values: []interface{}{string(rowName), string(driverEntry)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   DELETE,
+				Delete: QueryDelete{
+					Rows: []QueryRowDelete{
+						QueryRowDelete{
+							RowKey: rowName,
+						},
+						QueryRowDelete{
+							RowKey:  crdt.RowName("test"),
+							Entries: []crdt.EntryName{driverEntry},
+						},
+					},
+				},
+			},
+		},
 		placeholderTest{
 			source: "select cars where and(str_eq(??, ?), str_glob(??, ?)) limit ?",
 			values: []interface{}{string(specialFeature), string(fourWheeler), string(driverEntry), string(driverName), int(theLimit)},
@@ -98,6 +139,157 @@ func TestPlaceholders(t *testing.T) {
 				},
 			},
 		},
+		placeholderTest{
+			source: "select cars where or(not(str_eq(??, ?)), missing(??))",
+			values: []interface{}{string(driverEntry), string(driverName), string(specialFeature)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Where: QueryWhere{
+						OpCode: OR,
+						Clauses: []QueryWhere{
+							QueryWhere{
+								OpCode: NOT,
+								Clauses: []QueryWhere{
+									QueryWhere{
+										OpCode: PREDICATE,
+										Predicate: QueryPredicate{
+											FunctionName: "str_eq",
+											Values:       []PredicateValue{PredicateKey(driverEntry), PredicateLiteral(driverName)},
+										},
+									},
+								},
+							},
+							QueryWhere{
+								OpCode: PREDICATE,
+								Predicate: QueryPredicate{
+									FunctionName: "missing",
+									Values:       []PredicateValue{PredicateKey(specialFeature)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		placeholderTest{
+			source: "explain select cars limit ?",
+			values: []interface{}{int(theLimit)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Limit:   theLimit,
+					Explain: true,
+				},
+			},
+		},
+		placeholderTest{
+			source: "select cars signed ? limit ?",
+			values: []interface{}{"QmKey", int(theLimit)},
+			expected: &Query{
+				TableKey:   carTable,
+				OpCode:     SELECT,
+				PublicKeys: []crypto.PublicKeyHash{crypto.PublicKeyHash("QmKey")},
+				Select: QuerySelect{
+					Limit: theLimit,
+				},
+			},
+		},
+		placeholderTest{
+			source: "select cars at ? limit ?",
+			values: []interface{}{"QmIndex", int(theLimit)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Limit:     theLimit,
+					IndexPath: "QmIndex",
+				},
+			},
+		},
+		placeholderTest{
+			source: "select cars at tag ? limit ?",
+			values: []interface{}{"release", int(theLimit)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Limit:    theLimit,
+					IndexTag: "release",
+				},
+			},
+		},
+		placeholderTest{
+			source: "select cars order by ?? desc limit ? offset ?",
+			values: []interface{}{string(driverEntry), int(theLimit), int(theLimit)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Limit:   theLimit,
+					Offset:  theLimit,
+					OrderBy: QueryOrderBy{Key: driverEntry, Descending: true},
+				},
+			},
+		},
+		placeholderTest{
+			source: "select count cars where str_eq(??, ?)",
+			values: []interface{}{string(driverEntry), string(driverName)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Aggregates: []QueryAggregate{QueryAggregate{Function: COUNT}},
+					Where: QueryWhere{
+						OpCode: PREDICATE,
+						Predicate: QueryPredicate{
+							FunctionName: "str_eq",
+							Values:       []PredicateValue{PredicateKey(driverEntry), PredicateLiteral(driverName)},
+						},
+					},
+				},
+			},
+		},
+		placeholderTest{
+			source: "select distinct ??, count, max(??) from cars group by ??",
+			values: []interface{}{string(driverEntry), string(specialFeature), string(driverEntry)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Aggregates: []QueryAggregate{
+						QueryAggregate{Function: DISTINCT, Key: driverEntry},
+						QueryAggregate{Function: COUNT},
+						QueryAggregate{Function: MAX, Key: specialFeature},
+					},
+					GroupBy: driverEntry,
+				},
+			},
+		},
+		placeholderTest{
+			source: "select cars join drivers on cars.driverId = drivers.@key where str_eq(drivers.name, ?)",
+			values: []interface{}{string(driverName)},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					TableJoin: QueryTableJoin{
+						TableKey: "drivers",
+						Left:     QueryColumn{TableKey: carTable, Entry: "driverId"},
+						Right:    QueryColumn{TableKey: "drivers", RowKey: true},
+					},
+					Where: QueryWhere{
+						OpCode: PREDICATE,
+						Predicate: QueryPredicate{
+							FunctionName: "str_eq",
+							Values:       []PredicateValue{PredicateKey("drivers.name"), PredicateLiteral(driverName)},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for i, test := range placeholderTable {
@@ -116,6 +308,136 @@ func TestPlaceholders(t *testing.T) {
 	}
 }
 
+type namedPlaceholderTest struct {
+	source   string
+	values   map[string]interface{}
+	expected *Query
+}
+
+func TestNamedPlaceholders(t *testing.T) {
+	const carTable = crdt.TableName("cars")
+	const driverEntry = crdt.EntryName("driver")
+	const driverName = crdt.PointText("Mr Fast")
+	publicKey := crypto.PublicKeyHash("QmKey")
+
+	placeholderTable := []namedPlaceholderTest{
+		namedPlaceholderTest{
+			source: "join ::table signed :key rows (@key=::row, ::entry=:driver, mileage += :miles)",
+			values: map[string]interface{}{
+				"table":  string(carTable),
+				"key":    string(publicKey),
+				"row":    "car1",
+				"entry":  string(driverEntry),
+				"driver": string(driverName),
+				"miles":  10,
+			},
+			expected: &Query{
+				TableKey:   carTable,
+				OpCode:     JOIN,
+				PublicKeys: []crypto.PublicKeyHash{publicKey},
+				Join: QueryJoin{
+					Rows: []QueryRowJoin{
+						QueryRowJoin{
+							RowKey: "car1",
+							Entries: map[crdt.EntryName]crdt.PointText{
+								driverEntry: driverName,
+							},
+							Counters: map[crdt.EntryName]int64{
+								"mileage": 10,
+							},
+						},
+					},
+				},
+			},
+		},
+		namedPlaceholderTest{
+			source: "select cars where or(str_eq(::entry, :author), str_eq(co_driver, :author)) limit :limit",
+			values: map[string]interface{}{
+				"entry":  string(driverEntry),
+				"author": string(driverName),
+				"limit":  5,
+			},
+			expected: &Query{
+				TableKey: carTable,
+				OpCode:   SELECT,
+				Select: QuerySelect{
+					Limit: 5,
+					Where: QueryWhere{
+						OpCode: OR,
+						Clauses: []QueryWhere{
+							QueryWhere{
+								OpCode: PREDICATE,
+								Predicate: QueryPredicate{
+									FunctionName: "str_eq",
+									Values:       []PredicateValue{PredicateKey(driverEntry), PredicateLiteral(driverName)},
+								},
+							},
+							QueryWhere{
+								OpCode: PREDICATE,
+								Predicate: QueryPredicate{
+									FunctionName: "str_eq",
+									Values:       []PredicateValue{PredicateKey("co_driver"), PredicateLiteral(driverName)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range placeholderTable {
+		actual, err := CompileNamed(test.source, test.values)
+
+		if err != nil {
+			t.Error("Error at", i, ":", err)
+		}
+
+		if actual != nil {
+			testutil.Assert(t, "Unexpected query", test.expected.Equals(actual))
+		} else {
+			t.Error("actual was nil at", i)
+		}
+	}
+}
+
+func TestInvalidNamedPlaceholder(t *testing.T) {
+	placeholderTable := []namedPlaceholderTest{
+		namedPlaceholderTest{
+			source: "select cars limit :limit",
+			values: map[string]interface{}{},
+		},
+		namedPlaceholderTest{
+			source: "select cars limit :limit",
+			values: map[string]interface{}{"limit": 5, "offset": 1},
+		},
+		namedPlaceholderTest{
+			source: "select cars limit :limit",
+			values: map[string]interface{}{"limit": "5"},
+		},
+		namedPlaceholderTest{
+			source: "select cars limit :limit offset ?",
+			values: map[string]interface{}{"limit": 5},
+		},
+		namedPlaceholderTest{
+			source: "select cars limit 5",
+			values: map[string]interface{}{"limit": 5},
+		},
+	}
+
+	for _, test := range placeholderTable {
+		actual, err := CompileNamed(test.source, test.values)
+
+		testutil.AssertNonNil(t, err)
+		if actual != nil {
+			t.Error("actual was not nil")
+		}
+	}
+
+	_, err := Compile("select cars limit :limit", 5)
+	testutil.AssertNonNil(t, err)
+}
+
 func TestInvalidPlaceholder(t *testing.T) {
 	const tableName = crdt.TableName("The table")
 	const driverName = crdt.PointText("Mr Fast")
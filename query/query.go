@@ -25,6 +25,7 @@ const (
 	QUERY_NOP = QueryOpCode(iota)
 	SELECT
 	JOIN
+	DELETE
 )
 
 type Query struct {
@@ -34,6 +35,7 @@ type Query struct {
 	TableKey   crdt.TableName
 	Join       QueryJoin   `json:",omitempty"`
 	Select     QuerySelect `json:",omitempty"`
+	Delete     QueryDelete `json:",omitempty"`
 	PublicKeys []crypto.PublicKeyHash
 }
 
@@ -54,6 +56,12 @@ type joinVisitor interface {
 	VisitRowJoin(int, *QueryRowJoin)
 }
 
+type deleteVisitor interface {
+	VisitDelete(*QueryDelete)
+	LeaveDelete(*QueryDelete)
+	VisitRowDelete(int, *QueryRowDelete)
+}
+
 type cryptoVisitor interface {
 	VisitPublicKeyHash(crypto.PublicKeyHash)
 }
@@ -73,12 +81,15 @@ type QueryVisitor interface {
 	selectVisitor
 	cryptoVisitor
 	joinVisitor
+	deleteVisitor
 	debugVisitor
 	baseVisitor
 }
 
 type QueryJoin struct {
 	Rows []QueryRowJoin `json:",omitempty"`
+	// LWW stamps each point so that its entry becomes a last-writer-wins register.
+	LWW bool `json:",omitempty"`
 }
 
 func (join QueryJoin) IsEmpty() bool {
@@ -86,6 +97,10 @@ func (join QueryJoin) IsEmpty() bool {
 }
 
 func (join QueryJoin) equals(other QueryJoin) bool {
+	if join.LWW != other.LWW {
+		return false
+	}
+
 	if len(join.Rows) != len(other.Rows) {
 		return false
 	}
@@ -104,11 +119,14 @@ type QueryRowJoin struct {
 	RowKey crdt.RowName
 	// TODO would this be clearer/more performant as a slice of pair structures?
 	Entries map[crdt.EntryName]crdt.PointText `json:",omitempty"`
+	// Counters adds to counter entries.
+	Counters map[crdt.EntryName]int64 `json:",omitempty"`
 }
 
 func (join QueryRowJoin) equals(other QueryRowJoin) bool {
 	ok := join.RowKey == other.RowKey
 	ok = ok && len(join.Entries) == len(other.Entries)
+	ok = ok && len(join.Counters) == len(other.Counters)
 
 	if !ok {
 		return false
@@ -129,16 +147,192 @@ func (join QueryRowJoin) equals(other QueryRowJoin) bool {
 		}
 	}
 
+	for ename, delta := range join.Counters {
+		theirDelta, present := other.Counters[ename]
+		if !present || delta != theirDelta {
+			return false
+		}
+	}
+
+	return true
+}
+
+type QueryDelete struct {
+	Rows []QueryRowDelete `json:",omitempty"`
+}
+
+func (qdelete QueryDelete) IsEmpty() bool {
+	return qdelete.equals(QueryDelete{})
+}
+
+func (qdelete QueryDelete) equals(other QueryDelete) bool {
+	if len(qdelete.Rows) != len(other.Rows) {
+		return false
+	}
+
+	for i, myDelete := range qdelete.Rows {
+		theirDelete := other.Rows[i]
+		if !myDelete.equals(theirDelete) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// QueryRowDelete removes Entries from a Row.  If there are no Entries, the
+// whole Row is removed.
+type QueryRowDelete struct {
+	RowKey  crdt.RowName
+	Entries []crdt.EntryName `json:",omitempty"`
+}
+
+func (qdelete QueryRowDelete) IsWholeRow() bool {
+	return len(qdelete.Entries) == 0
+}
+
+func (qdelete QueryRowDelete) equals(other QueryRowDelete) bool {
+	ok := qdelete.RowKey == other.RowKey
+	ok = ok && len(qdelete.Entries) == len(other.Entries)
+
+	if !ok {
+		return false
+	}
+
+	for i, entry := range qdelete.Entries {
+		if entry != other.Entries[i] {
+			return false
+		}
+	}
+
 	return true
 }
 
 type QuerySelect struct {
 	Where QueryWhere `json:",omitempty"`
 	Limit uint32     `json:",omitempty"`
+	// Fields limits the entries returned for each row.  All entries are returned if empty.
+	Fields  []crdt.EntryName `json:",omitempty"`
+	OrderBy QueryOrderBy     `json:",omitempty"`
+	// Offset skips rows from the start of the ordered results.
+	Offset     uint32           `json:",omitempty"`
+	Aggregates []QueryAggregate `json:",omitempty"`
+	// GroupBy splits the selected rows by the values of an entry before aggregation.
+	GroupBy   crdt.EntryName `json:",omitempty"`
+	TableJoin QueryTableJoin `json:",omitempty"`
+	// Explain asks for a report of how the select ran, rather than its results.
+	Explain bool `json:",omitempty"`
+	// IndexPath runs the select against a past index, rather than the current one.
+	IndexPath crdt.IPFSPath `json:",omitempty"`
+	// IndexTag runs the select against a tagged index.
+	IndexTag string `json:",omitempty"`
 }
 
 func (querySelect QuerySelect) IsEmpty() bool {
-	return 0 == querySelect.Limit && querySelect.Where.IsEmpty()
+	ok := 0 == querySelect.Limit && 0 == querySelect.Offset
+	ok = ok && querySelect.Where.IsEmpty() && querySelect.OrderBy.IsEmpty()
+	ok = ok && querySelect.TableJoin.IsEmpty() && !querySelect.Explain
+	ok = ok && crdt.IsNilPath(querySelect.IndexPath) && querySelect.IndexTag == ""
+	return ok && len(querySelect.Fields) == 0 && querySelect.GroupBy == ""
+}
+
+// QueryTableJoin relates the rows of another table to the selected table
+// when the query is read.  Matching rows are combined into one row, with
+// each entry named after its table, e.g. "authors.name".
+type QueryTableJoin struct {
+	TableKey crdt.TableName
+	Left     QueryColumn
+	Right    QueryColumn
+}
+
+func (join QueryTableJoin) IsEmpty() bool {
+	return join == QueryTableJoin{}
+}
+
+// QueryColumn is an entry, or the row key, of a table.
+type QueryColumn struct {
+	TableKey crdt.TableName
+	Entry    crdt.EntryName `json:",omitempty"`
+	RowKey   bool           `json:",omitempty"`
+}
+
+// IsAggregate is true when the select returns a table of summary values
+// rather than namespace rows.
+func (querySelect QuerySelect) IsAggregate() bool {
+	return len(querySelect.Aggregates) > 0 || querySelect.GroupBy != ""
+}
+
+func (querySelect QuerySelect) aggregatesEqual(other QuerySelect) bool {
+	if len(querySelect.Aggregates) != len(other.Aggregates) {
+		return false
+	}
+
+	for i, aggregate := range querySelect.Aggregates {
+		if aggregate != other.Aggregates[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type QueryAggregateFunction uint8
+
+const (
+	AGGREGATE_NOOP = QueryAggregateFunction(iota)
+	COUNT
+	DISTINCT
+	MIN
+	MAX
+)
+
+// QueryAggregate summarises the selected rows.  Key is empty for COUNT.
+type QueryAggregate struct {
+	Function QueryAggregateFunction
+	Key      crdt.EntryName `json:",omitempty"`
+}
+
+// Column names the aggregate in a result table.
+func (aggregate QueryAggregate) Column() string {
+	switch aggregate.Function {
+	case COUNT:
+		return "count"
+	case DISTINCT:
+		return string(aggregate.Key)
+	case MIN:
+		return fmt.Sprintf("min(%s)", aggregate.Key)
+	case MAX:
+		return fmt.Sprintf("max(%s)", aggregate.Key)
+	default:
+		return ""
+	}
+}
+
+// QueryOrderBy sorts selected rows by an entry or by the row key.  Rows are
+// always sorted by row key when no order is given, so that results are the
+// same on every peer holding the same index.
+type QueryOrderBy struct {
+	Key        crdt.EntryName `json:",omitempty"`
+	RowKey     bool           `json:",omitempty"`
+	Descending bool           `json:",omitempty"`
+}
+
+func (orderBy QueryOrderBy) IsEmpty() bool {
+	return orderBy.Key == "" && !orderBy.RowKey
+}
+
+func (querySelect QuerySelect) fieldsEqual(other QuerySelect) bool {
+	if len(querySelect.Fields) != len(other.Fields) {
+		return false
+	}
+
+	for i, field := range querySelect.Fields {
+		if field != other.Fields[i] {
+			return false
+		}
+	}
+
+	return true
 }
 
 type QueryWhereOpCode uint16
@@ -148,6 +342,7 @@ const (
 	AND
 	OR
 	PREDICATE
+	NOT
 )
 
 type QueryWhere struct {
@@ -254,6 +449,16 @@ func (pred QueryPredicate) Keys() []crdt.EntryName {
 	return keys
 }
 
+func (pred QueryPredicate) argumentCount() int {
+	count := len(pred.Values)
+
+	if pred.IncludeRowKey {
+		count++
+	}
+
+	return count
+}
+
 func (pred QueryPredicate) IsEmpty() bool {
 	return pred.equals(QueryPredicate{})
 }
@@ -278,28 +483,70 @@ func (pred QueryPredicate) equals(other QueryPredicate) bool {
 }
 
 func Compile(source string, variables ...interface{}) (*Query, error) {
-	parser := &QueryParser{Buffer: source}
-	parser.Pretty = true
-	parser.Init()
+	context := CompileContext{
+		Variables: variables,
+	}
 
-	if err := parser.Parse(); err != nil {
-		if log.CanLog(log.LOG_DEBUG) {
-			parser.PrintSyntaxTree()
+	return compileWithContext(source, context)
+}
+
+// CompileNamed compiles a query with named placeholders, such as ":author"
+// for a literal or "::table" for a key.  A name used twice takes the same
+// value each time.
+func CompileNamed(source string, variables map[string]interface{}) (*Query, error) {
+	context := CompileContext{
+		NamedVariables: variables,
+	}
+
+	return compileWithContext(source, context)
+}
+
+// CompileScript compiles each statement in a script.  Statements are
+// separated by ';' and may not contain placeholders.
+func CompileScript(source string) ([]*Query, error) {
+	parser, err := parseSource(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	script := make([]*Query, len(parser.Statements))
+
+	for i, ast := range parser.Statements {
+		query, err := compileStatement(parser, ast, CompileContext{})
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error in statement %d", i+1)
 		}
 
-		return nil, errors.Wrap(err, "Query parse failed")
+		script[i] = query
 	}
 
-	parser.Execute()
+	return script, nil
+}
 
-	context := CompileContext{
-		Variables: variables,
+func compileWithContext(source string, context CompileContext) (*Query, error) {
+	parser, err := parseSource(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := parser.singleStatement()
+
+	if err != nil {
+		return nil, err
 	}
-	query, err := parser.QueryAST.Compile(context)
+
+	return compileStatement(parser, ast, context)
+}
+
+func compileStatement(parser *QueryParser, ast *QueryAST, context CompileContext) (*Query, error) {
+	query, err := ast.Compile(context)
 
 	if err != nil {
 		if log.CanLog(log.LOG_DEBUG) {
-			log.Debug("AST:\n\n%s\n\n", prettyPrintJson(parser.QueryAST))
+			log.Debug("AST:\n\n%s\n\n", prettyPrintJson(ast))
 			parser.PrintSyntaxTree()
 		}
 
@@ -311,6 +558,34 @@ func Compile(source string, variables ...interface{}) (*Query, error) {
 	return query, nil
 }
 
+func parseSource(source string) (*QueryParser, error) {
+	parser := &QueryParser{Buffer: source}
+	parser.Pretty = true
+	parser.Init()
+
+	if err := parser.Parse(); err != nil {
+		if log.CanLog(log.LOG_DEBUG) {
+			parser.PrintSyntaxTree()
+		}
+
+		return nil, errors.Wrap(err, "Query parse failed")
+	}
+
+	parser.Execute()
+
+	return parser, nil
+}
+
+func (parser *QueryParser) singleStatement() (*QueryAST, error) {
+	count := len(parser.Statements)
+
+	if count != 1 {
+		return nil, fmt.Errorf("Expected 1 statement but found %d", count)
+	}
+
+	return parser.Statements[0], nil
+}
+
 func EncodeQuery(query *Query, w io.Writer) error {
 	const failMsg = "EncodeQuery failed"
 
@@ -339,11 +614,7 @@ func DecodeQuery(r io.Reader) (*Query, error) {
 }
 
 func (query *Query) PrettyPrint(w io.Writer) error {
-	printer := &queryPrinter{output: w}
-
-	query.Visit(printer)
-
-	return printer.Error()
+	return query.Format(w, FormatOptions{})
 }
 
 func (query *Query) Analyse() string {
@@ -394,6 +665,13 @@ func (query *Query) Visit(visitor QueryVisitor) {
 			visitor.VisitRowJoin(i, &row)
 		}
 		visitor.LeaveJoin(queryJoin)
+	case DELETE:
+		queryDelete := &query.Delete
+		visitor.VisitDelete(queryDelete)
+		for i, row := range query.Delete.Rows {
+			visitor.VisitRowDelete(i, &row)
+		}
+		visitor.LeaveDelete(queryDelete)
 	case SELECT:
 		querySelect := &query.Select
 		visitor.VisitSelect(querySelect)
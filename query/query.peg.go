@@ -15,6 +15,7 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleQuery
+	ruleStatement
 	ruleTableName
 	ruleTableNameText
 	ruleTableNamePlaceholder
@@ -28,16 +29,67 @@ const (
 	ruleJoinPointValueText
 	ruleJoinPointKeyText
 	ruleJoinPointKeyPlaceholder
+	ruleJoinCounter
+	ruleJoinCounterOperator
+	ruleJoinCounterDeltaText
+	ruleJoinCounterDeltaPlaceholder
+	ruleDelete
+	ruleDeleteRow
+	ruleDeleteRowKey
+	ruleDeleteRowKeyValuePlaceholder
+	ruleDeleteRowKeyValueText
+	ruleDeleteEntry
+	ruleDeleteEntryText
+	ruleDeleteEntryPlaceholder
+	ruleExplain
 	ruleSelect
+	ruleSelectAggregates
+	ruleAggregate
+	ruleCountAggregate
+	ruleDistinctAggregate
+	ruleMinAggregate
+	ruleMaxAggregate
+	ruleAggregateKey
+	ruleAggregateKeyText
+	ruleAggregateKeyPlaceholder
 	ruleWherePart
+	ruleAt
+	ruleAtTag
+	ruleAtTagText
+	ruleAtTagPlaceholder
+	ruleAtText
+	ruleAtPlaceholder
+	ruleTableJoin
+	ruleTableJoinName
+	ruleTableJoinColumn
+	ruleTableJoinColumnRowKey
+	ruleTableJoinColumnEntry
+	ruleGroupBy
+	ruleGroupByText
+	ruleGroupByPlaceholder
+	ruleOrderBy
+	ruleOrderByRowKey
+	ruleOrderByKeyText
+	ruleOrderByKeyPlaceholder
+	ruleOrderByDirection
+	ruleFields
+	ruleField
+	ruleFieldText
+	ruleFieldPlaceholder
 	ruleLimit
 	ruleLimitText
 	ruleLimitPlaceholder
+	ruleOffset
+	ruleOffsetText
+	ruleOffsetPlaceholder
 	ruleCryptoKey
+	ruleCryptoKeyText
+	ruleCryptoKeyPlaceholder
 	ruleWhere
 	ruleWhereClause
 	ruleAndClause
 	ruleOrClause
+	ruleNotClause
 	rulePredicateClause
 	rulePredicate
 	rulePredicateValue
@@ -50,17 +102,20 @@ const (
 	rulePredicateLiteralPlaceholder
 	ruleKeyPlaceholder
 	ruleLiteralPlaceholder
+	rulePlaceholderName
 	ruleLiteral
 	rulePositiveInteger
 	ruleKey
+	ruleColumnTable
 	ruleEscape
+	ruleComment
 	ruleMustSpacing
 	ruleSpacing
 	ruleAction0
 	ruleAction1
-	rulePegText
 	ruleAction2
 	ruleAction3
+	rulePegText
 	ruleAction4
 	ruleAction5
 	ruleAction6
@@ -82,11 +137,52 @@ const (
 	ruleAction22
 	ruleAction23
 	ruleAction24
+	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
+	ruleAction33
+	ruleAction34
+	ruleAction35
+	ruleAction36
+	ruleAction37
+	ruleAction38
+	ruleAction39
+	ruleAction40
+	ruleAction41
+	ruleAction42
+	ruleAction43
+	ruleAction44
+	ruleAction45
+	ruleAction46
+	ruleAction47
+	ruleAction48
+	ruleAction49
+	ruleAction50
+	ruleAction51
+	ruleAction52
+	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
+	ruleAction60
+	ruleAction61
+	ruleAction62
+	ruleAction63
+	ruleAction64
 )
 
 var rul3s = [...]string{
 	"Unknown",
 	"Query",
+	"Statement",
 	"TableName",
 	"TableNameText",
 	"TableNamePlaceholder",
@@ -100,16 +196,67 @@ var rul3s = [...]string{
 	"JoinPointValueText",
 	"JoinPointKeyText",
 	"JoinPointKeyPlaceholder",
+	"JoinCounter",
+	"JoinCounterOperator",
+	"JoinCounterDeltaText",
+	"JoinCounterDeltaPlaceholder",
+	"Delete",
+	"DeleteRow",
+	"DeleteRowKey",
+	"DeleteRowKeyValuePlaceholder",
+	"DeleteRowKeyValueText",
+	"DeleteEntry",
+	"DeleteEntryText",
+	"DeleteEntryPlaceholder",
+	"Explain",
 	"Select",
+	"SelectAggregates",
+	"Aggregate",
+	"CountAggregate",
+	"DistinctAggregate",
+	"MinAggregate",
+	"MaxAggregate",
+	"AggregateKey",
+	"AggregateKeyText",
+	"AggregateKeyPlaceholder",
 	"WherePart",
+	"At",
+	"AtTag",
+	"AtTagText",
+	"AtTagPlaceholder",
+	"AtText",
+	"AtPlaceholder",
+	"TableJoin",
+	"TableJoinName",
+	"TableJoinColumn",
+	"TableJoinColumnRowKey",
+	"TableJoinColumnEntry",
+	"GroupBy",
+	"GroupByText",
+	"GroupByPlaceholder",
+	"OrderBy",
+	"OrderByRowKey",
+	"OrderByKeyText",
+	"OrderByKeyPlaceholder",
+	"OrderByDirection",
+	"Fields",
+	"Field",
+	"FieldText",
+	"FieldPlaceholder",
 	"Limit",
 	"LimitText",
 	"LimitPlaceholder",
+	"Offset",
+	"OffsetText",
+	"OffsetPlaceholder",
 	"CryptoKey",
+	"CryptoKeyText",
+	"CryptoKeyPlaceholder",
 	"Where",
 	"WhereClause",
 	"AndClause",
 	"OrClause",
+	"NotClause",
 	"PredicateClause",
 	"Predicate",
 	"PredicateValue",
@@ -122,17 +269,20 @@ var rul3s = [...]string{
 	"PredicateLiteralPlaceholder",
 	"KeyPlaceholder",
 	"LiteralPlaceholder",
+	"PlaceholderName",
 	"Literal",
 	"PositiveInteger",
 	"Key",
+	"ColumnTable",
 	"Escape",
+	"Comment",
 	"MustSpacing",
 	"Spacing",
 	"Action0",
 	"Action1",
-	"PegText",
 	"Action2",
 	"Action3",
+	"PegText",
 	"Action4",
 	"Action5",
 	"Action6",
@@ -154,6 +304,46 @@ var rul3s = [...]string{
 	"Action22",
 	"Action23",
 	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
+	"Action39",
+	"Action40",
+	"Action41",
+	"Action42",
+	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
+	"Action61",
+	"Action62",
+	"Action63",
+	"Action64",
 }
 
 type token32 struct {
@@ -270,7 +460,7 @@ type QueryParser struct {
 
 	Buffer string
 	buffer []rune
-	rules  [69]func() bool
+	rules  [164]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -367,51 +557,131 @@ func (p *QueryParser) Execute() {
 		case ruleAction1:
 			p.AddJoin()
 		case ruleAction2:
-			p.SetTableName(buffer[begin:end])
+			p.AddDelete()
 		case ruleAction3:
-			p.SetTableNamePlaceholder(begin)
+			p.EndStatement()
 		case ruleAction4:
-			p.AddJoinRow()
+			p.SetTableName(buffer[begin:end])
 		case ruleAction5:
-			p.SetJoinRowKeyPlaceholder(begin)
+			p.SetTableNamePlaceholder(begin, buffer[begin:end])
 		case ruleAction6:
-			p.SetJoinRowKey(buffer[begin:end])
+			p.SetJoinLWW()
 		case ruleAction7:
-			p.SetJoinValuePlaceholder(begin)
+			p.AddJoinRow()
 		case ruleAction8:
-			p.SetJoinValue(buffer[begin:end])
+			p.SetJoinRowKeyPlaceholder(begin, buffer[begin:end])
 		case ruleAction9:
-			p.SetJoinKey(buffer[begin:end])
+			p.SetJoinRowKey(buffer[begin:end])
 		case ruleAction10:
-			p.SetJoinKeyPlaceholder(begin)
+			p.SetJoinValuePlaceholder(begin, buffer[begin:end])
 		case ruleAction11:
-			p.SetLimit(buffer[begin:end])
+			p.SetJoinValue(buffer[begin:end])
 		case ruleAction12:
-			p.SetLimitPlaceholder(begin)
+			p.SetJoinKey(buffer[begin:end])
 		case ruleAction13:
-			p.AddCryptoKey(buffer[begin:end])
+			p.SetJoinKeyPlaceholder(begin, buffer[begin:end])
 		case ruleAction14:
-			p.PushWhere()
+			p.SetJoinCounterIncrement()
 		case ruleAction15:
-			p.PopWhere()
+			p.SetJoinCounterDecrement()
 		case ruleAction16:
-			p.SetWhereCommand("and")
+			p.SetJoinCounterDelta(buffer[begin:end])
 		case ruleAction17:
-			p.SetWhereCommand("or")
+			p.SetJoinCounterDeltaPlaceholder(begin, buffer[begin:end])
 		case ruleAction18:
-			p.InitPredicate()
+			p.AddDeleteRow()
 		case ruleAction19:
-			p.SetPredicateCommand(buffer[begin:end])
+			p.SetDeleteRowKeyPlaceholder(begin, buffer[begin:end])
 		case ruleAction20:
-			p.UsePredicateRowKey()
+			p.SetDeleteRowKey(buffer[begin:end])
 		case ruleAction21:
-			p.AddPredicateKey(buffer[begin:end])
+			p.AddDeleteEntry(buffer[begin:end])
 		case ruleAction22:
-			p.AddPredicateKeyPlaceholder(begin)
+			p.AddDeleteEntryPlaceholder(begin, buffer[begin:end])
 		case ruleAction23:
-			p.AddPredicateLiteral(buffer[begin:end])
+			p.SetExplain()
 		case ruleAction24:
-			p.AddPredicateLiteralPlaceholder(begin)
+			p.AddCountAggregate()
+		case ruleAction25:
+			p.SetAggregateFunction("distinct")
+		case ruleAction26:
+			p.SetAggregateFunction("min")
+		case ruleAction27:
+			p.SetAggregateFunction("max")
+		case ruleAction28:
+			p.AddAggregate(buffer[begin:end])
+		case ruleAction29:
+			p.AddAggregatePlaceholder(begin, buffer[begin:end])
+		case ruleAction30:
+			p.SetIndexTag(buffer[begin:end])
+		case ruleAction31:
+			p.SetIndexTagPlaceholder(begin, buffer[begin:end])
+		case ruleAction32:
+			p.SetIndexPath(buffer[begin:end])
+		case ruleAction33:
+			p.SetIndexPathPlaceholder(begin, buffer[begin:end])
+		case ruleAction34:
+			p.SetTableJoinName(buffer[begin:end])
+		case ruleAction35:
+			p.AddTableJoinColumn(buffer[begin:end])
+		case ruleAction36:
+			p.SetTableJoinColumnRowKey()
+		case ruleAction37:
+			p.SetTableJoinColumnEntry(buffer[begin:end])
+		case ruleAction38:
+			p.SetGroupBy(buffer[begin:end])
+		case ruleAction39:
+			p.SetGroupByPlaceholder(begin, buffer[begin:end])
+		case ruleAction40:
+			p.SetOrderByRowKey()
+		case ruleAction41:
+			p.SetOrderByKey(buffer[begin:end])
+		case ruleAction42:
+			p.SetOrderByKeyPlaceholder(begin, buffer[begin:end])
+		case ruleAction43:
+			p.SetOrderByDescending()
+		case ruleAction44:
+			p.AddField(buffer[begin:end])
+		case ruleAction45:
+			p.AddFieldPlaceholder(begin, buffer[begin:end])
+		case ruleAction46:
+			p.SetLimit(buffer[begin:end])
+		case ruleAction47:
+			p.SetLimitPlaceholder(begin, buffer[begin:end])
+		case ruleAction48:
+			p.SetOffset(buffer[begin:end])
+		case ruleAction49:
+			p.SetOffsetPlaceholder(begin, buffer[begin:end])
+		case ruleAction50:
+			p.AddCryptoKey(buffer[begin:end])
+		case ruleAction51:
+			p.AddCryptoKeyPlaceholder(begin, buffer[begin:end])
+		case ruleAction52:
+			p.PushWhere()
+		case ruleAction53:
+			p.PopWhere()
+		case ruleAction54:
+			p.SetWhereCommand("and")
+		case ruleAction55:
+			p.SetWhereCommand("or")
+		case ruleAction56:
+			p.SetWhereCommand("not")
+		case ruleAction57:
+			p.InitPredicate()
+		case ruleAction58:
+			p.SetPredicateCommand(buffer[begin:end])
+		case ruleAction59:
+			p.UsePredicateRowKey()
+		case ruleAction60:
+			p.AddPredicateKey(buffer[begin:end])
+		case ruleAction61:
+			p.AddPredicateKeyPlaceholder(begin, buffer[begin:end])
+		case ruleAction62:
+			p.AddPredicateLiteral(buffer[begin:end])
+		case ruleAction63:
+			p.AddPredicateLiteralPlaceholder(begin, buffer[begin:end])
+		case ruleAction64:
+			p.AddComment(buffer[begin:end])
 
 		}
 	}
@@ -486,7 +756,7 @@ func (p *QueryParser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 Query <- <(Spacing ((Select Action0) / (Join Action1)) Spacing !.)> */
+		/* 0 Query <- <(Spacing (Statement (Spacing ';' Spacing Statement)* (Spacing ';')?)? Spacing !.)> */
 		func() bool {
 			position0, tokenIndex0 := position, tokenIndex
 			{
@@ -496,1473 +766,3688 @@ func (p *QueryParser) Init() {
 				}
 				{
 					position2, tokenIndex2 := position, tokenIndex
+					if !_rules[ruleStatement]() {
+						goto l2
+					}
+				l4:
 					{
-						position4 := position
-						if buffer[position] != rune('s') {
-							goto l3
+						position5, tokenIndex5 := position, tokenIndex
+						if !_rules[ruleSpacing]() {
+							goto l5
 						}
-						position++
-						if buffer[position] != rune('e') {
-							goto l3
+						if buffer[position] != rune(';') {
+							goto l5
 						}
 						position++
-						if buffer[position] != rune('l') {
-							goto l3
+						if !_rules[ruleSpacing]() {
+							goto l5
 						}
-						position++
-						if buffer[position] != rune('e') {
-							goto l3
+						if !_rules[ruleStatement]() {
+							goto l5
 						}
-						position++
-						if buffer[position] != rune('c') {
-							goto l3
+						goto l4
+					l5:
+						position, tokenIndex = position5, tokenIndex5
+					}
+					{
+						position6, tokenIndex6 := position, tokenIndex
+						if !_rules[ruleSpacing]() {
+							goto l6
 						}
-						position++
-						if buffer[position] != rune('t') {
-							goto l3
+						if buffer[position] != rune(';') {
+							goto l6
 						}
 						position++
-						if !_rules[ruleMustSpacing]() {
-							goto l3
+						goto l7
+					l6:
+						position, tokenIndex = position6, tokenIndex6
+					}
+				l7:
+					goto l3
+				l2:
+					position, tokenIndex = position2, tokenIndex2
+				}
+			l3:
+				if !_rules[ruleSpacing]() {
+					goto l0
+				}
+				{
+					position8, tokenIndex8 := position, tokenIndex
+					if !matchDot() {
+						goto l8
+					}
+					goto l0
+				l8:
+					position, tokenIndex = position8, tokenIndex8
+				}
+				add(ruleQuery, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex = position0, tokenIndex0
+			return false
+		},
+		/* 1 Statement <- <(((&('d') (Delete Action2)) | (&('j') (Join Action1)) | (&('e' | 's') (Explain? Select Action0))) Action3)> */
+		func() bool {
+			position9, tokenIndex9 := position, tokenIndex
+			{
+				position10 := position
+				{
+					switch buffer[position] {
+					case 'd':
+						{
+							position12 := position
+							if buffer[position] != rune('d') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('l') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l9
+							}
+							position++
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if !_rules[ruleTableName]() {
+								goto l9
+							}
+						l13:
+							{
+								position14, tokenIndex14 := position, tokenIndex
+								if !_rules[ruleMustSpacing]() {
+									goto l14
+								}
+								if !_rules[ruleCryptoKey]() {
+									goto l14
+								}
+								goto l13
+							l14:
+								position, tokenIndex = position14, tokenIndex14
+							}
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if buffer[position] != rune('r') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('w') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('s') {
+								goto l9
+							}
+							position++
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if !_rules[ruleDeleteRow]() {
+								goto l9
+							}
+						l15:
+							{
+								position16, tokenIndex16 := position, tokenIndex
+								if !_rules[ruleSpacing]() {
+									goto l16
+								}
+								if buffer[position] != rune(',') {
+									goto l16
+								}
+								position++
+								if !_rules[ruleSpacing]() {
+									goto l16
+								}
+								if !_rules[ruleDeleteRow]() {
+									goto l16
+								}
+								goto l15
+							l16:
+								position, tokenIndex = position16, tokenIndex16
+							}
+							if !_rules[ruleSpacing]() {
+								goto l9
+							}
+							add(ruleDelete, position12)
 						}
-						if !_rules[ruleTableName]() {
-							goto l3
+						{
+							add(ruleAction2, position)
 						}
-					l5:
+						break
+					case 'j':
+						{
+							position18 := position
+							if buffer[position] != rune('j') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('i') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l9
+							}
+							position++
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if !_rules[ruleTableName]() {
+								goto l9
+							}
+						l19:
+							{
+								position20, tokenIndex20 := position, tokenIndex
+								if !_rules[ruleMustSpacing]() {
+									goto l20
+								}
+								if !_rules[ruleCryptoKey]() {
+									goto l20
+								}
+								goto l19
+							l20:
+								position, tokenIndex = position20, tokenIndex20
+							}
+							{
+								position21, tokenIndex21 := position, tokenIndex
+								if !_rules[ruleMustSpacing]() {
+									goto l21
+								}
+								if buffer[position] != rune('l') {
+									goto l21
+								}
+								position++
+								if buffer[position] != rune('w') {
+									goto l21
+								}
+								position++
+								if buffer[position] != rune('w') {
+									goto l21
+								}
+								position++
+								{
+									add(ruleAction6, position)
+								}
+								goto l22
+							l21:
+								position, tokenIndex = position21, tokenIndex21
+							}
+						l22:
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if buffer[position] != rune('r') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('w') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('s') {
+								goto l9
+							}
+							position++
+							if !_rules[ruleMustSpacing]() {
+								goto l9
+							}
+							if !_rules[ruleJoinRow]() {
+								goto l9
+							}
+						l24:
+							{
+								position25, tokenIndex25 := position, tokenIndex
+								if !_rules[ruleSpacing]() {
+									goto l25
+								}
+								if buffer[position] != rune(',') {
+									goto l25
+								}
+								position++
+								if !_rules[ruleSpacing]() {
+									goto l25
+								}
+								if !_rules[ruleJoinRow]() {
+									goto l25
+								}
+								goto l24
+							l25:
+								position, tokenIndex = position25, tokenIndex25
+							}
+							if !_rules[ruleSpacing]() {
+								goto l9
+							}
+							add(ruleJoin, position18)
+						}
+						{
+							add(ruleAction1, position)
+						}
+						break
+					default:
+						{
+							position27, tokenIndex27 := position, tokenIndex
+							{
+								position29 := position
+								if buffer[position] != rune('e') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('x') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('p') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('l') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l27
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l27
+								}
+								position++
+								if !_rules[ruleMustSpacing]() {
+									goto l27
+								}
+								{
+									add(ruleAction23, position)
+								}
+								add(ruleExplain, position29)
+							}
+							goto l28
+						l27:
+							position, tokenIndex = position27, tokenIndex27
+						}
+					l28:
 						{
-							position6, tokenIndex6 := position, tokenIndex
+							position31 := position
+							if buffer[position] != rune('s') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('l') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('c') {
+								goto l9
+							}
+							position++
+							if buffer[position] != rune('t') {
+								goto l9
+							}
+							position++
 							if !_rules[ruleMustSpacing]() {
-								goto l6
+								goto l9
 							}
 							{
-								position7 := position
+								position32, tokenIndex32 := position, tokenIndex
 								{
-									switch buffer[position] {
-									case 's':
-										if !_rules[ruleCryptoKey]() {
-											goto l6
+									position34 := position
+									if !_rules[ruleAggregate]() {
+										goto l32
+									}
+								l35:
+									{
+										position36, tokenIndex36 := position, tokenIndex
+										if !_rules[ruleSpacing]() {
+											goto l36
+										}
+										if buffer[position] != rune(',') {
+											goto l36
+										}
+										position++
+										if !_rules[ruleSpacing]() {
+											goto l36
+										}
+										if !_rules[ruleAggregate]() {
+											goto l36
+										}
+										goto l35
+									l36:
+										position, tokenIndex = position36, tokenIndex36
+									}
+									{
+										position37, tokenIndex37 := position, tokenIndex
+										if !_rules[ruleMustSpacing]() {
+											goto l37
+										}
+										if buffer[position] != rune('f') {
+											goto l37
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l37
 										}
-										break
-									case 'l':
+										position++
+										if buffer[position] != rune('o') {
+											goto l37
+										}
+										position++
+										if buffer[position] != rune('m') {
+											goto l37
+										}
+										position++
+										goto l38
+									l37:
+										position, tokenIndex = position37, tokenIndex37
+									}
+								l38:
+									add(ruleSelectAggregates, position34)
+								}
+								if !_rules[ruleMustSpacing]() {
+									goto l32
+								}
+								goto l33
+							l32:
+								position, tokenIndex = position32, tokenIndex32
+							}
+						l33:
+							if !_rules[ruleTableName]() {
+								goto l9
+							}
+						l39:
+							{
+								position40, tokenIndex40 := position, tokenIndex
+								if !_rules[ruleMustSpacing]() {
+									goto l40
+								}
+								{
+									position41 := position
+									{
+										position42, tokenIndex42 := position, tokenIndex
 										{
-											position9 := position
-											if buffer[position] != rune('l') {
-												goto l6
+											position44 := position
+											if buffer[position] != rune('o') {
+												goto l43
 											}
 											position++
-											if buffer[position] != rune('i') {
-												goto l6
+											if buffer[position] != rune('f') {
+												goto l43
 											}
 											position++
-											if buffer[position] != rune('m') {
-												goto l6
+											if buffer[position] != rune('f') {
+												goto l43
 											}
 											position++
-											if buffer[position] != rune('i') {
-												goto l6
+											if buffer[position] != rune('s') {
+												goto l43
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l43
 											}
 											position++
 											if buffer[position] != rune('t') {
-												goto l6
+												goto l43
 											}
 											position++
 											if !_rules[ruleMustSpacing]() {
-												goto l6
+												goto l43
 											}
 											{
-												position10, tokenIndex10 := position, tokenIndex
+												position45, tokenIndex45 := position, tokenIndex
 												{
-													position12 := position
+													position47 := position
 													{
-														position13 := position
+														position48 := position
+														if c := buffer[position]; c < rune('0') || c > rune('9') {
+															goto l46
+														}
+														position++
+													l49:
 														{
-															position14 := position
-															if c := buffer[position]; c < rune('1') || c > rune('9') {
-																goto l11
+															position50, tokenIndex50 := position, tokenIndex
+															if c := buffer[position]; c < rune('0') || c > rune('9') {
+																goto l50
 															}
 															position++
-														l15:
-															{
-																position16, tokenIndex16 := position, tokenIndex
-																if c := buffer[position]; c < rune('0') || c > rune('9') {
-																	goto l16
-																}
-																position++
-																goto l15
-															l16:
-																position, tokenIndex = position16, tokenIndex16
-															}
-															add(rulePositiveInteger, position14)
+															goto l49
+														l50:
+															position, tokenIndex = position50, tokenIndex50
 														}
-														add(rulePegText, position13)
+														add(rulePegText, position48)
 													}
 													{
-														add(ruleAction11, position)
+														add(ruleAction48, position)
 													}
-													add(ruleLimitText, position12)
+													add(ruleOffsetText, position47)
 												}
-												goto l10
-											l11:
-												position, tokenIndex = position10, tokenIndex10
+												goto l45
+											l46:
+												position, tokenIndex = position45, tokenIndex45
 												{
-													position18 := position
+													position52 := position
 													{
-														position19 := position
+														position53 := position
 														if !_rules[ruleLiteralPlaceholder]() {
-															goto l6
+															goto l43
 														}
-														add(rulePegText, position19)
+														add(rulePegText, position53)
 													}
 													{
-														add(ruleAction12, position)
+														add(ruleAction49, position)
 													}
-													add(ruleLimitPlaceholder, position18)
+													add(ruleOffsetPlaceholder, position52)
 												}
 											}
-										l10:
-											add(ruleLimit, position9)
+										l45:
+											add(ruleOffset, position44)
 										}
-										break
-									default:
+										goto l42
+									l43:
+										position, tokenIndex = position42, tokenIndex42
 										{
-											position21 := position
-											if buffer[position] != rune('w') {
-												goto l6
-											}
-											position++
-											if buffer[position] != rune('h') {
-												goto l6
-											}
-											position++
-											if buffer[position] != rune('e') {
-												goto l6
-											}
-											position++
-											if buffer[position] != rune('r') {
-												goto l6
-											}
-											position++
-											if buffer[position] != rune('e') {
-												goto l6
-											}
-											position++
-											if !_rules[ruleMustSpacing]() {
-												goto l6
+											switch buffer[position] {
+											case 's':
+												if !_rules[ruleCryptoKey]() {
+													goto l40
+												}
+												break
+											case 'a':
+												{
+													position56 := position
+													if buffer[position] != rune('a') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('t') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													{
+														switch buffer[position] {
+														case '"':
+															{
+																position58 := position
+																if buffer[position] != rune('"') {
+																	goto l40
+																}
+																position++
+																{
+																	position59 := position
+																	if !_rules[ruleLiteral]() {
+																		goto l40
+																	}
+																	add(rulePegText, position59)
+																}
+																if buffer[position] != rune('"') {
+																	goto l40
+																}
+																position++
+																{
+																	add(ruleAction32, position)
+																}
+																add(ruleAtText, position58)
+															}
+															break
+														case 't':
+															{
+																position61 := position
+																if buffer[position] != rune('t') {
+																	goto l40
+																}
+																position++
+																if buffer[position] != rune('a') {
+																	goto l40
+																}
+																position++
+																if buffer[position] != rune('g') {
+																	goto l40
+																}
+																position++
+																if !_rules[ruleMustSpacing]() {
+																	goto l40
+																}
+																{
+																	position62, tokenIndex62 := position, tokenIndex
+																	{
+																		position64 := position
+																		if buffer[position] != rune('"') {
+																			goto l63
+																		}
+																		position++
+																		{
+																			position65 := position
+																			if !_rules[ruleLiteral]() {
+																				goto l63
+																			}
+																			add(rulePegText, position65)
+																		}
+																		if buffer[position] != rune('"') {
+																			goto l63
+																		}
+																		position++
+																		{
+																			add(ruleAction30, position)
+																		}
+																		add(ruleAtTagText, position64)
+																	}
+																	goto l62
+																l63:
+																	position, tokenIndex = position62, tokenIndex62
+																	{
+																		position67 := position
+																		{
+																			position68 := position
+																			if !_rules[ruleLiteralPlaceholder]() {
+																				goto l40
+																			}
+																			add(rulePegText, position68)
+																		}
+																		{
+																			add(ruleAction31, position)
+																		}
+																		add(ruleAtTagPlaceholder, position67)
+																	}
+																}
+															l62:
+																add(ruleAtTag, position61)
+															}
+															break
+														default:
+															{
+																position70 := position
+																{
+																	position71 := position
+																	if !_rules[ruleLiteralPlaceholder]() {
+																		goto l40
+																	}
+																	add(rulePegText, position71)
+																}
+																{
+																	add(ruleAction33, position)
+																}
+																add(ruleAtPlaceholder, position70)
+															}
+															break
+														}
+													}
+
+													add(ruleAt, position56)
+												}
+												break
+											case 'j':
+												{
+													position73 := position
+													if buffer[position] != rune('j') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('o') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													{
+														position74 := position
+														{
+															position75 := position
+															if !_rules[ruleKey]() {
+																goto l40
+															}
+															add(rulePegText, position75)
+														}
+														{
+															add(ruleAction34, position)
+														}
+														add(ruleTableJoinName, position74)
+													}
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune('o') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													if !_rules[ruleTableJoinColumn]() {
+														goto l40
+													}
+													if !_rules[ruleSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune('=') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleSpacing]() {
+														goto l40
+													}
+													if !_rules[ruleTableJoinColumn]() {
+														goto l40
+													}
+													add(ruleTableJoin, position73)
+												}
+												break
+											case 'f':
+												{
+													position77 := position
+													if buffer[position] != rune('f') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('l') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('d') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('s') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune('(') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleSpacing]() {
+														goto l40
+													}
+													if !_rules[ruleField]() {
+														goto l40
+													}
+												l78:
+													{
+														position79, tokenIndex79 := position, tokenIndex
+														if !_rules[ruleSpacing]() {
+															goto l79
+														}
+														if buffer[position] != rune(',') {
+															goto l79
+														}
+														position++
+														if !_rules[ruleSpacing]() {
+															goto l79
+														}
+														if !_rules[ruleField]() {
+															goto l79
+														}
+														goto l78
+													l79:
+														position, tokenIndex = position79, tokenIndex79
+													}
+													if !_rules[ruleSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune(')') {
+														goto l40
+													}
+													position++
+													add(ruleFields, position77)
+												}
+												break
+											case 'g':
+												{
+													position80 := position
+													if buffer[position] != rune('g') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('r') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('o') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('u') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('p') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune('b') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('y') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													{
+														position81, tokenIndex81 := position, tokenIndex
+														{
+															position83 := position
+															{
+																position84, tokenIndex84 := position, tokenIndex
+																{
+																	position86 := position
+																	if !_rules[ruleKey]() {
+																		goto l85
+																	}
+																	add(rulePegText, position86)
+																}
+																goto l84
+															l85:
+																position, tokenIndex = position84, tokenIndex84
+																if buffer[position] != rune('@') {
+																	goto l82
+																}
+																position++
+																if buffer[position] != rune('"') {
+																	goto l82
+																}
+																position++
+																{
+																	position87 := position
+																	if !_rules[ruleLiteral]() {
+																		goto l82
+																	}
+																	add(rulePegText, position87)
+																}
+																if buffer[position] != rune('"') {
+																	goto l82
+																}
+																position++
+															}
+														l84:
+															{
+																add(ruleAction38, position)
+															}
+															add(ruleGroupByText, position83)
+														}
+														goto l81
+													l82:
+														position, tokenIndex = position81, tokenIndex81
+														{
+															position89 := position
+															{
+																position90 := position
+																if !_rules[ruleKeyPlaceholder]() {
+																	goto l40
+																}
+																add(rulePegText, position90)
+															}
+															{
+																add(ruleAction39, position)
+															}
+															add(ruleGroupByPlaceholder, position89)
+														}
+													}
+												l81:
+													add(ruleGroupBy, position80)
+												}
+												break
+											case 'o':
+												{
+													position92 := position
+													if buffer[position] != rune('o') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('r') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('d') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('r') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													if buffer[position] != rune('b') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('y') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													{
+														position93, tokenIndex93 := position, tokenIndex
+														{
+															position95 := position
+															if buffer[position] != rune('@') {
+																goto l94
+															}
+															position++
+															if buffer[position] != rune('k') {
+																goto l94
+															}
+															position++
+															if buffer[position] != rune('e') {
+																goto l94
+															}
+															position++
+															if buffer[position] != rune('y') {
+																goto l94
+															}
+															position++
+															{
+																add(ruleAction40, position)
+															}
+															add(ruleOrderByRowKey, position95)
+														}
+														goto l93
+													l94:
+														position, tokenIndex = position93, tokenIndex93
+														{
+															position98 := position
+															{
+																position99, tokenIndex99 := position, tokenIndex
+																{
+																	position101 := position
+																	if !_rules[ruleKey]() {
+																		goto l100
+																	}
+																	add(rulePegText, position101)
+																}
+																goto l99
+															l100:
+																position, tokenIndex = position99, tokenIndex99
+																if buffer[position] != rune('@') {
+																	goto l97
+																}
+																position++
+																if buffer[position] != rune('"') {
+																	goto l97
+																}
+																position++
+																{
+																	position102 := position
+																	if !_rules[ruleLiteral]() {
+																		goto l97
+																	}
+																	add(rulePegText, position102)
+																}
+																if buffer[position] != rune('"') {
+																	goto l97
+																}
+																position++
+															}
+														l99:
+															{
+																add(ruleAction41, position)
+															}
+															add(ruleOrderByKeyText, position98)
+														}
+														goto l93
+													l97:
+														position, tokenIndex = position93, tokenIndex93
+														{
+															position104 := position
+															{
+																position105 := position
+																if !_rules[ruleKeyPlaceholder]() {
+																	goto l40
+																}
+																add(rulePegText, position105)
+															}
+															{
+																add(ruleAction42, position)
+															}
+															add(ruleOrderByKeyPlaceholder, position104)
+														}
+													}
+												l93:
+													{
+														position107, tokenIndex107 := position, tokenIndex
+														if !_rules[ruleMustSpacing]() {
+															goto l107
+														}
+														{
+															position109 := position
+															{
+																position110, tokenIndex110 := position, tokenIndex
+																if buffer[position] != rune('a') {
+																	goto l111
+																}
+																position++
+																if buffer[position] != rune('s') {
+																	goto l111
+																}
+																position++
+																if buffer[position] != rune('c') {
+																	goto l111
+																}
+																position++
+																goto l110
+															l111:
+																position, tokenIndex = position110, tokenIndex110
+																if buffer[position] != rune('d') {
+																	goto l107
+																}
+																position++
+																if buffer[position] != rune('e') {
+																	goto l107
+																}
+																position++
+																if buffer[position] != rune('s') {
+																	goto l107
+																}
+																position++
+																if buffer[position] != rune('c') {
+																	goto l107
+																}
+																position++
+																{
+																	add(ruleAction43, position)
+																}
+															}
+														l110:
+															add(ruleOrderByDirection, position109)
+														}
+														goto l108
+													l107:
+														position, tokenIndex = position107, tokenIndex107
+													}
+												l108:
+													add(ruleOrderBy, position92)
+												}
+												break
+											case 'l':
+												{
+													position113 := position
+													if buffer[position] != rune('l') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('m') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('t') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													{
+														position114, tokenIndex114 := position, tokenIndex
+														{
+															position116 := position
+															{
+																position117 := position
+																{
+																	position118 := position
+																	if c := buffer[position]; c < rune('1') || c > rune('9') {
+																		goto l115
+																	}
+																	position++
+																l119:
+																	{
+																		position120, tokenIndex120 := position, tokenIndex
+																		if c := buffer[position]; c < rune('0') || c > rune('9') {
+																			goto l120
+																		}
+																		position++
+																		goto l119
+																	l120:
+																		position, tokenIndex = position120, tokenIndex120
+																	}
+																	add(rulePositiveInteger, position118)
+																}
+																add(rulePegText, position117)
+															}
+															{
+																add(ruleAction46, position)
+															}
+															add(ruleLimitText, position116)
+														}
+														goto l114
+													l115:
+														position, tokenIndex = position114, tokenIndex114
+														{
+															position122 := position
+															{
+																position123 := position
+																if !_rules[ruleLiteralPlaceholder]() {
+																	goto l40
+																}
+																add(rulePegText, position123)
+															}
+															{
+																add(ruleAction47, position)
+															}
+															add(ruleLimitPlaceholder, position122)
+														}
+													}
+												l114:
+													add(ruleLimit, position113)
+												}
+												break
+											default:
+												{
+													position125 := position
+													if buffer[position] != rune('w') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('h') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('r') {
+														goto l40
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l40
+													}
+													position++
+													if !_rules[ruleMustSpacing]() {
+														goto l40
+													}
+													if !_rules[ruleWhereClause]() {
+														goto l40
+													}
+													add(ruleWhere, position125)
+												}
+												break
 											}
-											if !_rules[ruleWhereClause]() {
-												goto l6
+										}
+
+									}
+								l42:
+									add(ruleWherePart, position41)
+								}
+								goto l39
+							l40:
+								position, tokenIndex = position40, tokenIndex40
+							}
+							add(ruleSelect, position31)
+						}
+						{
+							add(ruleAction0, position)
+						}
+						break
+					}
+				}
+
+				{
+					add(ruleAction3, position)
+				}
+				add(ruleStatement, position10)
+			}
+			return true
+		l9:
+			position, tokenIndex = position9, tokenIndex9
+			return false
+		},
+		/* 2 TableName <- <(TableNameText / TableNamePlaceholder)> */
+		func() bool {
+			position128, tokenIndex128 := position, tokenIndex
+			{
+				position129 := position
+				{
+					position130, tokenIndex130 := position, tokenIndex
+					{
+						position132 := position
+						{
+							position133 := position
+							if !_rules[ruleKey]() {
+								goto l131
+							}
+							add(rulePegText, position133)
+						}
+						{
+							add(ruleAction4, position)
+						}
+						add(ruleTableNameText, position132)
+					}
+					goto l130
+				l131:
+					position, tokenIndex = position130, tokenIndex130
+					{
+						position135 := position
+						{
+							position136 := position
+							if !_rules[ruleKeyPlaceholder]() {
+								goto l128
+							}
+							add(rulePegText, position136)
+						}
+						{
+							add(ruleAction5, position)
+						}
+						add(ruleTableNamePlaceholder, position135)
+					}
+				}
+			l130:
+				add(ruleTableName, position129)
+			}
+			return true
+		l128:
+			position, tokenIndex = position128, tokenIndex128
+			return false
+		},
+		/* 3 TableNameText <- <(<Key> Action4)> */
+		nil,
+		/* 4 TableNamePlaceholder <- <(<KeyPlaceholder> Action5)> */
+		nil,
+		/* 5 Join <- <('j' 'o' 'i' 'n' MustSpacing TableName (MustSpacing CryptoKey)* (MustSpacing ('l' 'w' 'w') Action6)? MustSpacing ('r' 'o' 'w' 's') MustSpacing JoinRow (Spacing ',' Spacing JoinRow)* Spacing)> */
+		nil,
+		/* 6 JoinRow <- <(Action7 '(' Spacing JoinRowKey Spacing (',' Spacing (JoinCounter / JoinPoint) Spacing)* ')')> */
+		func() bool {
+			position141, tokenIndex141 := position, tokenIndex
+			{
+				position142 := position
+				{
+					add(ruleAction7, position)
+				}
+				if buffer[position] != rune('(') {
+					goto l141
+				}
+				position++
+				if !_rules[ruleSpacing]() {
+					goto l141
+				}
+				{
+					position144 := position
+					if buffer[position] != rune('@') {
+						goto l141
+					}
+					position++
+					if buffer[position] != rune('k') {
+						goto l141
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l141
+					}
+					position++
+					if buffer[position] != rune('y') {
+						goto l141
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l141
+					}
+					if buffer[position] != rune('=') {
+						goto l141
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l141
+					}
+					{
+						position145, tokenIndex145 := position, tokenIndex
+						{
+							position147 := position
+							{
+								position148, tokenIndex148 := position, tokenIndex
+								if buffer[position] != rune('@') {
+									goto l149
+								}
+								position++
+								if buffer[position] != rune('"') {
+									goto l149
+								}
+								position++
+								{
+									position150 := position
+									if !_rules[ruleLiteral]() {
+										goto l149
+									}
+									add(rulePegText, position150)
+								}
+								if buffer[position] != rune('"') {
+									goto l149
+								}
+								position++
+								goto l148
+							l149:
+								position, tokenIndex = position148, tokenIndex148
+								{
+									position151 := position
+									if !_rules[ruleKey]() {
+										goto l146
+									}
+									add(rulePegText, position151)
+								}
+							}
+						l148:
+							{
+								add(ruleAction9, position)
+							}
+							add(ruleJoinRowKeyValueText, position147)
+						}
+						goto l145
+					l146:
+						position, tokenIndex = position145, tokenIndex145
+						{
+							position153 := position
+							{
+								position154 := position
+								if !_rules[ruleKeyPlaceholder]() {
+									goto l141
+								}
+								add(rulePegText, position154)
+							}
+							{
+								add(ruleAction8, position)
+							}
+							add(ruleJoinRowKeyValuePlaceholder, position153)
+						}
+					}
+				l145:
+					add(ruleJoinRowKey, position144)
+				}
+				if !_rules[ruleSpacing]() {
+					goto l141
+				}
+			l156:
+				{
+					position157, tokenIndex157 := position, tokenIndex
+					if buffer[position] != rune(',') {
+						goto l157
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l157
+					}
+					{
+						position158, tokenIndex158 := position, tokenIndex
+						{
+							position160 := position
+							{
+								position161, tokenIndex161 := position, tokenIndex
+								if !_rules[ruleJoinPointKeyText]() {
+									goto l162
+								}
+								goto l161
+							l162:
+								position, tokenIndex = position161, tokenIndex161
+								if !_rules[ruleJoinPointKeyPlaceholder]() {
+									goto l159
+								}
+							}
+						l161:
+							if !_rules[ruleSpacing]() {
+								goto l159
+							}
+							{
+								position163 := position
+								{
+									position164, tokenIndex164 := position, tokenIndex
+									if buffer[position] != rune('+') {
+										goto l165
+									}
+									position++
+									if buffer[position] != rune('=') {
+										goto l165
+									}
+									position++
+									{
+										add(ruleAction14, position)
+									}
+									goto l164
+								l165:
+									position, tokenIndex = position164, tokenIndex164
+									if buffer[position] != rune('-') {
+										goto l159
+									}
+									position++
+									if buffer[position] != rune('=') {
+										goto l159
+									}
+									position++
+									{
+										add(ruleAction15, position)
+									}
+								}
+							l164:
+								add(ruleJoinCounterOperator, position163)
+							}
+							if !_rules[ruleSpacing]() {
+								goto l159
+							}
+							{
+								position168, tokenIndex168 := position, tokenIndex
+								{
+									position170 := position
+									{
+										position171 := position
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l169
+										}
+										position++
+									l172:
+										{
+											position173, tokenIndex173 := position, tokenIndex
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l173
 											}
-											add(ruleWhere, position21)
+											position++
+											goto l172
+										l173:
+											position, tokenIndex = position173, tokenIndex173
+										}
+										add(rulePegText, position171)
+									}
+									{
+										add(ruleAction16, position)
+									}
+									add(ruleJoinCounterDeltaText, position170)
+								}
+								goto l168
+							l169:
+								position, tokenIndex = position168, tokenIndex168
+								{
+									position175 := position
+									{
+										position176 := position
+										if !_rules[ruleLiteralPlaceholder]() {
+											goto l159
+										}
+										add(rulePegText, position176)
+									}
+									{
+										add(ruleAction17, position)
+									}
+									add(ruleJoinCounterDeltaPlaceholder, position175)
+								}
+							}
+						l168:
+							add(ruleJoinCounter, position160)
+						}
+						goto l158
+					l159:
+						position, tokenIndex = position158, tokenIndex158
+						{
+							position178 := position
+							{
+								position179, tokenIndex179 := position, tokenIndex
+								if !_rules[ruleJoinPointKeyText]() {
+									goto l180
+								}
+								goto l179
+							l180:
+								position, tokenIndex = position179, tokenIndex179
+								if !_rules[ruleJoinPointKeyPlaceholder]() {
+									goto l157
+								}
+							}
+						l179:
+							if !_rules[ruleSpacing]() {
+								goto l157
+							}
+							if buffer[position] != rune('=') {
+								goto l157
+							}
+							position++
+							if !_rules[ruleSpacing]() {
+								goto l157
+							}
+							{
+								position181, tokenIndex181 := position, tokenIndex
+								{
+									position183 := position
+									if buffer[position] != rune('"') {
+										goto l182
+									}
+									position++
+									{
+										position184 := position
+										if !_rules[ruleLiteral]() {
+											goto l182
 										}
-										break
+										add(rulePegText, position184)
+									}
+									if buffer[position] != rune('"') {
+										goto l182
+									}
+									position++
+									{
+										add(ruleAction11, position)
 									}
+									add(ruleJoinPointValueText, position183)
+								}
+								goto l181
+							l182:
+								position, tokenIndex = position181, tokenIndex181
+								{
+									position186 := position
+									{
+										position187 := position
+										if !_rules[ruleLiteralPlaceholder]() {
+											goto l157
+										}
+										add(rulePegText, position187)
+									}
+									{
+										add(ruleAction10, position)
+									}
+									add(ruleJoinPointValuePlaceholder, position186)
 								}
-
-								add(ruleWherePart, position7)
 							}
-							goto l5
-						l6:
-							position, tokenIndex = position6, tokenIndex6
+						l181:
+							add(ruleJoinPoint, position178)
 						}
-						add(ruleSelect, position4)
 					}
+				l158:
+					if !_rules[ruleSpacing]() {
+						goto l157
+					}
+					goto l156
+				l157:
+					position, tokenIndex = position157, tokenIndex157
+				}
+				if buffer[position] != rune(')') {
+					goto l141
+				}
+				position++
+				add(ruleJoinRow, position142)
+			}
+			return true
+		l141:
+			position, tokenIndex = position141, tokenIndex141
+			return false
+		},
+		/* 7 JoinRowKey <- <('@' 'k' 'e' 'y' Spacing '=' Spacing (JoinRowKeyValueText / JoinRowKeyValuePlaceholder))> */
+		nil,
+		/* 8 JoinRowKeyValuePlaceholder <- <(<KeyPlaceholder> Action8)> */
+		nil,
+		/* 9 JoinRowKeyValueText <- <((('@' '"' <Literal> '"') / <Key>) Action9)> */
+		nil,
+		/* 10 JoinPoint <- <((JoinPointKeyText / JoinPointKeyPlaceholder) Spacing '=' Spacing (JoinPointValueText / JoinPointValuePlaceholder))> */
+		nil,
+		/* 11 JoinPointValuePlaceholder <- <(<LiteralPlaceholder> Action10)> */
+		nil,
+		/* 12 JoinPointValueText <- <('"' <Literal> '"' Action11)> */
+		nil,
+		/* 13 JoinPointKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action12)> */
+		func() bool {
+			position195, tokenIndex195 := position, tokenIndex
+			{
+				position196 := position
+				{
+					position197, tokenIndex197 := position, tokenIndex
 					{
-						add(ruleAction0, position)
+						position199 := position
+						if !_rules[ruleKey]() {
+							goto l198
+						}
+						add(rulePegText, position199)
 					}
-					goto l2
-				l3:
-					position, tokenIndex = position2, tokenIndex2
+					goto l197
+				l198:
+					position, tokenIndex = position197, tokenIndex197
+					if buffer[position] != rune('@') {
+						goto l195
+					}
+					position++
+					if buffer[position] != rune('"') {
+						goto l195
+					}
+					position++
 					{
-						position23 := position
-						if buffer[position] != rune('j') {
-							goto l0
+						position200 := position
+						if !_rules[ruleLiteral]() {
+							goto l195
 						}
-						position++
-						if buffer[position] != rune('o') {
-							goto l0
+						add(rulePegText, position200)
+					}
+					if buffer[position] != rune('"') {
+						goto l195
+					}
+					position++
+				}
+			l197:
+				{
+					add(ruleAction12, position)
+				}
+				add(ruleJoinPointKeyText, position196)
+			}
+			return true
+		l195:
+			position, tokenIndex = position195, tokenIndex195
+			return false
+		},
+		/* 14 JoinPointKeyPlaceholder <- <(<KeyPlaceholder> Action13)> */
+		func() bool {
+			position202, tokenIndex202 := position, tokenIndex
+			{
+				position203 := position
+				{
+					position204 := position
+					if !_rules[ruleKeyPlaceholder]() {
+						goto l202
+					}
+					add(rulePegText, position204)
+				}
+				{
+					add(ruleAction13, position)
+				}
+				add(ruleJoinPointKeyPlaceholder, position203)
+			}
+			return true
+		l202:
+			position, tokenIndex = position202, tokenIndex202
+			return false
+		},
+		/* 15 JoinCounter <- <((JoinPointKeyText / JoinPointKeyPlaceholder) Spacing JoinCounterOperator Spacing (JoinCounterDeltaText / JoinCounterDeltaPlaceholder))> */
+		nil,
+		/* 16 JoinCounterOperator <- <(('+' '=' Action14) / ('-' '=' Action15))> */
+		nil,
+		/* 17 JoinCounterDeltaText <- <(<[0-9]+> Action16)> */
+		nil,
+		/* 18 JoinCounterDeltaPlaceholder <- <(<LiteralPlaceholder> Action17)> */
+		nil,
+		/* 19 Delete <- <('d' 'e' 'l' 'e' 't' 'e' MustSpacing TableName (MustSpacing CryptoKey)* MustSpacing ('r' 'o' 'w' 's') MustSpacing DeleteRow (Spacing ',' Spacing DeleteRow)* Spacing)> */
+		nil,
+		/* 20 DeleteRow <- <(Action18 '(' Spacing DeleteRowKey Spacing (',' Spacing DeleteEntry Spacing)* ')')> */
+		func() bool {
+			position211, tokenIndex211 := position, tokenIndex
+			{
+				position212 := position
+				{
+					add(ruleAction18, position)
+				}
+				if buffer[position] != rune('(') {
+					goto l211
+				}
+				position++
+				if !_rules[ruleSpacing]() {
+					goto l211
+				}
+				{
+					position214 := position
+					if buffer[position] != rune('@') {
+						goto l211
+					}
+					position++
+					if buffer[position] != rune('k') {
+						goto l211
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l211
+					}
+					position++
+					if buffer[position] != rune('y') {
+						goto l211
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l211
+					}
+					if buffer[position] != rune('=') {
+						goto l211
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l211
+					}
+					{
+						position215, tokenIndex215 := position, tokenIndex
+						{
+							position217 := position
+							{
+								position218, tokenIndex218 := position, tokenIndex
+								if buffer[position] != rune('@') {
+									goto l219
+								}
+								position++
+								if buffer[position] != rune('"') {
+									goto l219
+								}
+								position++
+								{
+									position220 := position
+									if !_rules[ruleLiteral]() {
+										goto l219
+									}
+									add(rulePegText, position220)
+								}
+								if buffer[position] != rune('"') {
+									goto l219
+								}
+								position++
+								goto l218
+							l219:
+								position, tokenIndex = position218, tokenIndex218
+								{
+									position221 := position
+									if !_rules[ruleKey]() {
+										goto l216
+									}
+									add(rulePegText, position221)
+								}
+							}
+						l218:
+							{
+								add(ruleAction20, position)
+							}
+							add(ruleDeleteRowKeyValueText, position217)
+						}
+						goto l215
+					l216:
+						position, tokenIndex = position215, tokenIndex215
+						{
+							position223 := position
+							{
+								position224 := position
+								if !_rules[ruleKeyPlaceholder]() {
+									goto l211
+								}
+								add(rulePegText, position224)
+							}
+							{
+								add(ruleAction19, position)
+							}
+							add(ruleDeleteRowKeyValuePlaceholder, position223)
+						}
+					}
+				l215:
+					add(ruleDeleteRowKey, position214)
+				}
+				if !_rules[ruleSpacing]() {
+					goto l211
+				}
+			l226:
+				{
+					position227, tokenIndex227 := position, tokenIndex
+					if buffer[position] != rune(',') {
+						goto l227
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l227
+					}
+					{
+						position228 := position
+						{
+							position229, tokenIndex229 := position, tokenIndex
+							{
+								position231 := position
+								{
+									position232, tokenIndex232 := position, tokenIndex
+									{
+										position234 := position
+										if !_rules[ruleKey]() {
+											goto l233
+										}
+										add(rulePegText, position234)
+									}
+									goto l232
+								l233:
+									position, tokenIndex = position232, tokenIndex232
+									if buffer[position] != rune('@') {
+										goto l230
+									}
+									position++
+									if buffer[position] != rune('"') {
+										goto l230
+									}
+									position++
+									{
+										position235 := position
+										if !_rules[ruleLiteral]() {
+											goto l230
+										}
+										add(rulePegText, position235)
+									}
+									if buffer[position] != rune('"') {
+										goto l230
+									}
+									position++
+								}
+							l232:
+								{
+									add(ruleAction21, position)
+								}
+								add(ruleDeleteEntryText, position231)
+							}
+							goto l229
+						l230:
+							position, tokenIndex = position229, tokenIndex229
+							{
+								position237 := position
+								{
+									position238 := position
+									if !_rules[ruleKeyPlaceholder]() {
+										goto l227
+									}
+									add(rulePegText, position238)
+								}
+								{
+									add(ruleAction22, position)
+								}
+								add(ruleDeleteEntryPlaceholder, position237)
+							}
+						}
+					l229:
+						add(ruleDeleteEntry, position228)
+					}
+					if !_rules[ruleSpacing]() {
+						goto l227
+					}
+					goto l226
+				l227:
+					position, tokenIndex = position227, tokenIndex227
+				}
+				if buffer[position] != rune(')') {
+					goto l211
+				}
+				position++
+				add(ruleDeleteRow, position212)
+			}
+			return true
+		l211:
+			position, tokenIndex = position211, tokenIndex211
+			return false
+		},
+		/* 21 DeleteRowKey <- <('@' 'k' 'e' 'y' Spacing '=' Spacing (DeleteRowKeyValueText / DeleteRowKeyValuePlaceholder))> */
+		nil,
+		/* 22 DeleteRowKeyValuePlaceholder <- <(<KeyPlaceholder> Action19)> */
+		nil,
+		/* 23 DeleteRowKeyValueText <- <((('@' '"' <Literal> '"') / <Key>) Action20)> */
+		nil,
+		/* 24 DeleteEntry <- <(DeleteEntryText / DeleteEntryPlaceholder)> */
+		nil,
+		/* 25 DeleteEntryText <- <((<Key> / ('@' '"' <Literal> '"')) Action21)> */
+		nil,
+		/* 26 DeleteEntryPlaceholder <- <(<KeyPlaceholder> Action22)> */
+		nil,
+		/* 27 Explain <- <('e' 'x' 'p' 'l' 'a' 'i' 'n' MustSpacing Action23)> */
+		nil,
+		/* 28 Select <- <('s' 'e' 'l' 'e' 'c' 't' MustSpacing (SelectAggregates MustSpacing)? TableName (MustSpacing WherePart)*)> */
+		nil,
+		/* 29 SelectAggregates <- <(Aggregate (Spacing ',' Spacing Aggregate)* (MustSpacing ('f' 'r' 'o' 'm'))?)> */
+		nil,
+		/* 30 Aggregate <- <(MinAggregate / ((&('m') MaxAggregate) | (&('d') DistinctAggregate) | (&('c') CountAggregate)))> */
+		func() bool {
+			position249, tokenIndex249 := position, tokenIndex
+			{
+				position250 := position
+				{
+					position251, tokenIndex251 := position, tokenIndex
+					{
+						position253 := position
+						if buffer[position] != rune('m') {
+							goto l252
 						}
 						position++
 						if buffer[position] != rune('i') {
-							goto l0
+							goto l252
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l0
+							goto l252
 						}
 						position++
-						if !_rules[ruleMustSpacing]() {
-							goto l0
-						}
-						if !_rules[ruleTableName]() {
-							goto l0
-						}
-					l24:
 						{
-							position25, tokenIndex25 := position, tokenIndex
-							if !_rules[ruleMustSpacing]() {
-								goto l25
-							}
-							if !_rules[ruleCryptoKey]() {
-								goto l25
-							}
-							goto l24
-						l25:
-							position, tokenIndex = position25, tokenIndex25
-						}
-						if !_rules[ruleMustSpacing]() {
-							goto l0
+							add(ruleAction26, position)
 						}
-						if buffer[position] != rune('r') {
-							goto l0
+						if !_rules[ruleSpacing]() {
+							goto l252
 						}
-						position++
-						if buffer[position] != rune('o') {
-							goto l0
+						if buffer[position] != rune('(') {
+							goto l252
 						}
 						position++
-						if buffer[position] != rune('w') {
-							goto l0
+						if !_rules[ruleSpacing]() {
+							goto l252
 						}
-						position++
-						if buffer[position] != rune('s') {
-							goto l0
+						if !_rules[ruleAggregateKey]() {
+							goto l252
 						}
-						position++
-						if !_rules[ruleMustSpacing]() {
-							goto l0
+						if !_rules[ruleSpacing]() {
+							goto l252
 						}
-						if !_rules[ruleJoinRow]() {
-							goto l0
+						if buffer[position] != rune(')') {
+							goto l252
 						}
-					l26:
-						{
-							position27, tokenIndex27 := position, tokenIndex
-							if !_rules[ruleSpacing]() {
-								goto l27
-							}
-							if buffer[position] != rune(',') {
-								goto l27
+						position++
+						add(ruleMinAggregate, position253)
+					}
+					goto l251
+				l252:
+					position, tokenIndex = position251, tokenIndex251
+					{
+						switch buffer[position] {
+						case 'm':
+							{
+								position256 := position
+								if buffer[position] != rune('m') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('x') {
+									goto l249
+								}
+								position++
+								{
+									add(ruleAction27, position)
+								}
+								if !_rules[ruleSpacing]() {
+									goto l249
+								}
+								if buffer[position] != rune('(') {
+									goto l249
+								}
+								position++
+								if !_rules[ruleSpacing]() {
+									goto l249
+								}
+								if !_rules[ruleAggregateKey]() {
+									goto l249
+								}
+								if !_rules[ruleSpacing]() {
+									goto l249
+								}
+								if buffer[position] != rune(')') {
+									goto l249
+								}
+								position++
+								add(ruleMaxAggregate, position256)
 							}
-							position++
-							if !_rules[ruleSpacing]() {
-								goto l27
+							break
+						case 'd':
+							{
+								position258 := position
+								if buffer[position] != rune('d') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('s') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('c') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l249
+								}
+								position++
+								{
+									add(ruleAction25, position)
+								}
+								if !_rules[ruleMustSpacing]() {
+									goto l249
+								}
+								if !_rules[ruleAggregateKey]() {
+									goto l249
+								}
+								add(ruleDistinctAggregate, position258)
 							}
-							if !_rules[ruleJoinRow]() {
-								goto l27
+							break
+						default:
+							{
+								position260 := position
+								if buffer[position] != rune('c') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('o') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('u') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l249
+								}
+								position++
+								if buffer[position] != rune('t') {
+									goto l249
+								}
+								position++
+								{
+									add(ruleAction24, position)
+								}
+								add(ruleCountAggregate, position260)
 							}
-							goto l26
-						l27:
-							position, tokenIndex = position27, tokenIndex27
-						}
-						if !_rules[ruleSpacing]() {
-							goto l0
+							break
 						}
-						add(ruleJoin, position23)
-					}
-					{
-						add(ruleAction1, position)
-					}
-				}
-			l2:
-				if !_rules[ruleSpacing]() {
-					goto l0
-				}
-				{
-					position29, tokenIndex29 := position, tokenIndex
-					if !matchDot() {
-						goto l29
 					}
-					goto l0
-				l29:
-					position, tokenIndex = position29, tokenIndex29
+
 				}
-				add(ruleQuery, position1)
+			l251:
+				add(ruleAggregate, position250)
 			}
 			return true
-		l0:
-			position, tokenIndex = position0, tokenIndex0
+		l249:
+			position, tokenIndex = position249, tokenIndex249
 			return false
 		},
-		/* 1 TableName <- <(TableNameText / TableNamePlaceholder)> */
+		/* 31 CountAggregate <- <('c' 'o' 'u' 'n' 't' Action24)> */
+		nil,
+		/* 32 DistinctAggregate <- <('d' 'i' 's' 't' 'i' 'n' 'c' 't' Action25 MustSpacing AggregateKey)> */
+		nil,
+		/* 33 MinAggregate <- <('m' 'i' 'n' Action26 Spacing '(' Spacing AggregateKey Spacing ')')> */
+		nil,
+		/* 34 MaxAggregate <- <('m' 'a' 'x' Action27 Spacing '(' Spacing AggregateKey Spacing ')')> */
+		nil,
+		/* 35 AggregateKey <- <(AggregateKeyText / AggregateKeyPlaceholder)> */
 		func() bool {
-			position30, tokenIndex30 := position, tokenIndex
+			position266, tokenIndex266 := position, tokenIndex
 			{
-				position31 := position
+				position267 := position
 				{
-					position32, tokenIndex32 := position, tokenIndex
+					position268, tokenIndex268 := position, tokenIndex
 					{
-						position34 := position
+						position270 := position
 						{
-							position35 := position
-							if !_rules[ruleKey]() {
-								goto l33
+							position271, tokenIndex271 := position, tokenIndex
+							{
+								position273 := position
+								if !_rules[ruleKey]() {
+									goto l272
+								}
+								add(rulePegText, position273)
+							}
+							goto l271
+						l272:
+							position, tokenIndex = position271, tokenIndex271
+							if buffer[position] != rune('@') {
+								goto l269
 							}
-							add(rulePegText, position35)
+							position++
+							if buffer[position] != rune('"') {
+								goto l269
+							}
+							position++
+							{
+								position274 := position
+								if !_rules[ruleLiteral]() {
+									goto l269
+								}
+								add(rulePegText, position274)
+							}
+							if buffer[position] != rune('"') {
+								goto l269
+							}
+							position++
 						}
+					l271:
 						{
-							add(ruleAction2, position)
+							add(ruleAction28, position)
 						}
-						add(ruleTableNameText, position34)
+						add(ruleAggregateKeyText, position270)
 					}
-					goto l32
-				l33:
-					position, tokenIndex = position32, tokenIndex32
+					goto l268
+				l269:
+					position, tokenIndex = position268, tokenIndex268
 					{
-						position37 := position
+						position276 := position
 						{
-							position38 := position
+							position277 := position
 							if !_rules[ruleKeyPlaceholder]() {
-								goto l30
+								goto l266
 							}
-							add(rulePegText, position38)
+							add(rulePegText, position277)
 						}
 						{
-							add(ruleAction3, position)
+							add(ruleAction29, position)
 						}
-						add(ruleTableNamePlaceholder, position37)
+						add(ruleAggregateKeyPlaceholder, position276)
 					}
 				}
-			l32:
-				add(ruleTableName, position31)
+			l268:
+				add(ruleAggregateKey, position267)
 			}
 			return true
-		l30:
-			position, tokenIndex = position30, tokenIndex30
+		l266:
+			position, tokenIndex = position266, tokenIndex266
 			return false
 		},
-		/* 2 TableNameText <- <(<Key> Action2)> */
+		/* 36 AggregateKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action28)> */
+		nil,
+		/* 37 AggregateKeyPlaceholder <- <(<KeyPlaceholder> Action29)> */
+		nil,
+		/* 38 WherePart <- <(Offset / ((&('s') CryptoKey) | (&('a') At) | (&('j') TableJoin) | (&('f') Fields) | (&('g') GroupBy) | (&('o') OrderBy) | (&('l') Limit) | (&('w') Where)))> */
+		nil,
+		/* 39 At <- <('a' 't' MustSpacing ((&('"') AtText) | (&('t') AtTag) | (&(':' | '?') AtPlaceholder)))> */
+		nil,
+		/* 40 AtTag <- <('t' 'a' 'g' MustSpacing (AtTagText / AtTagPlaceholder))> */
+		nil,
+		/* 41 AtTagText <- <('"' <Literal> '"' Action30)> */
 		nil,
-		/* 3 TableNamePlaceholder <- <(<KeyPlaceholder> Action3)> */
+		/* 42 AtTagPlaceholder <- <(<LiteralPlaceholder> Action31)> */
 		nil,
-		/* 4 Join <- <('j' 'o' 'i' 'n' MustSpacing TableName (MustSpacing CryptoKey)* MustSpacing ('r' 'o' 'w' 's') MustSpacing JoinRow (Spacing ',' Spacing JoinRow)* Spacing)> */
+		/* 43 AtText <- <('"' <Literal> '"' Action32)> */
 		nil,
-		/* 5 JoinRow <- <(Action4 '(' Spacing JoinRowKey Spacing (',' Spacing JoinPoint Spacing)* ')')> */
+		/* 44 AtPlaceholder <- <(<LiteralPlaceholder> Action33)> */
+		nil,
+		/* 45 TableJoin <- <('j' 'o' 'i' 'n' MustSpacing TableJoinName MustSpacing ('o' 'n') MustSpacing TableJoinColumn Spacing '=' Spacing TableJoinColumn)> */
+		nil,
+		/* 46 TableJoinName <- <(<Key> Action34)> */
+		nil,
+		/* 47 TableJoinColumn <- <(<ColumnTable> Action35 '.' (TableJoinColumnRowKey / TableJoinColumnEntry))> */
 		func() bool {
-			position43, tokenIndex43 := position, tokenIndex
+			position290, tokenIndex290 := position, tokenIndex
 			{
-				position44 := position
-				{
-					add(ruleAction4, position)
-				}
-				if buffer[position] != rune('(') {
-					goto l43
-				}
-				position++
-				if !_rules[ruleSpacing]() {
-					goto l43
-				}
+				position291 := position
 				{
-					position46 := position
-					if buffer[position] != rune('@') {
-						goto l43
-					}
-					position++
-					if buffer[position] != rune('k') {
-						goto l43
-					}
-					position++
-					if buffer[position] != rune('e') {
-						goto l43
-					}
-					position++
-					if buffer[position] != rune('y') {
-						goto l43
-					}
-					position++
-					if !_rules[ruleSpacing]() {
-						goto l43
-					}
-					if buffer[position] != rune('=') {
-						goto l43
-					}
-					position++
-					if !_rules[ruleSpacing]() {
-						goto l43
-					}
+					position292 := position
 					{
-						position47, tokenIndex47 := position, tokenIndex
+						position293 := position
 						{
-							position49 := position
-							{
-								position50, tokenIndex50 := position, tokenIndex
-								if buffer[position] != rune('@') {
-									goto l51
-								}
-								position++
-								if buffer[position] != rune('"') {
-									goto l51
+							switch buffer[position] {
+							case '-':
+								if buffer[position] != rune('-') {
+									goto l290
 								}
 								position++
 								{
-									position52 := position
-									if !_rules[ruleLiteral]() {
-										goto l51
+									position297, tokenIndex297 := position, tokenIndex
+									if buffer[position] != rune('-') {
+										goto l297
 									}
-									add(rulePegText, position52)
+									position++
+									goto l290
+								l297:
+									position, tokenIndex = position297, tokenIndex297
 								}
-								if buffer[position] != rune('"') {
-									goto l51
+								break
+							case '+':
+								if buffer[position] != rune('+') {
+									goto l290
 								}
 								position++
-								goto l50
-							l51:
-								position, tokenIndex = position50, tokenIndex50
-								{
-									position53 := position
-									if !_rules[ruleKey]() {
-										goto l48
-									}
-									add(rulePegText, position53)
+								break
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l290
 								}
-							}
-						l50:
-							{
-								add(ruleAction6, position)
-							}
-							add(ruleJoinRowKeyValueText, position49)
-						}
-						goto l47
-					l48:
-						position, tokenIndex = position47, tokenIndex47
-						{
-							position55 := position
-							{
-								position56 := position
-								if !_rules[ruleKeyPlaceholder]() {
-									goto l43
+								position++
+								break
+							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l290
 								}
-								add(rulePegText, position56)
-							}
-							{
-								add(ruleAction5, position)
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l290
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l290
+								}
+								position++
+								break
 							}
-							add(ruleJoinRowKeyValuePlaceholder, position55)
 						}
-					}
-				l47:
-					add(ruleJoinRowKey, position46)
-				}
-				if !_rules[ruleSpacing]() {
-					goto l43
-				}
-			l58:
-				{
-					position59, tokenIndex59 := position, tokenIndex
-					if buffer[position] != rune(',') {
-						goto l59
-					}
-					position++
-					if !_rules[ruleSpacing]() {
-						goto l59
-					}
-					{
-						position60 := position
+
+					l294:
 						{
-							position61, tokenIndex61 := position, tokenIndex
+							position295, tokenIndex295 := position, tokenIndex
 							{
-								position63 := position
-								{
-									position64, tokenIndex64 := position, tokenIndex
+								switch buffer[position] {
+								case '-':
+									if buffer[position] != rune('-') {
+										goto l295
+									}
+									position++
 									{
-										position66 := position
-										if !_rules[ruleKey]() {
-											goto l65
+										position299, tokenIndex299 := position, tokenIndex
+										if buffer[position] != rune('-') {
+											goto l299
 										}
-										add(rulePegText, position66)
+										position++
+										goto l295
+									l299:
+										position, tokenIndex = position299, tokenIndex299
 									}
-									goto l64
-								l65:
-									position, tokenIndex = position64, tokenIndex64
-									if buffer[position] != rune('@') {
-										goto l62
+									break
+								case '+':
+									if buffer[position] != rune('+') {
+										goto l295
 									}
 									position++
-									if buffer[position] != rune('"') {
-										goto l62
+									break
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l295
 									}
 									position++
-									{
-										position67 := position
-										if !_rules[ruleLiteral]() {
-											goto l62
-										}
-										add(rulePegText, position67)
+									break
+								case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l295
 									}
-									if buffer[position] != rune('"') {
-										goto l62
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l295
 									}
 									position++
-								}
-							l64:
-								{
-									add(ruleAction9, position)
-								}
-								add(ruleJoinPointKeyText, position63)
-							}
-							goto l61
-						l62:
-							position, tokenIndex = position61, tokenIndex61
-							{
-								position69 := position
-								{
-									position70 := position
-									if !_rules[ruleKeyPlaceholder]() {
-										goto l59
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l295
 									}
-									add(rulePegText, position70)
-								}
-								{
-									add(ruleAction10, position)
+									position++
+									break
 								}
-								add(ruleJoinPointKeyPlaceholder, position69)
 							}
+
+							goto l294
+						l295:
+							position, tokenIndex = position295, tokenIndex295
 						}
-					l61:
-						if !_rules[ruleSpacing]() {
-							goto l59
+						add(ruleColumnTable, position293)
+					}
+					add(rulePegText, position292)
+				}
+				{
+					add(ruleAction35, position)
+				}
+				if buffer[position] != rune('.') {
+					goto l290
+				}
+				position++
+				{
+					position301, tokenIndex301 := position, tokenIndex
+					{
+						position303 := position
+						if buffer[position] != rune('@') {
+							goto l302
 						}
-						if buffer[position] != rune('=') {
-							goto l59
+						position++
+						if buffer[position] != rune('k') {
+							goto l302
 						}
 						position++
-						if !_rules[ruleSpacing]() {
-							goto l59
+						if buffer[position] != rune('e') {
+							goto l302
+						}
+						position++
+						if buffer[position] != rune('y') {
+							goto l302
+						}
+						position++
+						{
+							add(ruleAction36, position)
 						}
+						add(ruleTableJoinColumnRowKey, position303)
+					}
+					goto l301
+				l302:
+					position, tokenIndex = position301, tokenIndex301
+					{
+						position305 := position
 						{
-							position72, tokenIndex72 := position, tokenIndex
+							position306, tokenIndex306 := position, tokenIndex
 							{
-								position74 := position
-								if buffer[position] != rune('"') {
-									goto l73
-								}
-								position++
-								{
-									position75 := position
-									if !_rules[ruleLiteral]() {
-										goto l73
-									}
-									add(rulePegText, position75)
+								position308 := position
+								if !_rules[ruleKey]() {
+									goto l307
 								}
-								if buffer[position] != rune('"') {
-									goto l73
+								add(rulePegText, position308)
+							}
+							goto l306
+						l307:
+							position, tokenIndex = position306, tokenIndex306
+							if buffer[position] != rune('@') {
+								goto l290
+							}
+							position++
+							if buffer[position] != rune('"') {
+								goto l290
+							}
+							position++
+							{
+								position309 := position
+								if !_rules[ruleLiteral]() {
+									goto l290
 								}
-								position++
-								{
-									add(ruleAction8, position)
+								add(rulePegText, position309)
+							}
+							if buffer[position] != rune('"') {
+								goto l290
+							}
+							position++
+						}
+					l306:
+						{
+							add(ruleAction37, position)
+						}
+						add(ruleTableJoinColumnEntry, position305)
+					}
+				}
+			l301:
+				add(ruleTableJoinColumn, position291)
+			}
+			return true
+		l290:
+			position, tokenIndex = position290, tokenIndex290
+			return false
+		},
+		/* 48 TableJoinColumnRowKey <- <('@' 'k' 'e' 'y' Action36)> */
+		nil,
+		/* 49 TableJoinColumnEntry <- <((<Key> / ('@' '"' <Literal> '"')) Action37)> */
+		nil,
+		/* 50 GroupBy <- <('g' 'r' 'o' 'u' 'p' MustSpacing ('b' 'y') MustSpacing (GroupByText / GroupByPlaceholder))> */
+		nil,
+		/* 51 GroupByText <- <((<Key> / ('@' '"' <Literal> '"')) Action38)> */
+		nil,
+		/* 52 GroupByPlaceholder <- <(<KeyPlaceholder> Action39)> */
+		nil,
+		/* 53 OrderBy <- <('o' 'r' 'd' 'e' 'r' MustSpacing ('b' 'y') MustSpacing (OrderByRowKey / OrderByKeyText / OrderByKeyPlaceholder) (MustSpacing OrderByDirection)?)> */
+		nil,
+		/* 54 OrderByRowKey <- <('@' 'k' 'e' 'y' Action40)> */
+		nil,
+		/* 55 OrderByKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action41)> */
+		nil,
+		/* 56 OrderByKeyPlaceholder <- <(<KeyPlaceholder> Action42)> */
+		nil,
+		/* 57 OrderByDirection <- <(('a' 's' 'c') / ('d' 'e' 's' 'c' Action43))> */
+		nil,
+		/* 58 Fields <- <('f' 'i' 'e' 'l' 'd' 's' Spacing '(' Spacing Field (Spacing ',' Spacing Field)* Spacing ')')> */
+		nil,
+		/* 59 Field <- <(FieldText / FieldPlaceholder)> */
+		func() bool {
+			position322, tokenIndex322 := position, tokenIndex
+			{
+				position323 := position
+				{
+					position324, tokenIndex324 := position, tokenIndex
+					{
+						position326 := position
+						{
+							position327, tokenIndex327 := position, tokenIndex
+							{
+								position329 := position
+								if !_rules[ruleKey]() {
+									goto l328
 								}
-								add(ruleJoinPointValueText, position74)
+								add(rulePegText, position329)
+							}
+							goto l327
+						l328:
+							position, tokenIndex = position327, tokenIndex327
+							if buffer[position] != rune('@') {
+								goto l325
 							}
-							goto l72
-						l73:
-							position, tokenIndex = position72, tokenIndex72
+							position++
+							if buffer[position] != rune('"') {
+								goto l325
+							}
+							position++
 							{
-								position77 := position
-								{
-									position78 := position
-									if !_rules[ruleLiteralPlaceholder]() {
-										goto l59
-									}
-									add(rulePegText, position78)
-								}
-								{
-									add(ruleAction7, position)
+								position330 := position
+								if !_rules[ruleLiteral]() {
+									goto l325
 								}
-								add(ruleJoinPointValuePlaceholder, position77)
+								add(rulePegText, position330)
+							}
+							if buffer[position] != rune('"') {
+								goto l325
 							}
+							position++
 						}
-					l72:
-						add(ruleJoinPoint, position60)
+					l327:
+						{
+							add(ruleAction44, position)
+						}
+						add(ruleFieldText, position326)
 					}
-					if !_rules[ruleSpacing]() {
-						goto l59
+					goto l324
+				l325:
+					position, tokenIndex = position324, tokenIndex324
+					{
+						position332 := position
+						{
+							position333 := position
+							if !_rules[ruleKeyPlaceholder]() {
+								goto l322
+							}
+							add(rulePegText, position333)
+						}
+						{
+							add(ruleAction45, position)
+						}
+						add(ruleFieldPlaceholder, position332)
 					}
-					goto l58
-				l59:
-					position, tokenIndex = position59, tokenIndex59
-				}
-				if buffer[position] != rune(')') {
-					goto l43
 				}
-				position++
-				add(ruleJoinRow, position44)
+			l324:
+				add(ruleField, position323)
 			}
 			return true
-		l43:
-			position, tokenIndex = position43, tokenIndex43
+		l322:
+			position, tokenIndex = position322, tokenIndex322
 			return false
 		},
-		/* 6 JoinRowKey <- <('@' 'k' 'e' 'y' Spacing '=' Spacing (JoinRowKeyValueText / JoinRowKeyValuePlaceholder))> */
-		nil,
-		/* 7 JoinRowKeyValuePlaceholder <- <(<KeyPlaceholder> Action5)> */
-		nil,
-		/* 8 JoinRowKeyValueText <- <((('@' '"' <Literal> '"') / <Key>) Action6)> */
-		nil,
-		/* 9 JoinPoint <- <((JoinPointKeyText / JoinPointKeyPlaceholder) Spacing '=' Spacing (JoinPointValueText / JoinPointValuePlaceholder))> */
+		/* 60 FieldText <- <((<Key> / ('@' '"' <Literal> '"')) Action44)> */
 		nil,
-		/* 10 JoinPointValuePlaceholder <- <(<LiteralPlaceholder> Action7)> */
+		/* 61 FieldPlaceholder <- <(<KeyPlaceholder> Action45)> */
 		nil,
-		/* 11 JoinPointValueText <- <('"' <Literal> '"' Action8)> */
+		/* 62 Limit <- <('l' 'i' 'm' 'i' 't' MustSpacing (LimitText / LimitPlaceholder))> */
 		nil,
-		/* 12 JoinPointKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action9)> */
+		/* 63 LimitText <- <(<PositiveInteger> Action46)> */
 		nil,
-		/* 13 JoinPointKeyPlaceholder <- <(<KeyPlaceholder> Action10)> */
+		/* 64 LimitPlaceholder <- <(<LiteralPlaceholder> Action47)> */
 		nil,
-		/* 14 Select <- <('s' 'e' 'l' 'e' 'c' 't' MustSpacing TableName (MustSpacing WherePart)*)> */
+		/* 65 Offset <- <('o' 'f' 'f' 's' 'e' 't' MustSpacing (OffsetText / OffsetPlaceholder))> */
 		nil,
-		/* 15 WherePart <- <((&('s') CryptoKey) | (&('l') Limit) | (&('w') Where))> */
+		/* 66 OffsetText <- <(<[0-9]+> Action48)> */
 		nil,
-		/* 16 Limit <- <('l' 'i' 'm' 'i' 't' MustSpacing (LimitText / LimitPlaceholder))> */
+		/* 67 OffsetPlaceholder <- <(<LiteralPlaceholder> Action49)> */
 		nil,
-		/* 17 LimitText <- <(<PositiveInteger> Action11)> */
-		nil,
-		/* 18 LimitPlaceholder <- <(<LiteralPlaceholder> Action12)> */
-		nil,
-		/* 19 CryptoKey <- <('s' 'i' 'g' 'n' 'e' 'd' MustSpacing '"' <Key> '"' Action13)> */
+		/* 68 CryptoKey <- <('s' 'i' 'g' 'n' 'e' 'd' MustSpacing (CryptoKeyText / CryptoKeyPlaceholder))> */
 		func() bool {
-			position93, tokenIndex93 := position, tokenIndex
+			position343, tokenIndex343 := position, tokenIndex
 			{
-				position94 := position
+				position344 := position
 				if buffer[position] != rune('s') {
-					goto l93
+					goto l343
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l93
+					goto l343
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l93
+					goto l343
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l93
+					goto l343
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l93
+					goto l343
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l93
+					goto l343
 				}
 				position++
 				if !_rules[ruleMustSpacing]() {
-					goto l93
+					goto l343
 				}
-				if buffer[position] != rune('"') {
-					goto l93
-				}
-				position++
 				{
-					position95 := position
-					if !_rules[ruleKey]() {
-						goto l93
+					position345, tokenIndex345 := position, tokenIndex
+					{
+						position347 := position
+						if buffer[position] != rune('"') {
+							goto l346
+						}
+						position++
+						{
+							position348 := position
+							if !_rules[ruleKey]() {
+								goto l346
+							}
+							add(rulePegText, position348)
+						}
+						if buffer[position] != rune('"') {
+							goto l346
+						}
+						position++
+						{
+							add(ruleAction50, position)
+						}
+						add(ruleCryptoKeyText, position347)
+					}
+					goto l345
+				l346:
+					position, tokenIndex = position345, tokenIndex345
+					{
+						position350 := position
+						{
+							position351 := position
+							if !_rules[ruleLiteralPlaceholder]() {
+								goto l343
+							}
+							add(rulePegText, position351)
+						}
+						{
+							add(ruleAction51, position)
+						}
+						add(ruleCryptoKeyPlaceholder, position350)
 					}
-					add(rulePegText, position95)
-				}
-				if buffer[position] != rune('"') {
-					goto l93
-				}
-				position++
-				{
-					add(ruleAction13, position)
 				}
-				add(ruleCryptoKey, position94)
+			l345:
+				add(ruleCryptoKey, position344)
 			}
 			return true
-		l93:
-			position, tokenIndex = position93, tokenIndex93
+		l343:
+			position, tokenIndex = position343, tokenIndex343
 			return false
 		},
-		/* 20 Where <- <('w' 'h' 'e' 'r' 'e' MustSpacing WhereClause)> */
+		/* 69 CryptoKeyText <- <('"' <Key> '"' Action50)> */
+		nil,
+		/* 70 CryptoKeyPlaceholder <- <(<LiteralPlaceholder> Action51)> */
 		nil,
-		/* 21 WhereClause <- <(Action14 (AndClause / OrClause / PredicateClause) Action15)> */
+		/* 71 Where <- <('w' 'h' 'e' 'r' 'e' MustSpacing WhereClause)> */
+		nil,
+		/* 72 WhereClause <- <(Action52 (AndClause / OrClause / NotClause / PredicateClause) Action53)> */
 		func() bool {
-			position98, tokenIndex98 := position, tokenIndex
+			position356, tokenIndex356 := position, tokenIndex
 			{
-				position99 := position
+				position357 := position
 				{
-					add(ruleAction14, position)
+					add(ruleAction52, position)
 				}
 				{
-					position101, tokenIndex101 := position, tokenIndex
+					position359, tokenIndex359 := position, tokenIndex
 					{
-						position103 := position
+						position361 := position
 						if buffer[position] != rune('a') {
-							goto l102
+							goto l360
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l102
+							goto l360
 						}
 						position++
 						if buffer[position] != rune('d') {
-							goto l102
+							goto l360
 						}
 						position++
 						{
-							add(ruleAction16, position)
+							add(ruleAction54, position)
 						}
 						if !_rules[ruleSpacing]() {
-							goto l102
+							goto l360
 						}
 						if buffer[position] != rune('(') {
-							goto l102
+							goto l360
 						}
 						position++
 						if !_rules[ruleSpacing]() {
-							goto l102
+							goto l360
 						}
 						if !_rules[ruleWhereClause]() {
-							goto l102
+							goto l360
 						}
 						if !_rules[ruleSpacing]() {
-							goto l102
+							goto l360
 						}
-					l105:
+					l363:
 						{
-							position106, tokenIndex106 := position, tokenIndex
+							position364, tokenIndex364 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l106
+								goto l364
 							}
 							position++
 							if !_rules[ruleSpacing]() {
-								goto l106
+								goto l364
 							}
 							if !_rules[ruleWhereClause]() {
-								goto l106
+								goto l364
 							}
 							if !_rules[ruleSpacing]() {
-								goto l106
+								goto l364
 							}
-							goto l105
-						l106:
-							position, tokenIndex = position106, tokenIndex106
+							goto l363
+						l364:
+							position, tokenIndex = position364, tokenIndex364
 						}
 						if buffer[position] != rune(')') {
-							goto l102
+							goto l360
 						}
 						position++
-						add(ruleAndClause, position103)
+						add(ruleAndClause, position361)
 					}
-					goto l101
-				l102:
-					position, tokenIndex = position101, tokenIndex101
+					goto l359
+				l360:
+					position, tokenIndex = position359, tokenIndex359
 					{
-						position108 := position
+						position366 := position
 						if buffer[position] != rune('o') {
-							goto l107
+							goto l365
 						}
 						position++
 						if buffer[position] != rune('r') {
-							goto l107
+							goto l365
 						}
 						position++
 						{
-							add(ruleAction17, position)
+							add(ruleAction55, position)
 						}
 						if !_rules[ruleSpacing]() {
-							goto l107
+							goto l365
 						}
 						if buffer[position] != rune('(') {
-							goto l107
+							goto l365
 						}
 						position++
 						if !_rules[ruleSpacing]() {
-							goto l107
+							goto l365
 						}
 						if !_rules[ruleWhereClause]() {
-							goto l107
+							goto l365
 						}
 						if !_rules[ruleSpacing]() {
-							goto l107
+							goto l365
 						}
-					l110:
+					l368:
 						{
-							position111, tokenIndex111 := position, tokenIndex
+							position369, tokenIndex369 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l111
+								goto l369
 							}
 							position++
 							if !_rules[ruleSpacing]() {
-								goto l111
+								goto l369
 							}
 							if !_rules[ruleWhereClause]() {
-								goto l111
+								goto l369
 							}
 							if !_rules[ruleSpacing]() {
-								goto l111
+								goto l369
 							}
-							goto l110
-						l111:
-							position, tokenIndex = position111, tokenIndex111
+							goto l368
+						l369:
+							position, tokenIndex = position369, tokenIndex369
+						}
+						if buffer[position] != rune(')') {
+							goto l365
+						}
+						position++
+						add(ruleOrClause, position366)
+					}
+					goto l359
+				l365:
+					position, tokenIndex = position359, tokenIndex359
+					{
+						position371 := position
+						if buffer[position] != rune('n') {
+							goto l370
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l370
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l370
+						}
+						position++
+						{
+							add(ruleAction56, position)
+						}
+						if !_rules[ruleSpacing]() {
+							goto l370
+						}
+						if buffer[position] != rune('(') {
+							goto l370
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l370
+						}
+						if !_rules[ruleWhereClause]() {
+							goto l370
+						}
+						if !_rules[ruleSpacing]() {
+							goto l370
 						}
 						if buffer[position] != rune(')') {
-							goto l107
+							goto l370
 						}
 						position++
-						add(ruleOrClause, position108)
+						add(ruleNotClause, position371)
 					}
-					goto l101
-				l107:
-					position, tokenIndex = position101, tokenIndex101
+					goto l359
+				l370:
+					position, tokenIndex = position359, tokenIndex359
 					{
-						position112 := position
+						position373 := position
 						{
-							add(ruleAction18, position)
+							add(ruleAction57, position)
 						}
 						{
-							position114 := position
+							position375 := position
 							{
-								position115 := position
+								position376 := position
 								if !_rules[ruleKey]() {
-									goto l98
+									goto l356
 								}
-								add(rulePegText, position115)
+								add(rulePegText, position376)
 							}
 							{
-								add(ruleAction19, position)
+								add(ruleAction58, position)
 							}
-							add(rulePredicate, position114)
+							add(rulePredicate, position375)
 						}
 						if !_rules[ruleSpacing]() {
-							goto l98
+							goto l356
 						}
 						if buffer[position] != rune('(') {
-							goto l98
+							goto l356
 						}
 						position++
 						if !_rules[ruleSpacing]() {
-							goto l98
+							goto l356
 						}
 						if !_rules[rulePredicateValue]() {
-							goto l98
+							goto l356
+						}
+						if !_rules[ruleSpacing]() {
+							goto l356
 						}
-					l117:
+					l378:
 						{
-							position118, tokenIndex118 := position, tokenIndex
+							position379, tokenIndex379 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l118
+								goto l379
 							}
 							position++
 							if !_rules[ruleSpacing]() {
-								goto l118
+								goto l379
 							}
 							if !_rules[rulePredicateValue]() {
-								goto l118
+								goto l379
 							}
 							if !_rules[ruleSpacing]() {
-								goto l118
+								goto l379
 							}
-							goto l117
-						l118:
-							position, tokenIndex = position118, tokenIndex118
+							goto l378
+						l379:
+							position, tokenIndex = position379, tokenIndex379
 						}
 						if buffer[position] != rune(')') {
-							goto l98
+							goto l356
 						}
 						position++
-						add(rulePredicateClause, position112)
+						add(rulePredicateClause, position373)
 					}
 				}
-			l101:
+			l359:
 				{
-					add(ruleAction15, position)
+					add(ruleAction53, position)
 				}
-				add(ruleWhereClause, position99)
+				add(ruleWhereClause, position357)
 			}
 			return true
-		l98:
-			position, tokenIndex = position98, tokenIndex98
+		l356:
+			position, tokenIndex = position356, tokenIndex356
 			return false
 		},
-		/* 22 AndClause <- <('a' 'n' 'd' Action16 Spacing '(' Spacing WhereClause Spacing (',' Spacing WhereClause Spacing)* ')')> */
+		/* 73 AndClause <- <('a' 'n' 'd' Action54 Spacing '(' Spacing WhereClause Spacing (',' Spacing WhereClause Spacing)* ')')> */
+		nil,
+		/* 74 OrClause <- <('o' 'r' Action55 Spacing '(' Spacing WhereClause Spacing (',' Spacing WhereClause Spacing)* ')')> */
 		nil,
-		/* 23 OrClause <- <('o' 'r' Action17 Spacing '(' Spacing WhereClause Spacing (',' Spacing WhereClause Spacing)* ')')> */
+		/* 75 NotClause <- <('n' 'o' 't' Action56 Spacing '(' Spacing WhereClause Spacing ')')> */
 		nil,
-		/* 24 PredicateClause <- <(Action18 Predicate Spacing '(' Spacing PredicateValue (',' Spacing PredicateValue Spacing)* ')')> */
+		/* 76 PredicateClause <- <(Action57 Predicate Spacing '(' Spacing PredicateValue Spacing (',' Spacing PredicateValue Spacing)* ')')> */
 		nil,
-		/* 25 Predicate <- <(<Key> Action19)> */
+		/* 77 Predicate <- <(<Key> Action58)> */
 		nil,
-		/* 26 PredicateValue <- <(PredicateRowKey / PredicateKey / PredicateLiteral)> */
+		/* 78 PredicateValue <- <(PredicateRowKey / PredicateKey / PredicateLiteral)> */
 		func() bool {
-			position124, tokenIndex124 := position, tokenIndex
+			position386, tokenIndex386 := position, tokenIndex
 			{
-				position125 := position
+				position387 := position
 				{
-					position126, tokenIndex126 := position, tokenIndex
+					position388, tokenIndex388 := position, tokenIndex
 					{
-						position128 := position
+						position390 := position
 						if buffer[position] != rune('@') {
-							goto l127
+							goto l389
 						}
 						position++
 						if buffer[position] != rune('k') {
-							goto l127
+							goto l389
 						}
 						position++
 						if buffer[position] != rune('e') {
-							goto l127
+							goto l389
 						}
 						position++
 						if buffer[position] != rune('y') {
-							goto l127
+							goto l389
 						}
 						position++
 						{
-							add(ruleAction20, position)
+							add(ruleAction59, position)
 						}
-						add(rulePredicateRowKey, position128)
+						add(rulePredicateRowKey, position390)
 					}
-					goto l126
-				l127:
-					position, tokenIndex = position126, tokenIndex126
+					goto l388
+				l389:
+					position, tokenIndex = position388, tokenIndex388
 					{
-						position131 := position
+						position393 := position
 						{
-							position132, tokenIndex132 := position, tokenIndex
+							position394, tokenIndex394 := position, tokenIndex
 							{
-								position134 := position
+								position396 := position
 								{
-									position135, tokenIndex135 := position, tokenIndex
+									position397, tokenIndex397 := position, tokenIndex
 									{
-										position137 := position
+										position399 := position
 										if !_rules[ruleKey]() {
-											goto l136
+											goto l398
 										}
-										add(rulePegText, position137)
+										add(rulePegText, position399)
 									}
-									goto l135
-								l136:
-									position, tokenIndex = position135, tokenIndex135
+									goto l397
+								l398:
+									position, tokenIndex = position397, tokenIndex397
 									if buffer[position] != rune('@') {
-										goto l133
+										goto l395
 									}
 									position++
 									if buffer[position] != rune('"') {
-										goto l133
+										goto l395
 									}
 									position++
 									{
-										position138 := position
+										position400 := position
 										if !_rules[ruleLiteral]() {
-											goto l133
+											goto l395
 										}
-										add(rulePegText, position138)
+										add(rulePegText, position400)
 									}
 									if buffer[position] != rune('"') {
-										goto l133
+										goto l395
 									}
 									position++
 								}
-							l135:
+							l397:
 								{
-									add(ruleAction21, position)
+									add(ruleAction60, position)
 								}
-								add(rulePredicateKeyText, position134)
+								add(rulePredicateKeyText, position396)
 							}
-							goto l132
-						l133:
-							position, tokenIndex = position132, tokenIndex132
+							goto l394
+						l395:
+							position, tokenIndex = position394, tokenIndex394
 							{
-								position140 := position
+								position402 := position
 								{
-									position141 := position
+									position403 := position
 									if !_rules[ruleKeyPlaceholder]() {
-										goto l130
+										goto l392
 									}
-									add(rulePegText, position141)
+									add(rulePegText, position403)
 								}
 								{
-									add(ruleAction22, position)
+									add(ruleAction61, position)
 								}
-								add(rulePredicateKeyLiteral, position140)
+								add(rulePredicateKeyLiteral, position402)
 							}
 						}
-					l132:
-						add(rulePredicateKey, position131)
+					l394:
+						add(rulePredicateKey, position393)
 					}
-					goto l126
-				l130:
-					position, tokenIndex = position126, tokenIndex126
+					goto l388
+				l392:
+					position, tokenIndex = position388, tokenIndex388
 					{
-						position143 := position
+						position405 := position
 						{
-							position144, tokenIndex144 := position, tokenIndex
+							position406, tokenIndex406 := position, tokenIndex
 							{
-								position146 := position
+								position408 := position
 								if buffer[position] != rune('"') {
-									goto l145
+									goto l407
 								}
 								position++
 								{
-									position147 := position
+									position409 := position
 									if !_rules[ruleLiteral]() {
-										goto l145
+										goto l407
 									}
-									add(rulePegText, position147)
+									add(rulePegText, position409)
 								}
 								if buffer[position] != rune('"') {
-									goto l145
+									goto l407
 								}
 								position++
 								{
-									add(ruleAction23, position)
+									add(ruleAction62, position)
 								}
-								add(rulePredicateLiteralText, position146)
+								add(rulePredicateLiteralText, position408)
 							}
-							goto l144
-						l145:
-							position, tokenIndex = position144, tokenIndex144
+							goto l406
+						l407:
+							position, tokenIndex = position406, tokenIndex406
 							{
-								position149 := position
+								position411 := position
 								{
-									position150 := position
+									position412 := position
 									if !_rules[ruleLiteralPlaceholder]() {
-										goto l124
+										goto l386
 									}
-									add(rulePegText, position150)
+									add(rulePegText, position412)
 								}
 								{
-									add(ruleAction24, position)
+									add(ruleAction63, position)
 								}
-								add(rulePredicateLiteralPlaceholder, position149)
+								add(rulePredicateLiteralPlaceholder, position411)
 							}
 						}
-					l144:
-						add(rulePredicateLiteral, position143)
+					l406:
+						add(rulePredicateLiteral, position405)
 					}
 				}
-			l126:
-				add(rulePredicateValue, position125)
+			l388:
+				add(rulePredicateValue, position387)
 			}
 			return true
-		l124:
-			position, tokenIndex = position124, tokenIndex124
+		l386:
+			position, tokenIndex = position386, tokenIndex386
 			return false
 		},
-		/* 27 PredicateRowKey <- <('@' 'k' 'e' 'y' Action20)> */
+		/* 79 PredicateRowKey <- <('@' 'k' 'e' 'y' Action59)> */
 		nil,
-		/* 28 PredicateKey <- <(PredicateKeyText / PredicateKeyLiteral)> */
+		/* 80 PredicateKey <- <(PredicateKeyText / PredicateKeyLiteral)> */
 		nil,
-		/* 29 PredicateKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action21)> */
+		/* 81 PredicateKeyText <- <((<Key> / ('@' '"' <Literal> '"')) Action60)> */
 		nil,
-		/* 30 PredicateKeyLiteral <- <(<KeyPlaceholder> Action22)> */
+		/* 82 PredicateKeyLiteral <- <(<KeyPlaceholder> Action61)> */
 		nil,
-		/* 31 PredicateLiteral <- <(PredicateLiteralText / PredicateLiteralPlaceholder)> */
+		/* 83 PredicateLiteral <- <(PredicateLiteralText / PredicateLiteralPlaceholder)> */
 		nil,
-		/* 32 PredicateLiteralText <- <('"' <Literal> '"' Action23)> */
+		/* 84 PredicateLiteralText <- <('"' <Literal> '"' Action62)> */
 		nil,
-		/* 33 PredicateLiteralPlaceholder <- <(<LiteralPlaceholder> Action24)> */
+		/* 85 PredicateLiteralPlaceholder <- <(<LiteralPlaceholder> Action63)> */
 		nil,
-		/* 34 KeyPlaceholder <- <('?' '?')> */
+		/* 86 KeyPlaceholder <- <(('?' '?') / (':' ':' PlaceholderName))> */
 		func() bool {
-			position159, tokenIndex159 := position, tokenIndex
+			position421, tokenIndex421 := position, tokenIndex
 			{
-				position160 := position
-				if buffer[position] != rune('?') {
-					goto l159
+				position422 := position
+				{
+					position423, tokenIndex423 := position, tokenIndex
+					if buffer[position] != rune('?') {
+						goto l424
+					}
+					position++
+					if buffer[position] != rune('?') {
+						goto l424
+					}
+					position++
+					goto l423
+				l424:
+					position, tokenIndex = position423, tokenIndex423
+					if buffer[position] != rune(':') {
+						goto l421
+					}
+					position++
+					if buffer[position] != rune(':') {
+						goto l421
+					}
+					position++
+					if !_rules[rulePlaceholderName]() {
+						goto l421
+					}
 				}
-				position++
-				if buffer[position] != rune('?') {
-					goto l159
+			l423:
+				add(ruleKeyPlaceholder, position422)
+			}
+			return true
+		l421:
+			position, tokenIndex = position421, tokenIndex421
+			return false
+		},
+		/* 87 LiteralPlaceholder <- <('?' / (':' PlaceholderName))> */
+		func() bool {
+			position425, tokenIndex425 := position, tokenIndex
+			{
+				position426 := position
+				{
+					position427, tokenIndex427 := position, tokenIndex
+					if buffer[position] != rune('?') {
+						goto l428
+					}
+					position++
+					goto l427
+				l428:
+					position, tokenIndex = position427, tokenIndex427
+					if buffer[position] != rune(':') {
+						goto l425
+					}
+					position++
+					if !_rules[rulePlaceholderName]() {
+						goto l425
+					}
 				}
-				position++
-				add(ruleKeyPlaceholder, position160)
+			l427:
+				add(ruleLiteralPlaceholder, position426)
 			}
 			return true
-		l159:
-			position, tokenIndex = position159, tokenIndex159
+		l425:
+			position, tokenIndex = position425, tokenIndex425
 			return false
 		},
-		/* 35 LiteralPlaceholder <- <'?'> */
+		/* 88 PlaceholderName <- <(((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) ((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> */
 		func() bool {
-			position161, tokenIndex161 := position, tokenIndex
+			position429, tokenIndex429 := position, tokenIndex
 			{
-				position162 := position
-				if buffer[position] != rune('?') {
-					goto l161
+				position430 := position
+				{
+					switch buffer[position] {
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l429
+						}
+						position++
+						break
+					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l429
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l429
+						}
+						position++
+						break
+					}
 				}
-				position++
-				add(ruleLiteralPlaceholder, position162)
+
+			l432:
+				{
+					position433, tokenIndex433 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l433
+							}
+							position++
+							break
+						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l433
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l433
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l433
+							}
+							position++
+							break
+						}
+					}
+
+					goto l432
+				l433:
+					position, tokenIndex = position433, tokenIndex433
+				}
+				add(rulePlaceholderName, position430)
 			}
 			return true
-		l161:
-			position, tokenIndex = position161, tokenIndex161
+		l429:
+			position, tokenIndex = position429, tokenIndex429
 			return false
 		},
-		/* 36 Literal <- <(Escape / (!'"' .))*> */
+		/* 89 Literal <- <(Escape / (!'"' .))*> */
 		func() bool {
 			{
-				position164 := position
-			l165:
+				position436 := position
+			l437:
 				{
-					position166, tokenIndex166 := position, tokenIndex
+					position438, tokenIndex438 := position, tokenIndex
 					{
-						position167, tokenIndex167 := position, tokenIndex
+						position439, tokenIndex439 := position, tokenIndex
 						{
-							position169 := position
+							position441 := position
 							if buffer[position] != rune('\\') {
-								goto l168
+								goto l440
 							}
 							position++
 							{
 								switch buffer[position] {
 								case 'v':
 									if buffer[position] != rune('v') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 't':
 									if buffer[position] != rune('t') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 'r':
 									if buffer[position] != rune('r') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 'n':
 									if buffer[position] != rune('n') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 'f':
 									if buffer[position] != rune('f') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 'b':
 									if buffer[position] != rune('b') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case 'a':
 									if buffer[position] != rune('a') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								case '\\':
 									if buffer[position] != rune('\\') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								default:
 									if buffer[position] != rune('"') {
-										goto l168
+										goto l440
 									}
 									position++
 									break
 								}
 							}
 
-							add(ruleEscape, position169)
+							add(ruleEscape, position441)
 						}
-						goto l167
-					l168:
-						position, tokenIndex = position167, tokenIndex167
+						goto l439
+					l440:
+						position, tokenIndex = position439, tokenIndex439
 						{
-							position171, tokenIndex171 := position, tokenIndex
+							position443, tokenIndex443 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l171
+								goto l443
 							}
 							position++
-							goto l166
-						l171:
-							position, tokenIndex = position171, tokenIndex171
+							goto l438
+						l443:
+							position, tokenIndex = position443, tokenIndex443
 						}
 						if !matchDot() {
-							goto l166
+							goto l438
 						}
 					}
-				l167:
-					goto l165
-				l166:
-					position, tokenIndex = position166, tokenIndex166
+				l439:
+					goto l437
+				l438:
+					position, tokenIndex = position438, tokenIndex438
 				}
-				add(ruleLiteral, position164)
+				add(ruleLiteral, position436)
 			}
 			return true
 		},
-		/* 37 PositiveInteger <- <([1-9] [0-9]*)> */
+		/* 90 PositiveInteger <- <([1-9] [0-9]*)> */
 		nil,
-		/* 38 Key <- <((&('-') '-') | (&('+') '+') | (&('.') '.') | (&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 91 Key <- <((&('-') ('-' !'-')) | (&('+') '+') | (&('.') '.') | (&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
 		func() bool {
-			position173, tokenIndex173 := position, tokenIndex
+			position445, tokenIndex445 := position, tokenIndex
 			{
-				position174 := position
+				position446 := position
 				{
 					switch buffer[position] {
 					case '-':
 						if buffer[position] != rune('-') {
-							goto l173
+							goto l445
 						}
 						position++
+						{
+							position450, tokenIndex450 := position, tokenIndex
+							if buffer[position] != rune('-') {
+								goto l450
+							}
+							position++
+							goto l445
+						l450:
+							position, tokenIndex = position450, tokenIndex450
+						}
 						break
 					case '+':
 						if buffer[position] != rune('+') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					case '.':
 						if buffer[position] != rune('.') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					case '_':
 						if buffer[position] != rune('_') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					default:
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l173
+							goto l445
 						}
 						position++
 						break
 					}
 				}
 
-			l175:
+			l447:
 				{
-					position176, tokenIndex176 := position, tokenIndex
+					position448, tokenIndex448 := position, tokenIndex
 					{
 						switch buffer[position] {
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l176
+								goto l448
 							}
 							position++
+							{
+								position452, tokenIndex452 := position, tokenIndex
+								if buffer[position] != rune('-') {
+									goto l452
+								}
+								position++
+								goto l448
+							l452:
+								position, tokenIndex = position452, tokenIndex452
+							}
 							break
 						case '+':
 							if buffer[position] != rune('+') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						case '.':
 							if buffer[position] != rune('.') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						case '_':
 							if buffer[position] != rune('_') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l176
+								goto l448
 							}
 							position++
 							break
 						}
 					}
 
-					goto l175
-				l176:
-					position, tokenIndex = position176, tokenIndex176
+					goto l447
+				l448:
+					position, tokenIndex = position448, tokenIndex448
 				}
-				add(ruleKey, position174)
+				add(ruleKey, position446)
 			}
 			return true
-		l173:
-			position, tokenIndex = position173, tokenIndex173
+		l445:
+			position, tokenIndex = position445, tokenIndex445
 			return false
 		},
-		/* 39 Escape <- <('\\' ((&('v') 'v') | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('a') 'a') | (&('\\') '\\') | (&('"') '"')))> */
+		/* 92 ColumnTable <- <((&('-') ('-' !'-')) | (&('+') '+') | (&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		nil,
+		/* 93 Escape <- <('\\' ((&('v') 'v') | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('a') 'a') | (&('\\') '\\') | (&('"') '"')))> */
 		nil,
-		/* 40 MustSpacing <- <((&('\n') '\n') | (&('\t') '\t') | (&(' ') ' '))+> */
+		/* 94 Comment <- <(<('-' '-' (!'\n' .)*)> Action64)> */
+		func() bool {
+			position455, tokenIndex455 := position, tokenIndex
+			{
+				position456 := position
+				{
+					position457 := position
+					if buffer[position] != rune('-') {
+						goto l455
+					}
+					position++
+					if buffer[position] != rune('-') {
+						goto l455
+					}
+					position++
+				l458:
+					{
+						position459, tokenIndex459 := position, tokenIndex
+						{
+							position460, tokenIndex460 := position, tokenIndex
+							if buffer[position] != rune('\n') {
+								goto l460
+							}
+							position++
+							goto l459
+						l460:
+							position, tokenIndex = position460, tokenIndex460
+						}
+						if !matchDot() {
+							goto l459
+						}
+						goto l458
+					l459:
+						position, tokenIndex = position459, tokenIndex459
+					}
+					add(rulePegText, position457)
+				}
+				{
+					add(ruleAction64, position)
+				}
+				add(ruleComment, position456)
+			}
+			return true
+		l455:
+			position, tokenIndex = position455, tokenIndex455
+			return false
+		},
+		/* 95 MustSpacing <- <((&('-') Comment) | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' '))+> */
 		func() bool {
-			position180, tokenIndex180 := position, tokenIndex
+			position462, tokenIndex462 := position, tokenIndex
 			{
-				position181 := position
+				position463 := position
 				{
 					switch buffer[position] {
+					case '-':
+						if !_rules[ruleComment]() {
+							goto l462
+						}
+						break
 					case '\n':
 						if buffer[position] != rune('\n') {
-							goto l180
+							goto l462
 						}
 						position++
 						break
 					case '\t':
 						if buffer[position] != rune('\t') {
-							goto l180
+							goto l462
 						}
 						position++
 						break
 					default:
 						if buffer[position] != rune(' ') {
-							goto l180
+							goto l462
 						}
 						position++
 						break
 					}
 				}
 
-			l182:
+			l464:
 				{
-					position183, tokenIndex183 := position, tokenIndex
+					position465, tokenIndex465 := position, tokenIndex
 					{
 						switch buffer[position] {
+						case '-':
+							if !_rules[ruleComment]() {
+								goto l465
+							}
+							break
 						case '\n':
 							if buffer[position] != rune('\n') {
-								goto l183
+								goto l465
 							}
 							position++
 							break
 						case '\t':
 							if buffer[position] != rune('\t') {
-								goto l183
+								goto l465
 							}
 							position++
 							break
 						default:
 							if buffer[position] != rune(' ') {
-								goto l183
+								goto l465
 							}
 							position++
 							break
 						}
 					}
 
-					goto l182
-				l183:
-					position, tokenIndex = position183, tokenIndex183
+					goto l464
+				l465:
+					position, tokenIndex = position465, tokenIndex465
 				}
-				add(ruleMustSpacing, position181)
+				add(ruleMustSpacing, position463)
 			}
 			return true
-		l180:
-			position, tokenIndex = position180, tokenIndex180
+		l462:
+			position, tokenIndex = position462, tokenIndex462
 			return false
 		},
-		/* 41 Spacing <- <((&('\n') '\n') | (&('\t') '\t') | (&(' ') ' '))*> */
+		/* 96 Spacing <- <((&('-') Comment) | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' '))*> */
 		func() bool {
 			{
-				position187 := position
-			l188:
+				position469 := position
+			l470:
 				{
-					position189, tokenIndex189 := position, tokenIndex
+					position471, tokenIndex471 := position, tokenIndex
 					{
 						switch buffer[position] {
+						case '-':
+							if !_rules[ruleComment]() {
+								goto l471
+							}
+							break
 						case '\n':
 							if buffer[position] != rune('\n') {
-								goto l189
+								goto l471
 							}
 							position++
 							break
 						case '\t':
 							if buffer[position] != rune('\t') {
-								goto l189
+								goto l471
 							}
 							position++
 							break
 						default:
 							if buffer[position] != rune(' ') {
-								goto l189
+								goto l471
 							}
 							position++
 							break
 						}
 					}
 
-					goto l188
-				l189:
-					position, tokenIndex = position189, tokenIndex189
+					goto l470
+				l471:
+					position, tokenIndex = position471, tokenIndex471
 				}
-				add(ruleSpacing, position187)
+				add(ruleSpacing, position469)
 			}
 			return true
 		},
-		/* 43 Action0 <- <{ p.AddSelect() }> */
+		/* 98 Action0 <- <{ p.AddSelect() }> */
+		nil,
+		/* 99 Action1 <- <{ p.AddJoin() }> */
+		nil,
+		/* 100 Action2 <- <{ p.AddDelete() }> */
+		nil,
+		/* 101 Action3 <- <{ p.EndStatement() }> */
+		nil,
+		nil,
+		/* 103 Action4 <- <{ p.SetTableName(buffer[begin:end]) }> */
+		nil,
+		/* 104 Action5 <- <{ p.SetTableNamePlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 105 Action6 <- <{ p.SetJoinLWW() }> */
+		nil,
+		/* 106 Action7 <- <{ p.AddJoinRow() }> */
+		nil,
+		/* 107 Action8 <- <{ p.SetJoinRowKeyPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 108 Action9 <- <{ p.SetJoinRowKey(buffer[begin:end]) }> */
+		nil,
+		/* 109 Action10 <- <{ p.SetJoinValuePlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 110 Action11 <- <{ p.SetJoinValue(buffer[begin:end]) }> */
+		nil,
+		/* 111 Action12 <- <{ p.SetJoinKey(buffer[begin:end]) }> */
+		nil,
+		/* 112 Action13 <- <{ p.SetJoinKeyPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 113 Action14 <- <{ p.SetJoinCounterIncrement() }> */
+		nil,
+		/* 114 Action15 <- <{ p.SetJoinCounterDecrement() }> */
+		nil,
+		/* 115 Action16 <- <{ p.SetJoinCounterDelta(buffer[begin:end]) }> */
+		nil,
+		/* 116 Action17 <- <{ p.SetJoinCounterDeltaPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 117 Action18 <- <{ p.AddDeleteRow() }> */
+		nil,
+		/* 118 Action19 <- <{ p.SetDeleteRowKeyPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 119 Action20 <- <{ p.SetDeleteRowKey(buffer[begin:end]) }> */
+		nil,
+		/* 120 Action21 <- <{ p.AddDeleteEntry(buffer[begin:end]) }> */
+		nil,
+		/* 121 Action22 <- <{ p.AddDeleteEntryPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 122 Action23 <- <{ p.SetExplain() }> */
+		nil,
+		/* 123 Action24 <- <{ p.AddCountAggregate() }> */
+		nil,
+		/* 124 Action25 <- <{ p.SetAggregateFunction("distinct") }> */
+		nil,
+		/* 125 Action26 <- <{ p.SetAggregateFunction("min") }> */
+		nil,
+		/* 126 Action27 <- <{ p.SetAggregateFunction("max") }> */
+		nil,
+		/* 127 Action28 <- <{ p.AddAggregate(buffer[begin:end]) }> */
+		nil,
+		/* 128 Action29 <- <{ p.AddAggregatePlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 129 Action30 <- <{ p.SetIndexTag(buffer[begin:end]) }> */
+		nil,
+		/* 130 Action31 <- <{ p.SetIndexTagPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 131 Action32 <- <{ p.SetIndexPath(buffer[begin:end]) }> */
+		nil,
+		/* 132 Action33 <- <{ p.SetIndexPathPlaceholder(begin, buffer[begin:end]) }> */
+		nil,
+		/* 133 Action34 <- <{ p.SetTableJoinName(buffer[begin:end]) }> */
+		nil,
+		/* 134 Action35 <- <{ p.AddTableJoinColumn(buffer[begin:end]) }> */
+		nil,
+		/* 135 Action36 <- <{ p.SetTableJoinColumnRowKey() }> */
+		nil,
+		/* 136 Action37 <- <{ p.SetTableJoinColumnEntry(buffer[begin:end]) }> */
+		nil,
+		/* 137 Action38 <- <{ p.SetGroupBy(buffer[begin:end]) }> */
+		nil,
+		/* 138 Action39 <- <{ p.SetGroupByPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 44 Action1 <- <{ p.AddJoin() }> */
+		/* 139 Action40 <- <{ p.SetOrderByRowKey() }> */
 		nil,
+		/* 140 Action41 <- <{ p.SetOrderByKey(buffer[begin:end]) }> */
 		nil,
-		/* 46 Action2 <- <{ p.SetTableName(buffer[begin:end]) }> */
+		/* 141 Action42 <- <{ p.SetOrderByKeyPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 47 Action3 <- <{ p.SetTableNamePlaceholder(begin) }> */
+		/* 142 Action43 <- <{ p.SetOrderByDescending() }> */
 		nil,
-		/* 48 Action4 <- <{ p.AddJoinRow() }> */
+		/* 143 Action44 <- <{ p.AddField(buffer[begin:end]) }> */
 		nil,
-		/* 49 Action5 <- <{ p.SetJoinRowKeyPlaceholder(begin) }> */
+		/* 144 Action45 <- <{ p.AddFieldPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 50 Action6 <- <{ p.SetJoinRowKey(buffer[begin:end]) }> */
+		/* 145 Action46 <- <{ p.SetLimit(buffer[begin:end])}> */
 		nil,
-		/* 51 Action7 <- <{ p.SetJoinValuePlaceholder(begin) }> */
+		/* 146 Action47 <- <{ p.SetLimitPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 52 Action8 <- <{ p.SetJoinValue(buffer[begin:end]) }> */
+		/* 147 Action48 <- <{ p.SetOffset(buffer[begin:end]) }> */
 		nil,
-		/* 53 Action9 <- <{ p.SetJoinKey(buffer[begin:end]) }> */
+		/* 148 Action49 <- <{ p.SetOffsetPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 54 Action10 <- <{ p.SetJoinKeyPlaceholder(begin) }> */
+		/* 149 Action50 <- <{ p.AddCryptoKey(buffer[begin:end]) }> */
 		nil,
-		/* 55 Action11 <- <{ p.SetLimit(buffer[begin:end])}> */
+		/* 150 Action51 <- <{ p.AddCryptoKeyPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 56 Action12 <- <{ p.SetLimitPlaceholder(begin) }> */
+		/* 151 Action52 <- <{ p.PushWhere() }> */
 		nil,
-		/* 57 Action13 <- <{ p.AddCryptoKey(buffer[begin:end]) }> */
+		/* 152 Action53 <- <{ p.PopWhere() }> */
 		nil,
-		/* 58 Action14 <- <{ p.PushWhere() }> */
+		/* 153 Action54 <- <{ p.SetWhereCommand("and") }> */
 		nil,
-		/* 59 Action15 <- <{ p.PopWhere() }> */
+		/* 154 Action55 <- <{ p.SetWhereCommand("or") }> */
 		nil,
-		/* 60 Action16 <- <{ p.SetWhereCommand("and") }> */
+		/* 155 Action56 <- <{ p.SetWhereCommand("not") }> */
 		nil,
-		/* 61 Action17 <- <{ p.SetWhereCommand("or") }> */
+		/* 156 Action57 <- <{ p.InitPredicate() }> */
 		nil,
-		/* 62 Action18 <- <{ p.InitPredicate() }> */
+		/* 157 Action58 <- <{ p.SetPredicateCommand(buffer[begin:end]) }> */
 		nil,
-		/* 63 Action19 <- <{ p.SetPredicateCommand(buffer[begin:end]) }> */
+		/* 158 Action59 <- <{ p.UsePredicateRowKey() }> */
 		nil,
-		/* 64 Action20 <- <{ p.UsePredicateRowKey() }> */
+		/* 159 Action60 <- <{ p.AddPredicateKey(buffer[begin:end]) }> */
 		nil,
-		/* 65 Action21 <- <{ p.AddPredicateKey(buffer[begin:end]) }> */
+		/* 160 Action61 <- <{ p.AddPredicateKeyPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 66 Action22 <- <{ p.AddPredicateKeyPlaceholder(begin) }> */
+		/* 161 Action62 <- <{ p.AddPredicateLiteral(buffer[begin:end])}> */
 		nil,
-		/* 67 Action23 <- <{ p.AddPredicateLiteral(buffer[begin:end])}> */
+		/* 162 Action63 <- <{ p.AddPredicateLiteralPlaceholder(begin, buffer[begin:end]) }> */
 		nil,
-		/* 68 Action24 <- <{ p.AddPredicateLiteralPlaceholder(begin) }> */
+		/* 163 Action64 <- <{ p.AddComment(buffer[begin:end]) }> */
 		nil,
 	}
 	p.rules = _rules
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/johnny-morrice/godless/crdt"
 	"github.com/johnny-morrice/godless/crypto"
@@ -15,12 +17,21 @@ type QueryAST struct {
 	TableKey     *astVariable
 	Select       QuerySelectAST `json:",omitempty"`
 	Join         QueryJoinAST   `json:",omitempty"`
+	Delete       QueryDeleteAST `json:",omitempty"`
 	PublicKeys   []*astVariable
 	Placeholders []*astVariable
+	// Statements holds each statement in the source, in order.
+	Statements []*QueryAST `json:",omitempty"`
+	// Comments found while parsing the statement.  Comments after the last
+	// statement are left in the root AST.
+	Comments []string `json:",omitempty"`
 
 	WhereStack     []*QueryWhereAST
 	lastRowJoinKey *astVariable
 	lastRowJoin    *QueryRowJoinAST
+	lastDecrement  bool
+	lastRowDelete  *QueryRowDeleteAST
+	lastAggregate  string
 }
 
 type placeholderTaker interface {
@@ -32,8 +43,10 @@ type astVariable struct {
 	position      int
 	isPlaceholder bool
 	isKey         bool
-	text          string
-	num           int
+	// name is set for named placeholders, such as ":author".
+	name string
+	text string
+	num  int
 }
 
 type byPosition []*astVariable
@@ -65,31 +78,39 @@ func astInteger(num int) *astVariable {
 	}
 }
 
-func astKeyPlaceholder(position int) *astVariable {
+func astKeyPlaceholder(position int, token string) *astVariable {
 	return &astVariable{
 		taker:         isAstString{},
 		isKey:         true,
 		isPlaceholder: true,
 		position:      position,
+		name:          placeholderName(token),
 	}
 }
 
-func astLiteralPlaceholder(position int) *astVariable {
+func astLiteralPlaceholder(position int, token string) *astVariable {
 	return &astVariable{
 		taker:         isAstString{},
 		isPlaceholder: true,
 		position:      position,
+		name:          placeholderName(token),
 	}
 }
 
-func astIntegerPlaceholder(position int) *astVariable {
+func astIntegerPlaceholder(position int, token string) *astVariable {
 	return &astVariable{
 		taker:         isAstInt{},
 		isPlaceholder: true,
 		position:      position,
+		name:          placeholderName(token),
 	}
 }
 
+// placeholderName is empty for the positional placeholders "?" and "??".
+func placeholderName(token string) string {
+	return strings.TrimLeft(token, ":?")
+}
+
 type isAstString struct {
 }
 
@@ -126,45 +147,101 @@ func (ast *QueryAST) recordPlaceholder(placeholder *astVariable) {
 	ast.Placeholders = append(ast.Placeholders, placeholder)
 }
 
-func (ast *QueryAST) SetTableNamePlaceholder(begin int) {
-	ast.TableKey = astKeyPlaceholder(begin)
+func (ast *QueryAST) SetTableNamePlaceholder(begin int, token string) {
+	ast.TableKey = astKeyPlaceholder(begin, token)
 	ast.recordPlaceholder(ast.TableKey)
 }
 
-func (ast *QueryAST) SetJoinRowKeyPlaceholder(begin int) {
-	ast.lastRowJoin.RowKey = astKeyPlaceholder(begin)
+func (ast *QueryAST) SetJoinRowKeyPlaceholder(begin int, token string) {
+	ast.lastRowJoin.RowKey = astKeyPlaceholder(begin, token)
 	ast.recordPlaceholder(ast.lastRowJoin.RowKey)
 }
 
-func (ast *QueryAST) SetJoinValuePlaceholder(begin int) {
+func (ast *QueryAST) SetJoinValuePlaceholder(begin int, token string) {
 	joinValue := QueryRowJoinValueAST{
 		Key:   ast.lastRowJoinKey,
-		Value: astLiteralPlaceholder(begin),
+		Value: astLiteralPlaceholder(begin, token),
 	}
 	ast.lastRowJoin.Values = append(ast.lastRowJoin.Values, joinValue)
 	ast.recordPlaceholder(joinValue.Value)
 }
 
-func (ast *QueryAST) SetJoinKeyPlaceholder(begin int) {
-	ast.lastRowJoinKey = astKeyPlaceholder(begin)
+func (ast *QueryAST) SetJoinCounterDeltaPlaceholder(begin int, token string) {
+	counter := QueryRowJoinCounterAST{
+		Key:       ast.lastRowJoinKey,
+		Delta:     astIntegerPlaceholder(begin, token),
+		Decrement: ast.lastDecrement,
+	}
+	ast.lastRowJoin.Counters = append(ast.lastRowJoin.Counters, counter)
+	ast.recordPlaceholder(counter.Delta)
+}
+
+func (ast *QueryAST) SetJoinKeyPlaceholder(begin int, token string) {
+	ast.lastRowJoinKey = astKeyPlaceholder(begin, token)
 	ast.recordPlaceholder(ast.lastRowJoinKey)
 }
 
-func (ast *QueryAST) SetLimitPlaceholder(begin int) {
-	ast.Select.Limit = astIntegerPlaceholder(begin)
+func (ast *QueryAST) SetDeleteRowKeyPlaceholder(begin int, token string) {
+	ast.lastRowDelete.RowKey = astKeyPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.lastRowDelete.RowKey)
+}
+
+func (ast *QueryAST) AddDeleteEntryPlaceholder(begin int, token string) {
+	entry := astKeyPlaceholder(begin, token)
+	ast.lastRowDelete.Entries = append(ast.lastRowDelete.Entries, entry)
+	ast.recordPlaceholder(entry)
+}
+
+func (ast *QueryAST) AddFieldPlaceholder(begin int, token string) {
+	field := astKeyPlaceholder(begin, token)
+	ast.Select.Fields = append(ast.Select.Fields, field)
+	ast.recordPlaceholder(field)
+}
+
+func (ast *QueryAST) SetOffsetPlaceholder(begin int, token string) {
+	ast.Select.Offset = astIntegerPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.Select.Offset)
+}
+
+func (ast *QueryAST) SetOrderByKeyPlaceholder(begin int, token string) {
+	ast.Select.OrderBy = astKeyPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.Select.OrderBy)
+}
+
+func (ast *QueryAST) AddAggregatePlaceholder(begin int, token string) {
+	aggregate := &QueryAggregateAST{
+		Function: ast.lastAggregate,
+		Key:      astKeyPlaceholder(begin, token),
+	}
+	ast.Select.Aggregates = append(ast.Select.Aggregates, aggregate)
+	ast.recordPlaceholder(aggregate.Key)
+}
+
+func (ast *QueryAST) SetIndexPathPlaceholder(begin int, token string) {
+	ast.Select.IndexPath = astLiteralPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.Select.IndexPath)
+}
+
+func (ast *QueryAST) SetGroupByPlaceholder(begin int, token string) {
+	ast.Select.GroupBy = astKeyPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.Select.GroupBy)
+}
+
+func (ast *QueryAST) SetLimitPlaceholder(begin int, token string) {
+	ast.Select.Limit = astIntegerPlaceholder(begin, token)
 	ast.recordPlaceholder(ast.Select.Limit)
 }
 
-func (ast *QueryAST) AddPredicateKeyPlaceholder(begin int) {
+func (ast *QueryAST) AddPredicateKeyPlaceholder(begin int, token string) {
 	where := ast.peekWhere()
-	variable := astKeyPlaceholder(begin)
+	variable := astKeyPlaceholder(begin, token)
 	where.Predicate.Values = append(where.Predicate.Values, variable)
 	ast.recordPlaceholder(variable)
 }
 
-func (ast *QueryAST) AddPredicateLiteralPlaceholder(begin int) {
+func (ast *QueryAST) AddPredicateLiteralPlaceholder(begin int, token string) {
 	where := ast.peekWhere()
-	variable := astLiteralPlaceholder(begin)
+	variable := astLiteralPlaceholder(begin, token)
 	where.Predicate.Values = append(where.Predicate.Values, variable)
 	ast.recordPlaceholder(variable)
 }
@@ -173,10 +250,35 @@ func (ast *QueryAST) AddCryptoKey(publicKey string) {
 	ast.PublicKeys = append(ast.PublicKeys, astLiteral(publicKey))
 }
 
+func (ast *QueryAST) AddCryptoKeyPlaceholder(begin int, token string) {
+	publicKey := astLiteralPlaceholder(begin, token)
+	ast.PublicKeys = append(ast.PublicKeys, publicKey)
+	ast.recordPlaceholder(publicKey)
+}
+
+// EndStatement moves the statement parsed so far into Statements, so that
+// the next statement starts from an empty AST.
+func (ast *QueryAST) EndStatement() {
+	statement := *ast
+	statement.Statements = nil
+
+	*ast = QueryAST{
+		Statements: append(ast.Statements, &statement),
+	}
+}
+
+func (ast *QueryAST) AddComment(comment string) {
+	ast.Comments = append(ast.Comments, strings.TrimRightFunc(comment, unicode.IsSpace))
+}
+
 func (ast *QueryAST) AddJoin() {
 	ast.Command = "join"
 }
 
+func (ast *QueryAST) SetJoinLWW() {
+	ast.Join.LWW = true
+}
+
 func (ast *QueryAST) AddJoinRow() {
 	row := &QueryRowJoinAST{}
 	ast.Join.Rows = append(ast.Join.Rows, row)
@@ -187,6 +289,23 @@ func (ast *QueryAST) SetJoinRowKey(key string) {
 	ast.lastRowJoin.RowKey = astKey(key)
 }
 
+func (ast *QueryAST) SetJoinCounterIncrement() {
+	ast.lastDecrement = false
+}
+
+func (ast *QueryAST) SetJoinCounterDecrement() {
+	ast.lastDecrement = true
+}
+
+func (ast *QueryAST) SetJoinCounterDelta(delta string) {
+	counter := QueryRowJoinCounterAST{
+		Key:       ast.lastRowJoinKey,
+		Delta:     astLiteral(delta),
+		Decrement: ast.lastDecrement,
+	}
+	ast.lastRowJoin.Counters = append(ast.lastRowJoin.Counters, counter)
+}
+
 func (ast *QueryAST) SetJoinKey(key string) {
 	ast.lastRowJoinKey = astKey(key)
 }
@@ -199,6 +318,24 @@ func (ast *QueryAST) SetJoinValue(value string) {
 	ast.lastRowJoin.Values = append(ast.lastRowJoin.Values, joinValue)
 }
 
+func (ast *QueryAST) AddDelete() {
+	ast.Command = "delete"
+}
+
+func (ast *QueryAST) AddDeleteRow() {
+	row := &QueryRowDeleteAST{}
+	ast.Delete.Rows = append(ast.Delete.Rows, row)
+	ast.lastRowDelete = row
+}
+
+func (ast *QueryAST) SetDeleteRowKey(key string) {
+	ast.lastRowDelete.RowKey = astKey(key)
+}
+
+func (ast *QueryAST) AddDeleteEntry(entry string) {
+	ast.lastRowDelete.Entries = append(ast.lastRowDelete.Entries, astKey(entry))
+}
+
 func (ast *QueryAST) PushWhere() {
 	where := &QueryWhereAST{}
 
@@ -265,25 +402,161 @@ func (ast *QueryAST) AddSelect() {
 	ast.Command = "select"
 }
 
+func (ast *QueryAST) SetExplain() {
+	ast.Select.Explain = true
+}
+
 func (ast *QueryAST) SetTableName(key string) {
 	ast.TableKey = astKey(key)
 }
 
+func (ast *QueryAST) AddField(field string) {
+	ast.Select.Fields = append(ast.Select.Fields, astKey(field))
+}
+
 func (ast *QueryAST) SetLimit(limit string) {
 	ast.Select.Limit = astLiteral(limit)
 }
 
+func (ast *QueryAST) AddCountAggregate() {
+	aggregate := &QueryAggregateAST{Function: "count"}
+	ast.Select.Aggregates = append(ast.Select.Aggregates, aggregate)
+}
+
+func (ast *QueryAST) SetAggregateFunction(function string) {
+	ast.lastAggregate = function
+}
+
+func (ast *QueryAST) AddAggregate(key string) {
+	aggregate := &QueryAggregateAST{
+		Function: ast.lastAggregate,
+		Key:      astKey(key),
+	}
+	ast.Select.Aggregates = append(ast.Select.Aggregates, aggregate)
+}
+
+func (ast *QueryAST) SetTableJoinName(table string) {
+	ast.Select.TableJoin = &QueryTableJoinAST{TableKey: table}
+}
+
+func (ast *QueryAST) AddTableJoinColumn(table string) {
+	column := &QueryColumnAST{TableKey: table}
+	ast.Select.TableJoin.Columns = append(ast.Select.TableJoin.Columns, column)
+}
+
+func (ast *QueryAST) SetTableJoinColumnRowKey() {
+	column := ast.Select.TableJoin.lastColumn()
+	column.RowKey = true
+}
+
+func (ast *QueryAST) SetTableJoinColumnEntry(entry string) {
+	column := ast.Select.TableJoin.lastColumn()
+	column.Entry = entry
+}
+
+func (ast *QueryAST) SetIndexTag(tag string) {
+	ast.Select.IndexTag = astLiteral(tag)
+}
+
+func (ast *QueryAST) SetIndexTagPlaceholder(begin int, token string) {
+	ast.Select.IndexTag = astLiteralPlaceholder(begin, token)
+	ast.recordPlaceholder(ast.Select.IndexTag)
+}
+
+func (ast *QueryAST) SetIndexPath(path string) {
+	ast.Select.IndexPath = astLiteral(path)
+}
+
+func (ast *QueryAST) SetGroupBy(key string) {
+	ast.Select.GroupBy = astKey(key)
+}
+
+func (ast *QueryAST) SetOffset(offset string) {
+	ast.Select.Offset = astLiteral(offset)
+}
+
+func (ast *QueryAST) SetOrderByKey(key string) {
+	ast.Select.OrderBy = astKey(key)
+}
+
+func (ast *QueryAST) SetOrderByRowKey() {
+	ast.Select.OrderByRowKey = true
+}
+
+func (ast *QueryAST) SetOrderByDescending() {
+	ast.Select.Descending = true
+}
+
+// CompileContext holds the values for the query placeholders.  Positional
+// placeholders take Variables in order, named placeholders take
+// NamedVariables by name.
 type CompileContext struct {
-	Variables []interface{}
+	Variables      []interface{}
+	NamedVariables map[string]interface{}
 }
 
 func (ast *QueryAST) insertPlaceholderValues(context CompileContext) error {
+	sort.Sort(byPosition(ast.Placeholders))
+
+	named := 0
+	for _, placeholder := range ast.Placeholders {
+		if placeholder.name != "" {
+			named++
+		}
+	}
+
+	if named == 0 {
+		return ast.insertPositionalValues(context)
+	}
+
+	if named != len(ast.Placeholders) {
+		return fmt.Errorf("Query mixes named and positional placeholders")
+	}
+
+	return ast.insertNamedValues(context)
+}
+
+func (ast *QueryAST) insertNamedValues(context CompileContext) error {
+	if len(context.Variables) > 0 {
+		return fmt.Errorf("Query has named placeholders but received %d positional variables", len(context.Variables))
+	}
+
+	used := map[string]bool{}
+
+	for _, placeholder := range ast.Placeholders {
+		val, present := context.NamedVariables[placeholder.name]
+
+		if !present {
+			return fmt.Errorf("No value for Query variable ':%s'", placeholder.name)
+		}
+
+		err := placeholder.takeValue(val)
+
+		if err != nil {
+			return errors.Wrapf(err, "Error in Query variable ':%s' (%v)", placeholder.name, val)
+		}
+
+		used[placeholder.name] = true
+	}
+
+	for name := range context.NamedVariables {
+		if !used[name] {
+			return fmt.Errorf("Unused Query variable ':%s'", name)
+		}
+	}
+
+	return nil
+}
+
+func (ast *QueryAST) insertPositionalValues(context CompileContext) error {
+	if len(context.NamedVariables) > 0 {
+		return fmt.Errorf("Query has no named placeholders but received %d named variables", len(context.NamedVariables))
+	}
+
 	if len(ast.Placeholders) != len(context.Variables) {
 		return fmt.Errorf("Expected %d variables but received %d", len(ast.Placeholders), len(context.Variables))
 	}
 
-	sort.Sort(byPosition(ast.Placeholders))
-
 	for i, placeholder := range ast.Placeholders {
 		val := context.Variables[i]
 		err := placeholder.takeValue(val)
@@ -324,6 +597,15 @@ func (ast *QueryAST) Compile(context CompileContext) (*Query, error) {
 
 		query.OpCode = JOIN
 		query.Join = qjoin
+	case "delete":
+		qdelete, err := ast.Delete.Compile()
+
+		if err != nil {
+			return nil, errors.Wrap(err, "BUG delete compile failed")
+		}
+
+		query.OpCode = DELETE
+		query.Delete = qdelete
 	default:
 		return nil, fmt.Errorf("BUG no command matching '%v'", ast.Command)
 	}
@@ -341,6 +623,7 @@ func (ast *QueryAST) Compile(context CompileContext) (*Query, error) {
 
 type QueryJoinAST struct {
 	Rows []*QueryRowJoinAST `json:",omitempty"`
+	LWW  bool               `json:",omitempty"`
 }
 
 func (ast *QueryJoinAST) Compile() (QueryJoin, error) {
@@ -364,19 +647,64 @@ func (ast *QueryJoinAST) Compile() (QueryJoin, error) {
 			rowJoin.Entries[entry] = point
 		}
 
+		for _, counter := range r.Counters {
+			delta, err := counter.compileDelta()
+
+			if err != nil {
+				return QueryJoin{}, errors.Wrap(err, "Error compiling join")
+			}
+
+			if rowJoin.Counters == nil {
+				rowJoin.Counters = map[crdt.EntryName]int64{}
+			}
+
+			entry := crdt.EntryName(counter.Key.text)
+			rowJoin.Counters[entry] += delta
+		}
+
 		rows[i] = rowJoin
 	}
 
 	qjoin := QueryJoin{
 		Rows: rows,
+		LWW:  ast.LWW,
 	}
 
 	return qjoin, nil
 }
 
 type QueryRowJoinAST struct {
-	RowKey *astVariable
-	Values []QueryRowJoinValueAST `json:",omitempty"`
+	RowKey   *astVariable
+	Values   []QueryRowJoinValueAST   `json:",omitempty"`
+	Counters []QueryRowJoinCounterAST `json:",omitempty"`
+}
+
+type QueryRowJoinCounterAST struct {
+	Key       *astVariable
+	Delta     *astVariable
+	Decrement bool
+}
+
+func (ast QueryRowJoinCounterAST) compileDelta() (int64, error) {
+	var delta int64
+
+	if ast.Delta.isPlaceholder {
+		delta = int64(ast.Delta.num)
+	} else {
+		parsed, err := strconv.ParseInt(ast.Delta.text, __BASE_10, __BITS_64)
+
+		if err != nil {
+			return 0, errors.Wrap(err, "Error compiling counter")
+		}
+
+		delta = parsed
+	}
+
+	if ast.Decrement {
+		delta = -delta
+	}
+
+	return delta, nil
 }
 
 type QueryRowJoinValueAST struct {
@@ -384,9 +712,157 @@ type QueryRowJoinValueAST struct {
 	Value *astVariable
 }
 
+type QueryDeleteAST struct {
+	Rows []*QueryRowDeleteAST `json:",omitempty"`
+}
+
+func (ast *QueryDeleteAST) Compile() (QueryDelete, error) {
+	rows := make([]QueryRowDelete, len(ast.Rows))
+
+	for i, r := range ast.Rows {
+		rowKey, err := unquote(r.RowKey.text)
+
+		if err != nil {
+			return QueryDelete{}, errors.Wrap(err, "Error compiling delete")
+		}
+
+		rowDelete := QueryRowDelete{
+			RowKey: crdt.RowName(rowKey),
+		}
+
+		for _, e := range r.Entries {
+			entry, err := unquote(e.text)
+
+			if err != nil {
+				return QueryDelete{}, errors.Wrap(err, "Error compiling delete")
+			}
+
+			rowDelete.Entries = append(rowDelete.Entries, crdt.EntryName(entry))
+		}
+
+		rows[i] = rowDelete
+	}
+
+	qdelete := QueryDelete{
+		Rows: rows,
+	}
+
+	return qdelete, nil
+}
+
+type QueryRowDeleteAST struct {
+	RowKey  *astVariable
+	Entries []*astVariable `json:",omitempty"`
+}
+
 type QuerySelectAST struct {
-	Where *QueryWhereAST `json:",omitempty"`
-	Limit *astVariable
+	Where         *QueryWhereAST `json:",omitempty"`
+	Limit         *astVariable
+	Offset        *astVariable
+	Fields        []*astVariable `json:",omitempty"`
+	OrderBy       *astVariable
+	OrderByRowKey bool
+	Descending    bool
+	Aggregates    []*QueryAggregateAST `json:",omitempty"`
+	GroupBy       *astVariable
+	IndexPath     *astVariable
+	IndexTag      *astVariable
+	TableJoin     *QueryTableJoinAST `json:",omitempty"`
+	Explain       bool
+}
+
+type QueryTableJoinAST struct {
+	TableKey string
+	Columns  []*QueryColumnAST
+}
+
+func (ast *QueryTableJoinAST) lastColumn() *QueryColumnAST {
+	return ast.Columns[len(ast.Columns)-1]
+}
+
+func (ast *QueryTableJoinAST) Compile() (QueryTableJoin, error) {
+	if len(ast.Columns) != 2 {
+		return QueryTableJoin{}, fmt.Errorf("BUG expected 2 join columns but found %d", len(ast.Columns))
+	}
+
+	left, err := ast.Columns[0].Compile()
+
+	if err != nil {
+		return QueryTableJoin{}, err
+	}
+
+	right, err := ast.Columns[1].Compile()
+
+	if err != nil {
+		return QueryTableJoin{}, err
+	}
+
+	join := QueryTableJoin{
+		TableKey: crdt.TableName(ast.TableKey),
+		Left:     left,
+		Right:    right,
+	}
+
+	return join, nil
+}
+
+type QueryColumnAST struct {
+	TableKey string
+	Entry    string `json:",omitempty"`
+	RowKey   bool   `json:",omitempty"`
+}
+
+func (ast *QueryColumnAST) Compile() (QueryColumn, error) {
+	column := QueryColumn{
+		TableKey: crdt.TableName(ast.TableKey),
+		RowKey:   ast.RowKey,
+	}
+
+	if !ast.RowKey {
+		entry, err := unquote(ast.Entry)
+
+		if err != nil {
+			return QueryColumn{}, errors.Wrap(err, "Error compiling join column")
+		}
+
+		column.Entry = crdt.EntryName(entry)
+	}
+
+	return column, nil
+}
+
+type QueryAggregateAST struct {
+	Function string
+	Key      *astVariable
+}
+
+func (ast *QueryAggregateAST) Compile() (QueryAggregate, error) {
+	aggregate := QueryAggregate{}
+
+	switch ast.Function {
+	case "count":
+		aggregate.Function = COUNT
+	case "distinct":
+		aggregate.Function = DISTINCT
+	case "min":
+		aggregate.Function = MIN
+	case "max":
+		aggregate.Function = MAX
+	default:
+		return QueryAggregate{}, fmt.Errorf("BUG no aggregate matching '%v'", ast.Function)
+	}
+
+	if ast.Key != nil {
+		key, err := unquote(ast.Key.text)
+
+		if err != nil {
+			return QueryAggregate{}, errors.Wrap(err, "Error compiling aggregate")
+		}
+
+		aggregate.Key = crdt.EntryName(key)
+	}
+
+	return aggregate, nil
 }
 
 func (ast *QuerySelectAST) Compile() (QuerySelect, error) {
@@ -399,6 +875,60 @@ func (ast *QuerySelectAST) Compile() (QuerySelect, error) {
 		}
 	}
 
+	if ast.Offset != nil {
+		offset, err := compileUint32(ast.Offset)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "BUG convert offset failed")
+		}
+
+		qselect.Offset = offset
+	}
+
+	if ast.OrderBy != nil {
+		key, err := unquote(ast.OrderBy.text)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "Error compiling order by")
+		}
+
+		qselect.OrderBy.Key = crdt.EntryName(key)
+	}
+
+	qselect.OrderBy.RowKey = ast.OrderByRowKey
+	qselect.OrderBy.Descending = ast.Descending
+	qselect.Explain = ast.Explain
+
+	for _, astAggregate := range ast.Aggregates {
+		aggregate, err := astAggregate.Compile()
+
+		if err != nil {
+			return QuerySelect{}, err
+		}
+
+		qselect.Aggregates = append(qselect.Aggregates, aggregate)
+	}
+
+	if ast.TableJoin != nil {
+		join, err := ast.TableJoin.Compile()
+
+		if err != nil {
+			return QuerySelect{}, err
+		}
+
+		qselect.TableJoin = join
+	}
+
+	if ast.GroupBy != nil {
+		groupBy, err := unquote(ast.GroupBy.text)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "Error compiling group by")
+		}
+
+		qselect.GroupBy = crdt.EntryName(groupBy)
+	}
+
 	if ast.Where != nil {
 		where, err := ast.Where.Compile()
 
@@ -409,6 +939,36 @@ func (ast *QuerySelectAST) Compile() (QuerySelect, error) {
 		qselect.Where = where
 	}
 
+	if ast.IndexPath != nil {
+		path, err := unquote(ast.IndexPath.text)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "Error compiling index path")
+		}
+
+		qselect.IndexPath = crdt.IPFSPath(path)
+	}
+
+	if ast.IndexTag != nil {
+		tag, err := unquote(ast.IndexTag.text)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "Error compiling index tag")
+		}
+
+		qselect.IndexTag = tag
+	}
+
+	for _, f := range ast.Fields {
+		field, err := unquote(f.text)
+
+		if err != nil {
+			return QuerySelect{}, errors.Wrap(err, "Error compiling fields")
+		}
+
+		qselect.Fields = append(qselect.Fields, crdt.EntryName(field))
+	}
+
 	return qselect, nil
 }
 
@@ -428,6 +988,20 @@ func (ast *QuerySelectAST) compileLimit(qselect *QuerySelect) error {
 	return nil
 }
 
+func compileUint32(astVar *astVariable) (uint32, error) {
+	if astVar.text == "" {
+		return uint32(astVar.num), nil
+	}
+
+	num, err := strconv.ParseUint(astVar.text, __BASE_10, __BITS_32)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(num), nil
+}
+
 type QueryWhereAST struct {
 	Command   string
 	Clauses   []*QueryWhereAST   `json:",omitempty"`
@@ -455,6 +1029,8 @@ func (ast *QueryWhereAST) Configure(where *QueryWhere) error {
 		where.OpCode = AND
 	} else if ast.Command == "or" {
 		where.OpCode = OR
+	} else if ast.Command == "not" {
+		where.OpCode = NOT
 	} else if ast.Command == "predicate" && ast.Predicate != nil {
 		predicate, err := ast.Predicate.Compile()
 
@@ -608,3 +1184,4 @@ func makeEntryNames(mess []string) []crdt.EntryName {
 
 const __BASE_10 = 10
 const __BITS_32 = 64
+const __BITS_64 = 64
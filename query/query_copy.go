@@ -0,0 +1,320 @@
+package query
+
+import (
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/crypto"
+)
+
+// Copy is a deep copy of the query, including its AST, so the copy can be
+// changed or compiled again without affecting the original.  The parser is
+// shared, as it is not changed after parsing.
+func (query *Query) Copy() *Query {
+	dup := *query
+
+	if query.AST != nil {
+		dup.AST = query.AST.copy()
+	}
+
+	if query.PublicKeys != nil {
+		dup.PublicKeys = make([]crypto.PublicKeyHash, len(query.PublicKeys))
+		copy(dup.PublicKeys, query.PublicKeys)
+	}
+
+	dup.Join = query.Join.copy()
+	dup.Select = query.Select.copy()
+	dup.Delete = query.Delete.copy()
+
+	return &dup
+}
+
+func (join QueryJoin) copy() QueryJoin {
+	if join.Rows == nil {
+		return join
+	}
+
+	rows := make([]QueryRowJoin, len(join.Rows))
+
+	for i, row := range join.Rows {
+		rows[i] = row.copy()
+	}
+
+	join.Rows = rows
+	return join
+}
+
+func (join QueryRowJoin) copy() QueryRowJoin {
+	if join.Entries != nil {
+		entries := make(map[crdt.EntryName]crdt.PointText, len(join.Entries))
+
+		for entry, point := range join.Entries {
+			entries[entry] = point
+		}
+
+		join.Entries = entries
+	}
+
+	if join.Counters != nil {
+		counters := make(map[crdt.EntryName]int64, len(join.Counters))
+
+		for entry, delta := range join.Counters {
+			counters[entry] = delta
+		}
+
+		join.Counters = counters
+	}
+
+	return join
+}
+
+func (querySelect QuerySelect) copy() QuerySelect {
+	querySelect.Where = querySelect.Where.copy()
+
+	if querySelect.Fields != nil {
+		fields := make([]crdt.EntryName, len(querySelect.Fields))
+		copy(fields, querySelect.Fields)
+		querySelect.Fields = fields
+	}
+
+	if querySelect.Aggregates != nil {
+		aggregates := make([]QueryAggregate, len(querySelect.Aggregates))
+		copy(aggregates, querySelect.Aggregates)
+		querySelect.Aggregates = aggregates
+	}
+
+	return querySelect
+}
+
+func (where QueryWhere) copy() QueryWhere {
+	if where.Clauses != nil {
+		clauses := make([]QueryWhere, len(where.Clauses))
+
+		for i, clause := range where.Clauses {
+			clauses[i] = clause.copy()
+		}
+
+		where.Clauses = clauses
+	}
+
+	if where.Predicate.Values != nil {
+		values := make([]PredicateValue, len(where.Predicate.Values))
+		copy(values, where.Predicate.Values)
+		where.Predicate.Values = values
+	}
+
+	return where
+}
+
+func (qdelete QueryDelete) copy() QueryDelete {
+	if qdelete.Rows == nil {
+		return qdelete
+	}
+
+	rows := make([]QueryRowDelete, len(qdelete.Rows))
+
+	for i, row := range qdelete.Rows {
+		if row.Entries != nil {
+			entries := make([]crdt.EntryName, len(row.Entries))
+			copy(entries, row.Entries)
+			row.Entries = entries
+		}
+
+		rows[i] = row
+	}
+
+	qdelete.Rows = rows
+	return qdelete
+}
+
+// copy is a deep copy of the AST.  Placeholders are compiled by changing
+// their variables in place, so each copied variable is shared wherever the
+// original was, and Placeholders still refer to the variables in the copy.
+// The parse state is not copied.
+func (ast *QueryAST) copy() *QueryAST {
+	copier := &astCopier{vars: map[*astVariable]*astVariable{}}
+	return copier.copyAST(ast)
+}
+
+type astCopier struct {
+	vars map[*astVariable]*astVariable
+}
+
+func (copier *astCopier) copyAST(ast *QueryAST) *QueryAST {
+	if ast == nil {
+		return nil
+	}
+
+	dup := &QueryAST{
+		Command:      ast.Command,
+		TableKey:     copier.copyVar(ast.TableKey),
+		Select:       copier.copySelect(ast.Select),
+		Join:         copier.copyJoin(ast.Join),
+		Delete:       copier.copyDelete(ast.Delete),
+		PublicKeys:   copier.copyVars(ast.PublicKeys),
+		Placeholders: copier.copyVars(ast.Placeholders),
+	}
+
+	if ast.Comments != nil {
+		dup.Comments = make([]string, len(ast.Comments))
+		copy(dup.Comments, ast.Comments)
+	}
+
+	if ast.Statements != nil {
+		dup.Statements = make([]*QueryAST, len(ast.Statements))
+
+		for i, statement := range ast.Statements {
+			dup.Statements[i] = copier.copyAST(statement)
+		}
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copySelect(ast QuerySelectAST) QuerySelectAST {
+	dup := ast
+	dup.Where = copier.copyWhere(ast.Where)
+	dup.Limit = copier.copyVar(ast.Limit)
+	dup.Offset = copier.copyVar(ast.Offset)
+	dup.Fields = copier.copyVars(ast.Fields)
+	dup.OrderBy = copier.copyVar(ast.OrderBy)
+	dup.GroupBy = copier.copyVar(ast.GroupBy)
+	dup.IndexPath = copier.copyVar(ast.IndexPath)
+	dup.IndexTag = copier.copyVar(ast.IndexTag)
+
+	if ast.Aggregates != nil {
+		dup.Aggregates = make([]*QueryAggregateAST, len(ast.Aggregates))
+
+		for i, aggregate := range ast.Aggregates {
+			dup.Aggregates[i] = &QueryAggregateAST{
+				Function: aggregate.Function,
+				Key:      copier.copyVar(aggregate.Key),
+			}
+		}
+	}
+
+	if ast.TableJoin != nil {
+		tableJoin := &QueryTableJoinAST{TableKey: ast.TableJoin.TableKey}
+
+		for _, column := range ast.TableJoin.Columns {
+			dupColumn := *column
+			tableJoin.Columns = append(tableJoin.Columns, &dupColumn)
+		}
+
+		dup.TableJoin = tableJoin
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copyWhere(ast *QueryWhereAST) *QueryWhereAST {
+	if ast == nil {
+		return nil
+	}
+
+	dup := &QueryWhereAST{Command: ast.Command}
+
+	if ast.Clauses != nil {
+		dup.Clauses = make([]*QueryWhereAST, len(ast.Clauses))
+
+		for i, clause := range ast.Clauses {
+			dup.Clauses[i] = copier.copyWhere(clause)
+		}
+	}
+
+	if ast.Predicate != nil {
+		dup.Predicate = &QueryPredicateAST{
+			Command:       ast.Predicate.Command,
+			Values:        copier.copyVars(ast.Predicate.Values),
+			IncludeRowKey: ast.Predicate.IncludeRowKey,
+		}
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copyJoin(ast QueryJoinAST) QueryJoinAST {
+	dup := ast
+
+	if ast.Rows == nil {
+		return dup
+	}
+
+	dup.Rows = make([]*QueryRowJoinAST, len(ast.Rows))
+
+	for i, row := range ast.Rows {
+		dupRow := &QueryRowJoinAST{RowKey: copier.copyVar(row.RowKey)}
+
+		if row.Values != nil {
+			dupRow.Values = make([]QueryRowJoinValueAST, len(row.Values))
+
+			for j, value := range row.Values {
+				dupRow.Values[j] = QueryRowJoinValueAST{
+					Key:   copier.copyVar(value.Key),
+					Value: copier.copyVar(value.Value),
+				}
+			}
+		}
+
+		if row.Counters != nil {
+			dupRow.Counters = make([]QueryRowJoinCounterAST, len(row.Counters))
+
+			for j, counter := range row.Counters {
+				dupRow.Counters[j] = QueryRowJoinCounterAST{
+					Key:       copier.copyVar(counter.Key),
+					Delta:     copier.copyVar(counter.Delta),
+					Decrement: counter.Decrement,
+				}
+			}
+		}
+
+		dup.Rows[i] = dupRow
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copyDelete(ast QueryDeleteAST) QueryDeleteAST {
+	if ast.Rows == nil {
+		return ast
+	}
+
+	dup := QueryDeleteAST{Rows: make([]*QueryRowDeleteAST, len(ast.Rows))}
+
+	for i, row := range ast.Rows {
+		dup.Rows[i] = &QueryRowDeleteAST{
+			RowKey:  copier.copyVar(row.RowKey),
+			Entries: copier.copyVars(row.Entries),
+		}
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copyVars(vars []*astVariable) []*astVariable {
+	if vars == nil {
+		return nil
+	}
+
+	dup := make([]*astVariable, len(vars))
+
+	for i, astVar := range vars {
+		dup[i] = copier.copyVar(astVar)
+	}
+
+	return dup
+}
+
+func (copier *astCopier) copyVar(astVar *astVariable) *astVariable {
+	if astVar == nil {
+		return nil
+	}
+
+	if dup, present := copier.vars[astVar]; present {
+		return dup
+	}
+
+	dup := &astVariable{}
+	*dup = *astVar
+	copier.vars[astVar] = dup
+
+	return dup
+}
@@ -22,6 +22,9 @@ type queryMessageVisitor interface {
 	VisitJoin(*proto.QueryJoinMessage)
 	LeaveJoin(*proto.QueryJoinMessage)
 	VisitRowJoin(int, *proto.QueryRowJoinMessage)
+	VisitDelete(*proto.QueryDeleteMessage)
+	LeaveDelete(*proto.QueryDeleteMessage)
+	VisitRowDelete(int, *proto.QueryRowDeleteMessage)
 	VisitSelect(*proto.QuerySelectMessage)
 	LeaveSelect(*proto.QuerySelectMessage)
 }
@@ -113,19 +116,74 @@ func MakeQueryMessage(query *Query) *proto.QueryMessage {
 		Table:     string(query.TableKey),
 		Join:      MakeQueryJoinMessage(query.Join),
 		Select:    MakeQuerySelectMessage(query.Select),
+		Delete:    MakeQueryDeleteMessage(query.Delete),
 		KeyHashes: hashTexts,
 	}
 }
 
 func MakeQuerySelectMessage(querySelect QuerySelect) *proto.QuerySelectMessage {
 	message := &proto.QuerySelectMessage{
-		Limit: querySelect.Limit,
-		Where: MakeQueryWhereMessage(querySelect.Where),
+		Limit:   querySelect.Limit,
+		Offset:  querySelect.Offset,
+		Explain: querySelect.Explain,
+		Index:   string(querySelect.IndexPath),
+		Tag:     querySelect.IndexTag,
+		GroupBy: string(querySelect.GroupBy),
+		Where:   MakeQueryWhereMessage(querySelect.Where),
+		Fields:  make([]string, len(querySelect.Fields)),
+	}
+
+	for i, field := range querySelect.Fields {
+		message.Fields[i] = string(field)
+	}
+
+	for _, aggregate := range querySelect.Aggregates {
+		aggregateMessage := &proto.QueryAggregateMessage{
+			Function: uint32(aggregate.Function),
+			Key:      string(aggregate.Key),
+		}
+		message.Aggregates = append(message.Aggregates, aggregateMessage)
+	}
+
+	if !querySelect.TableJoin.IsEmpty() {
+		message.TableJoin = &proto.QueryTableJoinMessage{
+			Table: string(querySelect.TableJoin.TableKey),
+			Left:  makeQueryColumnMessage(querySelect.TableJoin.Left),
+			Right: makeQueryColumnMessage(querySelect.TableJoin.Right),
+		}
+	}
+
+	if !querySelect.OrderBy.IsEmpty() {
+		message.OrderBy = &proto.QueryOrderByMessage{
+			Key:        string(querySelect.OrderBy.Key),
+			RowKey:     querySelect.OrderBy.RowKey,
+			Descending: querySelect.OrderBy.Descending,
+		}
 	}
 
 	return message
 }
 
+func makeQueryColumnMessage(column QueryColumn) *proto.QueryColumnMessage {
+	return &proto.QueryColumnMessage{
+		Table:  string(column.TableKey),
+		Entry:  string(column.Entry),
+		RowKey: column.RowKey,
+	}
+}
+
+func readQueryColumnMessage(message *proto.QueryColumnMessage) QueryColumn {
+	if message == nil {
+		return QueryColumn{}
+	}
+
+	return QueryColumn{
+		TableKey: crdt.TableName(message.Table),
+		Entry:    crdt.EntryName(message.Entry),
+		RowKey:   message.RowKey,
+	}
+}
+
 func MakeQueryWhereMessage(queryWhere QueryWhere) *proto.QueryWhereMessage {
 	builder := &whereMessageBuilder{}
 	builder.stack = makeWhereBuilderFrameStack()
@@ -177,6 +235,7 @@ func MakeQueryPredicateMessage(predicate QueryPredicate) *proto.QueryPredicateMe
 
 func MakeQueryJoinMessage(join QueryJoin) *proto.QueryJoinMessage {
 	message := &proto.QueryJoinMessage{
+		Lww:  join.LWW,
 		Rows: make([]*proto.QueryRowJoinMessage, len(join.Rows)),
 	}
 
@@ -202,6 +261,39 @@ func MakeQueryRowJoinMessage(row QueryRowJoin) *proto.QueryRowJoinMessage {
 		message.Entries = append(message.Entries, rowJoin)
 	}
 
+	for e, delta := range row.Counters {
+		counter := &proto.QueryRowJoinCounterMessage{
+			Entry: string(e),
+			Delta: delta,
+		}
+		message.Counters = append(message.Counters, counter)
+	}
+
+	return message
+}
+
+func MakeQueryDeleteMessage(qdelete QueryDelete) *proto.QueryDeleteMessage {
+	message := &proto.QueryDeleteMessage{
+		Rows: make([]*proto.QueryRowDeleteMessage, len(qdelete.Rows)),
+	}
+
+	for i, r := range qdelete.Rows {
+		message.Rows[i] = MakeQueryRowDeleteMessage(r)
+	}
+
+	return message
+}
+
+func MakeQueryRowDeleteMessage(row QueryRowDelete) *proto.QueryRowDeleteMessage {
+	message := &proto.QueryRowDeleteMessage{
+		Row:     string(row.RowKey),
+		Entries: make([]string, len(row.Entries)),
+	}
+
+	for i, entry := range row.Entries {
+		message.Entries[i] = string(entry)
+	}
+
 	return message
 }
 
@@ -260,6 +352,8 @@ func (decoder *queryMessageDecoder) VisitOpCode(opCode uint32) {
 	case MESSAGE_SELECT:
 		fallthrough
 	case MESSAGE_JOIN:
+		fallthrough
+	case MESSAGE_DELETE:
 		decoder.Query.OpCode = QueryOpCode(opCode)
 	}
 }
@@ -270,6 +364,7 @@ func (decoder *queryMessageDecoder) VisitTableKey(table string) {
 
 func (decoder *queryMessageDecoder) VisitJoin(message *proto.QueryJoinMessage) {
 	decoder.Query.Join.Rows = make([]QueryRowJoin, len(message.Rows))
+	decoder.Query.Join.LWW = message.Lww
 }
 
 func (decoder *queryMessageDecoder) LeaveJoin(*proto.QueryJoinMessage) {
@@ -280,8 +375,57 @@ func (decoder *queryMessageDecoder) VisitRowJoin(position int, message *proto.Qu
 	decoder.decodeRowJoin(row, message)
 }
 
+func (decoder *queryMessageDecoder) VisitDelete(message *proto.QueryDeleteMessage) {
+	decoder.Query.Delete.Rows = make([]QueryRowDelete, len(message.Rows))
+}
+
+func (decoder *queryMessageDecoder) LeaveDelete(*proto.QueryDeleteMessage) {
+}
+
+func (decoder *queryMessageDecoder) VisitRowDelete(position int, message *proto.QueryRowDeleteMessage) {
+	row := &decoder.Query.Delete.Rows[position]
+	row.RowKey = crdt.RowName(message.Row)
+
+	for _, entry := range message.Entries {
+		row.Entries = append(row.Entries, crdt.EntryName(entry))
+	}
+}
+
 func (decoder *queryMessageDecoder) VisitSelect(message *proto.QuerySelectMessage) {
 	decoder.Query.Select.Limit = message.Limit
+	decoder.Query.Select.Offset = message.Offset
+	decoder.Query.Select.Explain = message.Explain
+	decoder.Query.Select.IndexPath = crdt.IPFSPath(message.Index)
+	decoder.Query.Select.IndexTag = message.Tag
+	decoder.Query.Select.GroupBy = crdt.EntryName(message.GroupBy)
+
+	for _, aggregateMessage := range message.Aggregates {
+		aggregate := QueryAggregate{
+			Function: QueryAggregateFunction(aggregateMessage.Function),
+			Key:      crdt.EntryName(aggregateMessage.Key),
+		}
+		decoder.Query.Select.Aggregates = append(decoder.Query.Select.Aggregates, aggregate)
+	}
+
+	if message.TableJoin != nil {
+		decoder.Query.Select.TableJoin = QueryTableJoin{
+			TableKey: crdt.TableName(message.TableJoin.Table),
+			Left:     readQueryColumnMessage(message.TableJoin.Left),
+			Right:    readQueryColumnMessage(message.TableJoin.Right),
+		}
+	}
+
+	if message.OrderBy != nil {
+		decoder.Query.Select.OrderBy = QueryOrderBy{
+			Key:        crdt.EntryName(message.OrderBy.Key),
+			RowKey:     message.OrderBy.RowKey,
+			Descending: message.OrderBy.Descending,
+		}
+	}
+
+	for _, field := range message.Fields {
+		decoder.Query.Select.Fields = append(decoder.Query.Select.Fields, crdt.EntryName(field))
+	}
 }
 
 func (decoder *queryMessageDecoder) LeaveSelect(*proto.QuerySelectMessage) {
@@ -298,6 +442,8 @@ func (decoder *queryMessageDecoder) decodeWhere(frame whereBuilderFrame) {
 	case MESSAGE_NOOP:
 		fallthrough
 	case MESSAGE_PREDICATE:
+		fallthrough
+	case MESSAGE_NOT:
 		where.OpCode = QueryWhereOpCode(msg.OpCode)
 	default:
 		decoder.badWhereMessageOpCode(msg)
@@ -316,6 +462,17 @@ func (decoder *queryMessageDecoder) decodeRowJoin(row *QueryRowJoin, message *pr
 		point := crdt.PointText(messageEntry.Point)
 		row.Entries[entry] = point
 	}
+
+	if len(message.Counters) == 0 {
+		return
+	}
+
+	row.Counters = map[crdt.EntryName]int64{}
+
+	for _, messageCounter := range message.Counters {
+		entry := crdt.EntryName(messageCounter.Entry)
+		row.Counters[entry] = messageCounter.Delta
+	}
 }
 
 func (decoder *queryMessageDecoder) decodePredicate(pred *QueryPredicate, message *proto.QueryPredicateMessage) {
@@ -365,6 +522,12 @@ func visitMessage(message *proto.QueryMessage, visitor queryMessageVisitor) erro
 			visitor.VisitRowJoin(i, row)
 		}
 		visitor.LeaveJoin(message.Join)
+	case MESSAGE_DELETE:
+		visitor.VisitDelete(message.Delete)
+		for i, row := range message.Delete.Rows {
+			visitor.VisitRowDelete(i, row)
+		}
+		visitor.LeaveDelete(message.Delete)
 	case MESSAGE_SELECT:
 		visitor.VisitSelect(message.Select)
 
@@ -450,6 +613,7 @@ const (
 	MESSAGE_NOOP = uint32(iota)
 	MESSAGE_SELECT
 	MESSAGE_JOIN
+	MESSAGE_DELETE
 )
 
 const (
@@ -457,6 +621,7 @@ const (
 	MESSAGE_AND
 	MESSAGE_OR
 	MESSAGE_PREDICATE
+	MESSAGE_NOT
 )
 
 const (
@@ -8,6 +8,7 @@ import (
 	"testing/quick"
 
 	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/function"
 	"github.com/johnny-morrice/godless/internal/testutil"
 	"github.com/johnny-morrice/godless/log"
 	"github.com/pkg/errors"
@@ -29,6 +30,73 @@ func TestPredicateKeysLiterals(t *testing.T) {
 	testutil.AssertEquals(t, "Unexpected literals", []crdt.PointText{"Hi"}, pred.Literals())
 }
 
+func TestValidateFunctionArity(t *testing.T) {
+	context := ValidationContext{Functions: function.StandardFunctions()}
+
+	valid := []string{
+		"select books where num_lt(price, \"10\")",
+		"select books where time_between(@key, \"2017-01-01T00:00:00Z\", published)",
+		"select books where exists(price, published)",
+		"select books where and(exists(a), missing(@key))",
+	}
+
+	badArity := map[string]string{
+		"select books where num_lt(price)":              "num_lt requires 2 arguments but found 1",
+		"select books where num_between(price, \"10\")": "num_between requires 3 arguments but found 2",
+		"select books where not(num_between(a, \"1\"))": "num_between requires 3 arguments but found 2",
+	}
+
+	for _, source := range valid {
+		query, err := Compile(source)
+		testutil.AssertNil(t, err)
+		testutil.AssertNil(t, query.Validate(context))
+	}
+
+	for source, message := range badArity {
+		query, err := Compile(source)
+		testutil.AssertNil(t, err)
+
+		err = query.Validate(context)
+		testutil.AssertNonNil(t, err)
+		testutil.AssertEquals(t, "Unexpected error", message, err.Error())
+	}
+
+	unknown, err := Compile("select books where no_such_function(price)")
+	testutil.AssertNil(t, err)
+	testutil.AssertNonNil(t, unknown.Validate(context))
+
+	// A server may define its own function with a standard name.
+	custom := function.MakeFunctionNamespace()
+	err = custom.PutFunction(function.NamedMatchFunctionLambda{
+		Name:     "num_lt",
+		Function: function.MatchFunctionLambda(func([]string, []crdt.Entry) bool { return true }),
+	})
+	testutil.AssertNil(t, err)
+
+	overridden, err := Compile("select books where num_lt(price)")
+	testutil.AssertNil(t, err)
+	testutil.AssertNil(t, overridden.Validate(ValidationContext{Functions: custom}))
+}
+
+func TestCompilePredicateSpacing(t *testing.T) {
+	expected, err := Compile("select books where str_eq(a, \"b\")")
+	testutil.AssertNil(t, err)
+
+	sources := []string{
+		"select books where str_eq( a , \"b\" )",
+		"select books where str_eq(\n\t\ta,\n\t\t\"b\"\n\t)",
+	}
+
+	for _, source := range sources {
+		actual, err := Compile(source)
+		testutil.AssertNil(t, err)
+		testutil.AssertEquals(t, "Unexpected where clause", expected.Select.Where, actual.Select.Where)
+	}
+
+	_, err = Compile("select books where exists(\n\t\ta\n\t)")
+	testutil.AssertNil(t, err)
+}
+
 func TestParseQuery(t *testing.T) {
 	if testing.Short() {
 		t.SkipNow()
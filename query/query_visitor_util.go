@@ -36,6 +36,17 @@ func (visitor *NoJoinVisitor) LeaveJoin(*QueryJoin) {
 func (visitor *NoJoinVisitor) VisitRowJoin(int, *QueryRowJoin) {
 }
 
+type NoDeleteVisitor struct{}
+
+func (visitor *NoDeleteVisitor) VisitDelete(*QueryDelete) {
+}
+
+func (visitor *NoDeleteVisitor) LeaveDelete(*QueryDelete) {
+}
+
+func (visitor *NoDeleteVisitor) VisitRowDelete(int, *QueryRowDelete) {
+}
+
 type NoDebugVisitor struct{}
 
 func (visitor *NoDebugVisitor) VisitAST(*QueryAST) {
@@ -21,6 +21,8 @@ import (
 // VisitTableKey(crdt.TableName)
 // VisitJoin(*QueryJoin)
 // VisitRowJoin(int, *QueryRowJoin)
+// VisitDelete(*QueryDelete)
+// VisitRowDelete(int, *QueryRowDelete)
 // VisitSelect(*QuerySelect)
 // VisitWhere(int, *QueryWhere)
 // LeaveWhere(*QueryWhere)
@@ -29,8 +31,15 @@ import (
 type queryPrinter struct {
 	NoDebugVisitor
 	ErrorCollectVisitor
-	output    io.Writer
-	tabIndent int
+	output     io.Writer
+	tabIndent  int
+	aggregates []QueryAggregate
+	explain    bool
+	indentText string
+	compact    bool
+	// pendingSpace separates tokens on a single line in compact layout.
+	pendingSpace bool
+	last         byte
 }
 
 func (printer *queryPrinter) VisitPublicKeyHash(hash crypto.PublicKeyHash) {
@@ -42,9 +51,14 @@ func (printer *queryPrinter) VisitPublicKeyHash(hash crypto.PublicKeyHash) {
 func (printer *queryPrinter) VisitOpCode(opCode QueryOpCode) {
 	switch opCode {
 	case SELECT:
+		if printer.explain {
+			printer.write("explain ")
+		}
 		printer.write("select")
 	case JOIN:
 		printer.write("join")
+	case DELETE:
+		printer.write("delete")
 	default:
 		printer.CollectError(fmt.Errorf("Unknown "))
 		return
@@ -52,15 +66,52 @@ func (printer *queryPrinter) VisitOpCode(opCode QueryOpCode) {
 }
 
 func (printer *queryPrinter) VisitTableKey(table crdt.TableName) {
+	if len(printer.aggregates) > 0 {
+		printer.writeAggregates()
+		printer.write(" from")
+	}
+
 	printer.write(" ")
 	printer.write(table)
 }
 
+func (printer *queryPrinter) writeAggregates() {
+	for i, aggregate := range printer.aggregates {
+		if i > 0 {
+			printer.write(",")
+		}
+
+		printer.write(" ")
+
+		switch aggregate.Function {
+		case COUNT:
+			printer.write("count")
+		case DISTINCT:
+			printer.write("distinct ")
+			printer.writeKey(string(aggregate.Key))
+		case MIN:
+			printer.write("min(")
+			printer.writeKey(string(aggregate.Key))
+			printer.write(")")
+		case MAX:
+			printer.write("max(")
+			printer.writeKey(string(aggregate.Key))
+			printer.write(")")
+		default:
+			printer.CollectError(fmt.Errorf("Unknown aggregate function: %v", aggregate.Function))
+		}
+	}
+}
+
 func (printer *queryPrinter) VisitJoin(join *QueryJoin) {
 	if join.IsEmpty() {
 		return
 	}
 
+	if join.LWW {
+		printer.write(" lww")
+	}
+
 	printer.write(" rows")
 	printer.indent(1)
 }
@@ -99,7 +150,7 @@ func (printer *queryPrinter) VisitRowJoin(position int, row *QueryRowJoin) {
 	for _, k := range keys {
 		entry := crdt.EntryName(k)
 		point := row.Entries[entry]
-		printer.write(", ")
+		printer.write(",")
 		printer.indentWhitespace()
 		printer.writeKey(k)
 		printer.write("=")
@@ -107,18 +158,116 @@ func (printer *queryPrinter) VisitRowJoin(position int, row *QueryRowJoin) {
 		printer.writeText(string(point))
 		printer.write("\"")
 	}
+
+	counterKeys := make([]string, 0, len(row.Counters))
+	for entry, _ := range row.Counters {
+		counterKeys = append(counterKeys, string(entry))
+	}
+	sort.Strings(counterKeys)
+
+	for _, k := range counterKeys {
+		delta := row.Counters[crdt.EntryName(k)]
+		printer.write(",")
+		printer.indentWhitespace()
+		printer.writeKey(k)
+
+		if delta < 0 {
+			printer.write(" -= ")
+			printer.write(-delta)
+		} else {
+			printer.write(" += ")
+			printer.write(delta)
+		}
+	}
+	printer.indent(-1)
+	printer.indentWhitespace()
+	printer.write(")")
+}
+
+func (printer *queryPrinter) VisitDelete(qdelete *QueryDelete) {
+	if qdelete.IsEmpty() {
+		return
+	}
+
+	printer.write(" rows")
+	printer.indent(1)
+}
+
+func (printer *queryPrinter) LeaveDelete(qdelete *QueryDelete) {
+	if qdelete.IsEmpty() {
+		return
+	}
+
+	printer.indent(-1)
+}
+
+func (printer *queryPrinter) VisitRowDelete(position int, row *QueryRowDelete) {
+	if position > 0 {
+		printer.write(",")
+	}
+
+	printer.indentWhitespace()
+	printer.write("(")
+	printer.indent(1)
+	printer.indentWhitespace()
+	printer.write("@key")
+	printer.write("=")
+	printer.writeKey(string(row.RowKey))
+
+	for _, entry := range row.Entries {
+		printer.write(",")
+		printer.indentWhitespace()
+		printer.writeKey(string(entry))
+	}
+
 	printer.indent(-1)
 	printer.indentWhitespace()
 	printer.write(")")
 }
 
 func (printer *queryPrinter) VisitSelect(querySelect *QuerySelect) {
-	if querySelect.IsEmpty() {
+	if !querySelect.TableJoin.IsEmpty() {
+		printer.writeTableJoin(querySelect.TableJoin)
+	}
+
+	if !crdt.IsNilPath(querySelect.IndexPath) {
+		printer.write(" at \"")
+		printer.writeText(string(querySelect.IndexPath))
+		printer.write("\"")
+	}
+
+	if querySelect.IndexTag != "" {
+		printer.write(" at tag \"")
+		printer.writeText(querySelect.IndexTag)
+		printer.write("\"")
+	}
+
+	if querySelect.Where.IsEmpty() {
 		return
 	}
 
-	printer.write(" where ")
+	printer.write(" where")
+
+}
 
+func (printer *queryPrinter) writeTableJoin(join QueryTableJoin) {
+	printer.write(" join ")
+	printer.write(join.TableKey)
+	printer.write(" on ")
+	printer.writeColumn(join.Left)
+	printer.write(" = ")
+	printer.writeColumn(join.Right)
+}
+
+func (printer *queryPrinter) writeColumn(column QueryColumn) {
+	printer.write(column.TableKey)
+	printer.write(".")
+
+	if column.RowKey {
+		printer.write("@key")
+	} else {
+		printer.writeKey(string(column.Entry))
+	}
 }
 
 func (printer *queryPrinter) LeaveSelect(querySelect *QuerySelect) {
@@ -127,9 +276,56 @@ func (printer *queryPrinter) LeaveSelect(querySelect *QuerySelect) {
 	}
 
 	printer.indent(1)
-	printer.indentWhitespace()
-	printer.write("limit ")
-	printer.write(querySelect.Limit)
+
+	if len(querySelect.Fields) > 0 {
+		printer.indentWhitespace()
+		printer.write("fields (")
+
+		for i, field := range querySelect.Fields {
+			if i > 0 {
+				printer.write(", ")
+			}
+
+			printer.writeKey(string(field))
+		}
+
+		printer.write(")")
+	}
+
+	if querySelect.GroupBy != "" {
+		printer.indentWhitespace()
+		printer.write("group by ")
+		printer.writeKey(string(querySelect.GroupBy))
+	}
+
+	orderBy := querySelect.OrderBy
+	if !orderBy.IsEmpty() {
+		printer.indentWhitespace()
+		printer.write("order by ")
+
+		if orderBy.RowKey {
+			printer.write("@key")
+		} else {
+			printer.writeKey(string(orderBy.Key))
+		}
+
+		if orderBy.Descending {
+			printer.write(" desc")
+		}
+	}
+
+	if querySelect.Limit > 0 {
+		printer.indentWhitespace()
+		printer.write("limit ")
+		printer.write(querySelect.Limit)
+	}
+
+	if querySelect.Offset > 0 {
+		printer.indentWhitespace()
+		printer.write("offset ")
+		printer.write(querySelect.Offset)
+	}
+
 	printer.indent(-1)
 }
 
@@ -139,7 +335,7 @@ func (printer *queryPrinter) VisitWhere(position int, where *QueryWhere) {
 	}
 
 	if position > 0 {
-		printer.write(", ")
+		printer.write(",")
 	}
 
 	printer.indent(1)
@@ -150,6 +346,8 @@ func (printer *queryPrinter) VisitWhere(position int, where *QueryWhere) {
 		printer.write("and(")
 	case OR:
 		printer.write("or(")
+	case NOT:
+		printer.write("not(")
 	case PREDICATE:
 	default:
 		printer.BadWhereOpCode(position, where)
@@ -185,7 +383,7 @@ func (printer *queryPrinter) VisitPredicate(pred *QueryPredicate) {
 
 	for _, val := range pred.Values {
 		if !first {
-			printer.write(", ")
+			printer.write(",")
 		}
 
 		if val.IsKey {
@@ -213,13 +411,24 @@ func (printer *queryPrinter) predicateLiteral(lit PredicateValue) {
 }
 
 func (printer *queryPrinter) indentWhitespace() {
+	if printer.compact {
+		printer.pendingSpace = true
+		return
+	}
+
 	printer.newline()
 	printer.tabs()
 }
 
 func (printer *queryPrinter) tabs() {
+	indentText := printer.indentText
+
+	if indentText == "" {
+		indentText = "\t"
+	}
+
 	for i := 0; i < printer.tabIndent; i++ {
-		printer.write("\t")
+		printer.write(indentText)
 	}
 }
 
@@ -232,7 +441,27 @@ func (printer *queryPrinter) indent(indent int) {
 }
 
 func (printer *queryPrinter) write(token interface{}) {
-	fmt.Fprintf(printer.output, "%v", token)
+	text := fmt.Sprintf("%v", token)
+
+	if text == "" {
+		return
+	}
+
+	if printer.pendingSpace {
+		printer.pendingSpace = false
+
+		if !printer.isTight(text) {
+			fmt.Fprint(printer.output, " ")
+		}
+	}
+
+	fmt.Fprint(printer.output, text)
+	printer.last = text[len(text)-1]
+}
+
+// isTight is true when no space is needed before the text.
+func (printer *queryPrinter) isTight(text string) bool {
+	return printer.last == '(' || printer.last == ' ' || text[0] == ')'
 }
 
 func (printer *queryPrinter) writeKey(token string) {
@@ -277,6 +506,7 @@ type queryFlattener struct {
 	publicKeys []crypto.PublicKeyHash
 	opCode     QueryOpCode
 	join       QueryJoin
+	qdelete    QueryDelete
 	slct       QuerySelect
 	allClauses []QueryWhere
 }
@@ -310,6 +540,16 @@ func (visitor *queryFlattener) LeaveJoin(*QueryJoin) {
 func (visitor *queryFlattener) VisitRowJoin(int, *QueryRowJoin) {
 }
 
+func (visitor *queryFlattener) VisitDelete(qdelete *QueryDelete) {
+	visitor.qdelete = *qdelete
+}
+
+func (visitor *queryFlattener) LeaveDelete(*QueryDelete) {
+}
+
+func (visitor *queryFlattener) VisitRowDelete(int, *QueryRowDelete) {
+}
+
 func (visitor *queryFlattener) VisitWhere(position int, where *QueryWhere) {
 	visitor.allClauses = append(visitor.allClauses, *where)
 }
@@ -324,6 +564,15 @@ func (visitor *queryFlattener) Equals(other *queryFlattener) bool {
 	ok := visitor.opCode == other.opCode
 	ok = ok && visitor.tableName == other.tableName
 	ok = ok && visitor.slct.Limit == other.slct.Limit
+	ok = ok && visitor.slct.Offset == other.slct.Offset
+	ok = ok && visitor.slct.OrderBy == other.slct.OrderBy
+	ok = ok && visitor.slct.fieldsEqual(other.slct)
+	ok = ok && visitor.slct.GroupBy == other.slct.GroupBy
+	ok = ok && visitor.slct.TableJoin == other.slct.TableJoin
+	ok = ok && visitor.slct.Explain == other.slct.Explain
+	ok = ok && visitor.slct.IndexPath == other.slct.IndexPath
+	ok = ok && visitor.slct.IndexTag == other.slct.IndexTag
+	ok = ok && visitor.slct.aggregatesEqual(other.slct)
 	ok = ok && len(visitor.allClauses) == len(other.allClauses)
 
 	if !ok {
@@ -334,6 +583,10 @@ func (visitor *queryFlattener) Equals(other *queryFlattener) bool {
 		return false
 	}
 
+	if !visitor.qdelete.equals(other.qdelete) {
+		return false
+	}
+
 	for i, myClause := range visitor.allClauses {
 		theirClause := other.allClauses[i]
 
@@ -349,8 +602,10 @@ type queryValidator struct {
 	NoDebugVisitor
 	ErrorCollectVisitor
 	NoJoinVisitor
+	NoDeleteVisitor
 	Functions  function.FunctionNamespace
 	whereStack []*QueryWhere
+	tableKey   crdt.TableName
 }
 
 func (visitor *queryValidator) VisitPublicKeyHash(hash crypto.PublicKeyHash) {
@@ -361,6 +616,7 @@ func (visitor *queryValidator) VisitOpCode(opCode QueryOpCode) {
 	switch opCode {
 	case SELECT:
 	case JOIN:
+	case DELETE:
 		// Okay!
 	default:
 		visitor.BadOpcode(opCode)
@@ -371,9 +627,80 @@ func (visitor *queryValidator) VisitTableKey(tableKey crdt.TableName) {
 	if tableKey == "" {
 		visitor.badTableName(tableKey)
 	}
+
+	visitor.tableKey = tableKey
+}
+
+func (visitor *queryValidator) VisitSelect(querySelect *QuerySelect) {
+	var distinct []crdt.EntryName
+
+	for _, aggregate := range querySelect.Aggregates {
+		switch aggregate.Function {
+		case COUNT:
+			if aggregate.Key != "" {
+				visitor.CollectError(errors.New("count takes no entry"))
+			}
+		case DISTINCT:
+			distinct = append(distinct, aggregate.Key)
+			fallthrough
+		case MIN, MAX:
+			if aggregate.Key == "" {
+				visitor.CollectError(fmt.Errorf("Aggregate %v needs an entry", aggregate.Function))
+			}
+		default:
+			visitor.CollectError(fmt.Errorf("Unknown aggregate function: %v", aggregate.Function))
+		}
+	}
+
+	if len(distinct) > 1 {
+		visitor.CollectError(errors.New("Only one distinct entry may be selected"))
+	}
+
+	if !crdt.IsNilPath(querySelect.IndexPath) && querySelect.IndexTag != "" {
+		visitor.CollectError(errors.New("Select may be at an index path or a tag, not both"))
+	}
+
+	if len(distinct) == 1 && querySelect.GroupBy != "" && distinct[0] != querySelect.GroupBy {
+		visitor.CollectError(errors.New("distinct entry must match group by"))
+	}
+
+	if !querySelect.TableJoin.IsEmpty() {
+		visitor.validateTableJoin(querySelect.TableJoin)
+	}
+
+	if querySelect.IsAggregate() {
+		if len(querySelect.Fields) > 0 {
+			visitor.CollectError(errors.New("fields cannot be used with aggregates"))
+		}
+
+		if !querySelect.OrderBy.IsEmpty() {
+			visitor.CollectError(errors.New("order by cannot be used with aggregates"))
+		}
+	}
 }
 
-func (visitor *queryValidator) VisitSelect(*QuerySelect) {
+func (visitor *queryValidator) validateTableJoin(join QueryTableJoin) {
+	if join.TableKey == "" || join.TableKey == visitor.tableKey {
+		visitor.CollectError(fmt.Errorf("Cannot join table '%s' to itself", visitor.tableKey))
+		return
+	}
+
+	left := join.Left.TableKey
+	right := join.Right.TableKey
+
+	if left == right {
+		visitor.CollectError(errors.New("Join columns must come from different tables"))
+	}
+
+	for _, column := range []QueryColumn{join.Left, join.Right} {
+		if column.TableKey != visitor.tableKey && column.TableKey != join.TableKey {
+			visitor.CollectError(fmt.Errorf("Unknown table in join column: '%s'", column.TableKey))
+		}
+
+		if !column.RowKey && column.Entry == "" {
+			visitor.CollectError(errors.New("Join column needs an entry or @key"))
+		}
+	}
 }
 
 func (visitor *queryValidator) LeaveSelect(*QuerySelect) {
@@ -387,6 +714,10 @@ func (visitor *queryValidator) VisitWhere(position int, where *QueryWhere) {
 	case OR:
 	case PREDICATE:
 		// Okay!
+	case NOT:
+		if len(where.Clauses) != 1 {
+			visitor.CollectError(fmt.Errorf("not requires exactly one clause, found %d", len(where.Clauses)))
+		}
 	default:
 		visitor.BadWhereOpCode(position, where)
 	}
@@ -435,10 +766,19 @@ func (visitor *queryValidator) VisitPredicate(predicate *QueryPredicate) {
 	tip := visitor.whereStack[visitor.getTipIndex()]
 
 	if tip.OpCode == PREDICATE {
-		_, err := visitor.Functions.GetFunction(predicate.FunctionName)
+		matcher, err := visitor.Functions.GetFunction(predicate.FunctionName)
 
 		if err != nil {
 			visitor.CollectError(err)
+			return
+		}
+
+		if arity, ok := matcher.(function.ArityFunction); ok {
+			err = arity.CheckArity(predicate.argumentCount())
+
+			if err != nil {
+				visitor.CollectError(err)
+			}
 		}
 	}
 
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/johnny-morrice/godless/crdt"
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestCompileScript(t *testing.T) {
+	const source = `-- Seed the cars table.
+join cars rows (@key=car1, driver="Mr -- Fast"); -- The first car.
+join cars rows (@key=car2, driver="Mrs; Faster") ;
+
+-- Check they are there.
+select cars limit 2;
+`
+
+	script, err := CompileScript(source)
+
+	testutil.AssertNil(t, err)
+	testutil.AssertLenEquals(t, 3, script)
+
+	expected := []*Query{
+		&Query{
+			OpCode:   JOIN,
+			TableKey: "cars",
+			Join: QueryJoin{
+				Rows: []QueryRowJoin{
+					QueryRowJoin{
+						RowKey:  "car1",
+						Entries: map[crdt.EntryName]crdt.PointText{"driver": "Mr -- Fast"},
+					},
+				},
+			},
+		},
+		&Query{
+			OpCode:   JOIN,
+			TableKey: "cars",
+			Join: QueryJoin{
+				Rows: []QueryRowJoin{
+					QueryRowJoin{
+						RowKey:  "car2",
+						Entries: map[crdt.EntryName]crdt.PointText{"driver": "Mrs; Faster"},
+					},
+				},
+			},
+		},
+		&Query{
+			OpCode:   SELECT,
+			TableKey: "cars",
+			Select:   QuerySelect{Limit: 2},
+		},
+	}
+
+	for i, query := range script {
+		testutil.Assert(t, "Unexpected query", expected[i].Equals(query))
+	}
+}
+
+func TestCompileComments(t *testing.T) {
+	const source = `-- Fast cars.
+select cars -- All of them.
+	where str_eq(driver, "Mr Fast") -- Only the fast one.
+	limit 1`
+
+	query, err := Compile(source)
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected limit", uint32(1), query.Select.Limit)
+}
+
+func TestCompileCommentAfterKey(t *testing.T) {
+	expected, err := Compile(`join cars rows (@key=car1, driver="Mr Fast")`)
+	testutil.AssertNil(t, err)
+
+	actual, err := Compile(`join cars rows (@key=car1--note
+		, driver="Mr Fast")`)
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Unexpected query", expected.Equals(actual))
+
+	hyphen, err := Compile("join cars rows (@key=car-1)")
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected row key", crdt.RowName("car-1"), hyphen.Join.Rows[0].RowKey)
+}
+
+func TestCompileScriptInvalid(t *testing.T) {
+	invalid := []string{
+		"select cars;; select cars",
+		"select cars select cars",
+		"select cars limit ?; select cars",
+	}
+
+	for _, source := range invalid {
+		_, err := CompileScript(source)
+		testutil.AssertNonNil(t, err)
+	}
+
+	_, err := Compile("select cars; select drivers")
+	testutil.AssertNonNil(t, err)
+
+	_, err = Compile("-- Nothing here.")
+	testutil.AssertNonNil(t, err)
+}
+
+func TestFormatScript(t *testing.T) {
+	const source = `join cars rows (@key=car1) -- The first car.
+; select   cars limit 2; -- Done.
+`
+	const expected = `-- The first car.
+join cars rows (@key=@"car1");
+
+select cars limit 2;
+-- Done.`
+
+	actual, err := FormatSource(source, FormatOptions{Compact: true})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected format", expected, actual)
+
+	again, err := FormatSource(actual, FormatOptions{Compact: true})
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Format was not stable", expected, again)
+}
@@ -0,0 +1,59 @@
+package query
+
+// Statement is a query that has been parsed once, so that it can be compiled
+// many times with different variables.
+type Statement struct {
+	Source string
+	parser *QueryParser
+	ast    *QueryAST
+}
+
+func Prepare(source string) (*Statement, error) {
+	parser, err := parseSource(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := parser.singleStatement()
+
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{
+		Source: source,
+		parser: parser,
+		ast:    ast,
+	}
+
+	return statement, nil
+}
+
+// PlaceholderCount is the number of variables Bind expects.
+func (statement *Statement) PlaceholderCount() int {
+	return len(statement.ast.Placeholders)
+}
+
+// Bind compiles the statement with the variables.  Each Bind compiles a copy
+// of the AST, so a Statement may be bound by many goroutines at once.
+func (statement *Statement) Bind(variables ...interface{}) (*Query, error) {
+	context := CompileContext{
+		Variables: variables,
+	}
+
+	return statement.bind(context)
+}
+
+// BindNamed compiles a statement that has named placeholders.
+func (statement *Statement) BindNamed(variables map[string]interface{}) (*Query, error) {
+	context := CompileContext{
+		NamedVariables: variables,
+	}
+
+	return statement.bind(context)
+}
+
+func (statement *Statement) bind(context CompileContext) (*Query, error) {
+	return compileStatement(statement.parser, statement.ast.copy(), context)
+}
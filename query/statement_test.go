@@ -0,0 +1,107 @@
+package query
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/johnny-morrice/godless/internal/testutil"
+)
+
+func TestStatementBind(t *testing.T) {
+	const source = "join cars lww rows (@key=??, driver=?, mileage += ?)"
+
+	bindings := [][]interface{}{
+		[]interface{}{"car1", "Mr Fast", 10},
+		[]interface{}{"car2", "Mrs Faster", 20},
+		[]interface{}{"car1", "Mr Fast", 10},
+	}
+
+	statement, err := Prepare(source)
+	testutil.AssertNil(t, err)
+	testutil.AssertEquals(t, "Unexpected placeholder count", 3, statement.PlaceholderCount())
+
+	for i, variables := range bindings {
+		expected, err := Compile(source, variables...)
+		testutil.AssertNil(t, err)
+
+		actual, err := statement.Bind(variables...)
+
+		if err != nil {
+			t.Error("Error at", i, ":", err)
+			continue
+		}
+
+		testutil.Assert(t, "Unexpected query", expected.Equals(actual))
+	}
+
+	_, err = statement.Bind("car1")
+	testutil.AssertNonNil(t, err)
+}
+
+func TestPrepareInvalid(t *testing.T) {
+	statement, err := Prepare("select cars where")
+
+	testutil.AssertNonNil(t, err)
+	testutil.Assert(t, "Unexpected statement", statement == nil)
+}
+
+func TestStatementBindParallel(t *testing.T) {
+	const source = "select cars where str_eq(driver, ?) limit ?"
+	const bindCount = 50
+
+	statement, err := Prepare(source)
+	testutil.AssertNil(t, err)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(bindCount)
+
+	for i := 0; i < bindCount; i++ {
+		driver := fmt.Sprintf("Driver %d", i)
+		limit := i + 1
+
+		go func() {
+			defer wg.Done()
+
+			expected, err := Compile(source, driver, limit)
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			actual, err := statement.Bind(driver, limit)
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !expected.Equals(actual) {
+				t.Error("Unexpected query for", driver)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestQueryCopy(t *testing.T) {
+	statement, err := Prepare("select cars where str_eq(driver, ?) limit ?")
+	testutil.AssertNil(t, err)
+
+	first, err := statement.Bind("Mr Fast", 1)
+	testutil.AssertNil(t, err)
+
+	dup := first.Copy()
+	testutil.Assert(t, "Unexpected copy", first.Equals(dup))
+
+	dup.Select.Where.Predicate.Values[0] = PredicateLiteral("Mrs Faster")
+	dup.AST.Placeholders[0].text = "Mrs Faster"
+	testutil.Assert(t, "Copy changed the query", !first.Equals(dup))
+
+	second, err := statement.Bind("Mr Fast", 1)
+	testutil.AssertNil(t, err)
+	testutil.Assert(t, "Copy changed the statement", first.Equals(second))
+	testutil.AssertEquals(t, "Copy changed the AST", "Mr Fast", first.AST.Select.Where.Predicate.Values[1].text)
+}